@@ -0,0 +1,262 @@
+package admin
+
+import (
+	"encoding/json"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// AdminStore is the storage of the admin API, as implemented by the
+// repositories.
+type AdminStore interface {
+	ListUsers(filter map[string]any, search string, paging *clients.Paging) ([]domain.User, error)
+	GetUser(conditions map[string]any) (*domain.User, error)
+	UpdateUser(id uuid.UUID, fields map[string]any) error
+	SaveAudit(entry *domain.AuditEntry) error
+	ListAudit(filter map[string]any, paging *clients.Paging) ([]domain.AuditEntry, error)
+}
+
+//go:generate mockery --name AdminRepo
+type AdminRepo interface {
+	AdminStore
+	Transaction(fn func(repo AdminRepo) error) error
+}
+
+// ItemLister lists the items of a user, with the filters of the item listing.
+//
+//go:generate mockery --name ItemLister
+type ItemLister interface {
+	GetAllItem(tenant domain.Tenant, filter *domain.ItemFilter, paging *clients.Paging) ([]domain.Item, error)
+}
+
+// SessionRevoker signs a user out of every device.
+//
+//go:generate mockery --name SessionRevoker
+type SessionRevoker interface {
+	RevokeRefreshTokens(conditions map[string]any, revokedAt time.Time) error
+}
+
+// UserCache drops cached users, so changes are seen by the auth middleware.
+//
+//go:generate mockery --name UserCache
+type UserCache interface {
+	Invalidate(id uuid.UUID)
+}
+
+type adminService struct {
+	adminRepo AdminRepo
+	items     ItemLister
+	sessions  SessionRevoker
+	cache     UserCache
+}
+
+func NewAdminService(repo AdminRepo, items ItemLister, sessions SessionRevoker, cache UserCache) *adminService {
+	return &adminService{
+		adminRepo: repo,
+		items:     items,
+		sessions:  sessions,
+		cache:     cache,
+	}
+}
+
+func (s *adminService) ListUsers(actorID uuid.UUID, filter *domain.UserFilter, paging *clients.Paging) ([]domain.User, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	conditions := map[string]any{}
+	if filter.Status != nil {
+		conditions["status"] = *filter.Status
+	}
+	if filter.Role != "" {
+		role, _ := domain.ParseUserRole(filter.Role)
+		conditions["role"] = role
+	}
+
+	if err := s.audit(s.adminRepo, actorID, domain.AuditListUsers, nil, filter); err != nil {
+		return nil, err
+	}
+
+	users, err := s.adminRepo.ListUsers(conditions, filter.Query, paging)
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.User{}.TableName(), err)
+	}
+
+	return users, nil
+}
+
+func (s *adminService) GetUser(actorID, id uuid.UUID) (*domain.User, error) {
+	if err := s.audit(s.adminRepo, actorID, domain.AuditViewUser, &id, nil); err != nil {
+		return nil, err
+	}
+
+	user, err := s.adminRepo.GetUser(map[string]any{"id": id})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	return user, nil
+}
+
+// BanUser deactivates a user and signs them out everywhere. Their data is
+// kept, so unbanning gives them their account back. Users who deleted their
+// account can not be banned, as unbanning would bring the account back.
+func (s *adminService) BanUser(actorID, id uuid.UUID) error {
+	if actorID == id {
+		return domain.ErrCannotModerateSelf
+	}
+
+	now := time.Now()
+	err := s.adminRepo.Transaction(func(repo AdminRepo) error {
+		user, err := repo.GetUser(map[string]any{"id": id})
+		if err != nil {
+			return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+		}
+
+		if user.Status == clients.Deleted && user.BannedAt == nil {
+			return domain.ErrUserDeleted
+		}
+
+		if err := repo.UpdateUser(id, map[string]any{"status": clients.Deleted, "banned_at": now}); err != nil {
+			return clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+		}
+
+		return s.audit(repo, actorID, domain.AuditBanUser, &id, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cache.Invalidate(id)
+
+	if err := s.sessions.RevokeRefreshTokens(map[string]any{"user_id": id}, now); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.RefreshToken{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// UnbanUser reactivates a banned user. Users who deleted their account can
+// not be brought back this way.
+func (s *adminService) UnbanUser(actorID, id uuid.UUID) error {
+	err := s.adminRepo.Transaction(func(repo AdminRepo) error {
+		user, err := repo.GetUser(map[string]any{"id": id})
+		if err != nil {
+			return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+		}
+
+		if user.BannedAt == nil {
+			return domain.ErrUserNotBanned
+		}
+
+		if err := repo.UpdateUser(id, map[string]any{"status": clients.Active, "banned_at": nil}); err != nil {
+			return clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+		}
+
+		return s.audit(repo, actorID, domain.AuditUnbanUser, &id, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cache.Invalidate(id)
+
+	return nil
+}
+
+func (s *adminService) ChangeRole(actorID, id uuid.UUID, data *domain.RoleChange) error {
+	role, err := domain.ParseUserRole(data.Role)
+	if err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	if actorID == id {
+		return domain.ErrCannotModerateSelf
+	}
+
+	err = s.adminRepo.Transaction(func(repo AdminRepo) error {
+		user, err := repo.GetUser(map[string]any{"id": id})
+		if err != nil {
+			return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+		}
+
+		if err := repo.UpdateUser(id, map[string]any{"role": role}); err != nil {
+			return clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+		}
+
+		return s.audit(repo, actorID, domain.AuditChangeRole, &id, map[string]string{
+			"from": user.Role.String(),
+			"to":   role.String(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cache.Invalidate(id)
+
+	return nil
+}
+
+// GetUserItems lists the personal items of any user, trashed ones excluded
+// like in the listing of the user themselves.
+func (s *adminService) GetUserItems(actorID, id uuid.UUID, filter *domain.ItemFilter, paging *clients.Paging) ([]domain.Item, error) {
+	if err := s.audit(s.adminRepo, actorID, domain.AuditViewItems, &id, filter); err != nil {
+		return nil, err
+	}
+
+	return s.items.GetAllItem(domain.Tenant{UserID: id}, filter, paging)
+}
+
+func (s *adminService) GetAuditLog(actorID uuid.UUID, filter *domain.AuditFilter, paging *clients.Paging) ([]domain.AuditEntry, error) {
+	conditions := map[string]any{}
+	if filter.ActorID != nil {
+		conditions["actor_id"] = *filter.ActorID
+	}
+	if filter.TargetID != nil {
+		conditions["target_id"] = *filter.TargetID
+	}
+	if filter.Action != "" {
+		conditions["action"] = filter.Action
+	}
+
+	if err := s.audit(s.adminRepo, actorID, domain.AuditViewAudit, nil, filter); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.adminRepo.ListAudit(conditions, paging)
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.AuditEntry{}.TableName(), err)
+	}
+
+	return entries, nil
+}
+
+// audit records an admin action. Reads are recorded before they happen and
+// changes in the transaction making them, so no action goes unrecorded.
+func (s *adminService) audit(repo AdminRepo, actorID uuid.UUID, action string, targetID *uuid.UUID, details any) error {
+	entry := &domain.AuditEntry{
+		ID:       uuid.New(),
+		ActorID:  actorID,
+		Action:   action,
+		TargetID: targetID,
+		Details:  "{}",
+	}
+
+	if details != nil {
+		data, err := json.Marshal(details)
+		if err != nil {
+			return clients.ErrInternal(err)
+		}
+		entry.Details = string(data)
+	}
+
+	if err := repo.SaveAudit(entry); err != nil {
+		return clients.ErrCannotCreateEntity(entry.TableName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package admin_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	service "todo-app/admin"
+	"todo-app/admin/mocks"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type adminMocks struct {
+	adminRepo *mocks.AdminRepo
+	items     *mocks.ItemLister
+	sessions  *mocks.SessionRevoker
+	cache     *mocks.UserCache
+}
+
+// adminService lists the methods under test, as the service type itself is
+// unexported.
+type adminService interface {
+	ListUsers(actorID uuid.UUID, filter *domain.UserFilter, paging *clients.Paging) ([]domain.User, error)
+	BanUser(actorID, id uuid.UUID) error
+	UnbanUser(actorID, id uuid.UUID) error
+	ChangeRole(actorID, id uuid.UUID, data *domain.RoleChange) error
+	GetUserItems(actorID, id uuid.UUID, filter *domain.ItemFilter, paging *clients.Paging) ([]domain.Item, error)
+}
+
+// setupAdminService makes the repository mock run transactional callbacks
+// against itself, like the real repository does.
+func setupAdminService() (*adminMocks, adminService) {
+	m := &adminMocks{
+		adminRepo: new(mocks.AdminRepo),
+		items:     new(mocks.ItemLister),
+		sessions:  new(mocks.SessionRevoker),
+		cache:     new(mocks.UserCache),
+	}
+	m.adminRepo.On("Transaction", mock.Anything).Return(func(fn func(repo service.AdminRepo) error) error {
+		return fn(m.adminRepo)
+	}).Maybe()
+
+	return m, service.NewAdminService(m.adminRepo, m.items, m.sessions, m.cache)
+}
+
+func auditOf(action string, targetID *uuid.UUID) any {
+	return mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		if entry.Action != action {
+			return false
+		}
+		if targetID == nil {
+			return entry.TargetID == nil
+		}
+
+		return entry.TargetID != nil && *entry.TargetID == *targetID
+	})
+}
+
+func TestBanUser(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		m.adminRepo.On("GetUser", map[string]any{"id": userID}).Return(&domain.User{ID: userID, Status: clients.Active}, nil).Once()
+		m.adminRepo.On("UpdateUser", userID, mock.MatchedBy(func(fields map[string]any) bool {
+			_, banned := fields["banned_at"].(time.Time)
+			return fields["status"] == clients.Deleted && banned
+		})).Return(nil).Once()
+		m.adminRepo.On("SaveAudit", auditOf(domain.AuditBanUser, &userID)).Return(nil).Once()
+		m.cache.On("Invalidate", userID).Once()
+		m.sessions.On("RevokeRefreshTokens", map[string]any{"user_id": userID}, mock.Anything).Return(nil).Once()
+
+		err := adminService.BanUser(adminID, userID)
+
+		assert.NoError(t, err)
+		m.adminRepo.AssertExpectations(t)
+		m.cache.AssertExpectations(t)
+		m.sessions.AssertExpectations(t)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		err := adminService.BanUser(adminID, adminID)
+
+		assert.Equal(t, domain.ErrCannotModerateSelf, err)
+		m.adminRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("deleted by themselves", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		deleted := &domain.User{ID: userID, Status: clients.Deleted}
+		m.adminRepo.On("GetUser", map[string]any{"id": userID}).Return(deleted, nil).Twice()
+
+		err := adminService.BanUser(adminID, userID)
+		assert.Equal(t, domain.ErrUserDeleted, err)
+
+		// The refused ban leaves nothing for an unban to reactivate
+		err = adminService.UnbanUser(adminID, userID)
+		assert.Equal(t, domain.ErrUserNotBanned, err)
+
+		m.adminRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+		m.sessions.AssertNotCalled(t, "RevokeRefreshTokens", mock.Anything, mock.Anything)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		m.adminRepo.On("GetUser", map[string]any{"id": userID}).Return(nil, clients.ErrRecordNotFound).Once()
+
+		err := adminService.BanUser(adminID, userID)
+
+		assert.Error(t, err)
+		m.sessions.AssertNotCalled(t, "RevokeRefreshTokens", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUnbanUser(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		bannedAt := time.Now()
+		m.adminRepo.On("GetUser", map[string]any{"id": userID}).Return(&domain.User{ID: userID, BannedAt: &bannedAt}, nil).Once()
+		m.adminRepo.On("UpdateUser", userID, map[string]any{"status": clients.Active, "banned_at": nil}).Return(nil).Once()
+		m.adminRepo.On("SaveAudit", auditOf(domain.AuditUnbanUser, &userID)).Return(nil).Once()
+		m.cache.On("Invalidate", userID).Once()
+
+		err := adminService.UnbanUser(adminID, userID)
+
+		assert.NoError(t, err)
+		m.adminRepo.AssertExpectations(t)
+		m.cache.AssertExpectations(t)
+	})
+
+	t.Run("deleted by themselves", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		m.adminRepo.On("GetUser", map[string]any{"id": userID}).Return(&domain.User{ID: userID, Status: clients.Deleted}, nil).Once()
+
+		err := adminService.UnbanUser(adminID, userID)
+
+		assert.Equal(t, domain.ErrUserNotBanned, err)
+		m.adminRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestChangeRole(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		m.adminRepo.On("GetUser", map[string]any{"id": userID}).Return(&domain.User{ID: userID, Role: domain.RoleUser}, nil).Once()
+		m.adminRepo.On("UpdateUser", userID, map[string]any{"role": domain.RoleAdmin}).Return(nil).Once()
+		m.adminRepo.On("SaveAudit", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+			var details map[string]string
+			_ = json.Unmarshal([]byte(entry.Details), &details)
+			return entry.Action == domain.AuditChangeRole && entry.ActorID == adminID &&
+				details["from"] == "user" && details["to"] == "admin"
+		})).Return(nil).Once()
+		m.cache.On("Invalidate", userID).Once()
+
+		err := adminService.ChangeRole(adminID, userID, &domain.RoleChange{Role: "admin"})
+
+		assert.NoError(t, err)
+		m.adminRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		err := adminService.ChangeRole(adminID, userID, &domain.RoleChange{Role: "root"})
+
+		assert.Error(t, err)
+		m.adminRepo.AssertNotCalled(t, "Transaction", mock.Anything)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		_, adminService := setupAdminService()
+
+		err := adminService.ChangeRole(adminID, adminID, &domain.RoleChange{Role: "user"})
+
+		assert.Equal(t, domain.ErrCannotModerateSelf, err)
+	})
+}
+
+func TestListUsers(t *testing.T) {
+	adminID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		status := clients.Active
+		paging := &clients.Paging{Page: 1, Limit: 10}
+		m.adminRepo.On("SaveAudit", auditOf(domain.AuditListUsers, nil)).Return(nil).Once()
+		m.adminRepo.On("ListUsers", map[string]any{"status": clients.Active, "role": domain.RoleAdmin}, "ann", paging).
+			Return([]domain.User{{Email: "ann@example.com"}}, nil).Once()
+
+		users, err := adminService.ListUsers(adminID, &domain.UserFilter{Query: " ann ", Status: &status, Role: "admin"}, paging)
+
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+		m.adminRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		_, err := adminService.ListUsers(adminID, &domain.UserFilter{Role: "root"}, &clients.Paging{Page: 1, Limit: 10})
+
+		assert.Error(t, err)
+		m.adminRepo.AssertNotCalled(t, "SaveAudit", mock.Anything)
+	})
+}
+
+func TestGetUserItems(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+
+	t.Run("audited before reading", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		filter, paging := &domain.ItemFilter{}, &clients.Paging{Page: 1, Limit: 10}
+		m.adminRepo.On("SaveAudit", auditOf(domain.AuditViewItems, &userID)).Return(nil).Once()
+		m.items.On("GetAllItem", domain.Tenant{UserID: userID}, filter, paging).Return([]domain.Item{{UserID: userID}}, nil).Once()
+
+		result, err := adminService.GetUserItems(adminID, userID, filter, paging)
+
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+		m.items.AssertExpectations(t)
+	})
+
+	t.Run("audit failure", func(t *testing.T) {
+		m, adminService := setupAdminService()
+
+		m.adminRepo.On("SaveAudit", mock.Anything).Return(clients.ErrDB(assert.AnError)).Once()
+
+		_, err := adminService.GetUserItems(adminID, userID, &domain.ItemFilter{}, &clients.Paging{Page: 1, Limit: 10})
+
+		assert.Error(t, err)
+		m.items.AssertNotCalled(t, "GetAllItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
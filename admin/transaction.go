@@ -0,0 +1,24 @@
+package admin
+
+// TransactionalStore is an AdminStore whose transactions hand out stores of
+// its own type S, so that repositories need not depend on this package.
+type TransactionalStore[S any] interface {
+	AdminStore
+	Transaction(fn func(store S) error) error
+}
+
+// NewTransactionalRepo adapts a transactional store to AdminRepo.
+func NewTransactionalRepo[S TransactionalStore[S]](store S) AdminRepo {
+	return transactionalRepo[S]{AdminStore: store, store: store}
+}
+
+type transactionalRepo[S TransactionalStore[S]] struct {
+	AdminStore
+	store S
+}
+
+func (r transactionalRepo[S]) Transaction(fn func(repo AdminRepo) error) error {
+	return r.store.Transaction(func(store S) error {
+		return fn(NewTransactionalRepo(store))
+	})
+}
@@ -0,0 +1,34 @@
+package attachment
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// OrphanPurger removes attachments whose item has been purged.
+type OrphanPurger interface {
+	PurgeOrphans() (int64, error)
+}
+
+// RunOrphanCleanup purges orphaned attachments once at start and then every
+// interval, until ctx is done.
+func RunOrphanCleanup(ctx context.Context, purger OrphanPurger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := purger.PurgeOrphans()
+		if err != nil {
+			log.Println("attachment cleanup:", err)
+		} else if purged > 0 {
+			log.Printf("attachment cleanup: purged %d attachments", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
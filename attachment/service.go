@@ -0,0 +1,256 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// orphanBatchSize is the number of orphaned attachments removed per query.
+const orphanBatchSize = 100
+
+//go:generate mockery --name AttachmentRepo
+type AttachmentRepo interface {
+	Save(attachment *domain.Attachment) error
+	GetAttachment(conditions map[string]any) (*domain.Attachment, error)
+	// ListAttachments lists the matching attachments, oldest first.
+	ListAttachments(conditions map[string]any) ([]domain.Attachment, error)
+	Delete(id uuid.UUID) error
+	// ListOrphans lists up to limit attachments whose item no longer exists.
+	ListOrphans(limit int) ([]domain.Attachment, error)
+}
+
+//go:generate mockery --name ItemGetter
+type ItemGetter interface {
+	GetItem(filter map[string]any) (domain.Item, error)
+}
+
+//go:generate mockery --name PermissionResolver
+type PermissionResolver interface {
+	ItemAccess(item domain.Item, userID uuid.UUID) (domain.Access, error)
+}
+
+// BlobStore keeps the contents of attachments by key.
+//
+//go:generate mockery --name BlobStore
+type BlobStore interface {
+	// Put stores size bytes of content under key, replacing any blob there.
+	Put(key string, content io.Reader, size int64, contentType string) error
+	// Open returns the blob under key, or blob.ErrNotFound.
+	Open(key string) (io.ReadSeekCloser, error)
+	// Delete removes the blob under key, if any.
+	Delete(key string) error
+}
+
+type attachmentService struct {
+	attachmentRepo AttachmentRepo
+	items          ItemGetter
+	permissions    PermissionResolver
+	blobs          BlobStore
+	limits         domain.AttachmentLimits
+}
+
+// NewAttachmentService creates the attachment service. Everyone who can view
+// an item can download its attachments; editors can add and remove them.
+func NewAttachmentService(repo AttachmentRepo, items ItemGetter, permissions PermissionResolver, blobs BlobStore, limits domain.AttachmentLimits) *attachmentService {
+	return &attachmentService{
+		attachmentRepo: repo,
+		items:          items,
+		permissions:    permissions,
+		blobs:          blobs,
+		limits:         limits,
+	}
+}
+
+// UploadAttachment stores the file and attaches it to a live item. The
+// content type is detected from the content and has to be allowed.
+func (s *attachmentService) UploadAttachment(itemID, userID uuid.UUID, upload *domain.AttachmentUpload) (*domain.Attachment, error) {
+	if err := upload.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	if upload.Size > s.limits.MaxSize {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+
+	item, err := s.getItem(itemID, userID, domain.AccessEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Status == domain.Deleted {
+		return nil, clients.ErrEntityDeleted(item.TableName(), nil)
+	}
+
+	contentType, err := detectContentType(upload.Content)
+	if err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	if !s.limits.Allows(contentType) {
+		return nil, domain.ErrAttachmentType
+	}
+
+	now := time.Now()
+	attachment := &domain.Attachment{
+		ID:          uuid.New(),
+		ItemID:      itemID,
+		UserID:      userID,
+		FileName:    upload.FileName,
+		ContentType: contentType,
+		Size:        upload.Size,
+		CreatedAt:   &now,
+	}
+	attachment.BlobKey = "items/" + itemID.String() + "/" + attachment.ID.String()
+
+	if err := s.blobs.Put(attachment.BlobKey, upload.Content, upload.Size, contentType); err != nil {
+		return nil, clients.ErrCannotCreateEntity(attachment.TableName(), err)
+	}
+
+	if err := s.attachmentRepo.Save(attachment); err != nil {
+		if err := s.blobs.Delete(attachment.BlobKey); err != nil {
+			log.Println("attachment: delete blob of failed upload:", err)
+		}
+		return nil, clients.ErrCannotCreateEntity(attachment.TableName(), err)
+	}
+
+	return attachment, nil
+}
+
+// GetAttachments lists the attachments of an item the user can view, oldest
+// first.
+func (s *attachmentService) GetAttachments(itemID, userID uuid.UUID) ([]domain.Attachment, error) {
+	if _, err := s.getItem(itemID, userID, domain.AccessView); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.ListAttachments(map[string]any{"item_id": itemID})
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Attachment{}.TableName(), err)
+	}
+
+	return attachments, nil
+}
+
+// OpenAttachment returns an attachment of an item the user can view together
+// with its content, which the caller has to close.
+func (s *attachmentService) OpenAttachment(itemID, id, userID uuid.UUID) (*domain.Attachment, io.ReadSeekCloser, error) {
+	attachment, err := s.getAttachment(itemID, id, userID, domain.AccessView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Open(attachment.BlobKey)
+	if err != nil {
+		return nil, nil, clients.ErrCannotGetEntity(attachment.TableName(), err)
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment of an item the user can edit. The
+// blob goes first, so a failure never leaves a row without content.
+func (s *attachmentService) DeleteAttachment(itemID, id, userID uuid.UUID) error {
+	attachment, err := s.getAttachment(itemID, id, userID, domain.AccessEdit)
+	if err != nil {
+		return err
+	}
+
+	if err := s.blobs.Delete(attachment.BlobKey); err != nil {
+		return clients.ErrCannotDeleteEntity(attachment.TableName(), err)
+	}
+
+	if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+		return clients.ErrCannotDeleteEntity(attachment.TableName(), err)
+	}
+
+	return nil
+}
+
+// PurgeOrphans deletes the attachments of purged items together with their
+// blobs, and returns how many were deleted.
+func (s *attachmentService) PurgeOrphans() (int64, error) {
+	var purged int64
+
+	for {
+		orphans, err := s.attachmentRepo.ListOrphans(orphanBatchSize)
+		if err != nil {
+			return purged, clients.ErrCannotListEntity(domain.Attachment{}.TableName(), err)
+		}
+
+		for _, orphan := range orphans {
+			if err := s.blobs.Delete(orphan.BlobKey); err != nil {
+				return purged, clients.ErrCannotDeleteEntity(orphan.TableName(), err)
+			}
+
+			if err := s.attachmentRepo.Delete(orphan.ID); err != nil {
+				return purged, clients.ErrCannotDeleteEntity(orphan.TableName(), err)
+			}
+			purged++
+		}
+
+		if len(orphans) < orphanBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// getItem returns the item when the user has at least the needed access.
+func (s *attachmentService) getItem(itemID, userID uuid.UUID, need domain.Access) (domain.Item, error) {
+	item, err := s.items.GetItem(map[string]any{"id": itemID})
+	if err != nil {
+		return domain.Item{}, clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	access, err := s.permissions.ItemAccess(item, userID)
+	if err != nil {
+		return domain.Item{}, clients.ErrInternal(err)
+	}
+
+	if access < need {
+		return domain.Item{}, clients.ErrNoPermission(errors.New("no access to the item"))
+	}
+
+	return item, nil
+}
+
+func (s *attachmentService) getAttachment(itemID, id, userID uuid.UUID, need domain.Access) (*domain.Attachment, error) {
+	if _, err := s.getItem(itemID, userID, need); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetAttachment(map[string]any{"id": id, "item_id": itemID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.Attachment{}.TableName(), err)
+	}
+
+	return attachment, nil
+}
+
+// detectContentType sniffs the media type, without parameters, from the first
+// 512 bytes of content and rewinds it.
+func detectContentType(content io.ReadSeeker) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if err != nil {
+		return "", err
+	}
+
+	return mediaType, nil
+}
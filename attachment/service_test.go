@@ -0,0 +1,223 @@
+package attachment_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	service "todo-app/attachment"
+	"todo-app/attachment/mocks"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// attachmentService lists the methods under test, as the service type itself
+// is unexported.
+type attachmentService interface {
+	UploadAttachment(itemID, userID uuid.UUID, upload *domain.AttachmentUpload) (*domain.Attachment, error)
+	GetAttachments(itemID, userID uuid.UUID) ([]domain.Attachment, error)
+	OpenAttachment(itemID, id, userID uuid.UUID) (*domain.Attachment, io.ReadSeekCloser, error)
+	DeleteAttachment(itemID, id, userID uuid.UUID) error
+	PurgeOrphans() (int64, error)
+}
+
+type attachmentMocks struct {
+	repo        *mocks.AttachmentRepo
+	items       *mocks.ItemGetter
+	permissions *mocks.PermissionResolver
+	blobs       *mocks.BlobStore
+}
+
+var (
+	ownerID    = uuid.New()
+	viewerID   = uuid.New()
+	strangerID = uuid.New()
+	item       = domain.Item{ID: uuid.New(), UserID: ownerID, Status: domain.Active}
+	limits     = domain.AttachmentLimits{MaxSize: 1024, Types: []string{"image/*", "text/plain"}}
+	pngHeader  = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// setupAttachmentService wires the service to mocks where the owner can edit
+// the item, a viewer can view it and a stranger can not.
+func setupAttachmentService() (attachmentMocks, attachmentService) {
+	m := attachmentMocks{
+		repo:        new(mocks.AttachmentRepo),
+		items:       new(mocks.ItemGetter),
+		permissions: new(mocks.PermissionResolver),
+		blobs:       new(mocks.BlobStore),
+	}
+
+	m.items.On("GetItem", map[string]any{"id": item.ID}).Return(item, nil).Maybe()
+	m.permissions.On("ItemAccess", item, ownerID).Return(domain.AccessOwner, nil).Maybe()
+	m.permissions.On("ItemAccess", item, viewerID).Return(domain.AccessView, nil).Maybe()
+	m.permissions.On("ItemAccess", item, strangerID).Return(domain.AccessNone, nil).Maybe()
+
+	return m, service.NewAttachmentService(m.repo, m.items, m.permissions, m.blobs, limits)
+}
+
+func upload(name string, content []byte) *domain.AttachmentUpload {
+	return &domain.AttachmentUpload{FileName: name, Size: int64(len(content)), Content: bytes.NewReader(content)}
+}
+
+func TestUploadAttachment(t *testing.T) {
+	t.Run("stores the blob with the detected type", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		var stored []byte
+		m.blobs.On("Put", mock.AnythingOfType("string"), mock.Anything, int64(len(pngHeader)), "image/png").
+			Run(func(args mock.Arguments) {
+				stored, _ = io.ReadAll(args.Get(1).(io.Reader))
+			}).Return(nil).Once()
+		m.repo.On("Save", mock.MatchedBy(func(attachment *domain.Attachment) bool {
+			return attachment.ItemID == item.ID && attachment.UserID == ownerID
+		})).Return(nil).Once()
+
+		attachment, err := attachmentService.UploadAttachment(item.ID, ownerID, upload("../photo.txt", pngHeader))
+
+		require.NoError(t, err)
+		assert.Equal(t, "photo.txt", attachment.FileName)
+		assert.Equal(t, "image/png", attachment.ContentType)
+		assert.Equal(t, "items/"+item.ID.String()+"/"+attachment.ID.String(), attachment.BlobKey)
+		assert.Equal(t, pngHeader, stored) // the sniffed bytes are not lost
+		m.blobs.AssertExpectations(t)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("text is stored without its charset", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		m.blobs.On("Put", mock.Anything, mock.Anything, mock.Anything, "text/plain").Return(nil).Once()
+		m.repo.On("Save", mock.Anything).Return(nil).Once()
+
+		attachment, err := attachmentService.UploadAttachment(item.ID, ownerID, upload("notes.txt", []byte("hello")))
+
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", attachment.ContentType)
+	})
+
+	t.Run("files over the limit are refused", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		_, err := attachmentService.UploadAttachment(item.ID, ownerID, upload("big.txt", bytes.Repeat([]byte("a"), 1025)))
+
+		assert.ErrorIs(t, err, domain.ErrAttachmentTooLarge)
+		m.blobs.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("types that are not allowed are refused", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		_, err := attachmentService.UploadAttachment(item.ID, ownerID, upload("page.txt", []byte("<html><body>hi</body></html>")))
+
+		assert.ErrorIs(t, err, domain.ErrAttachmentType)
+		m.blobs.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("viewers can not upload", func(t *testing.T) {
+		_, attachmentService := setupAttachmentService()
+
+		_, err := attachmentService.UploadAttachment(item.ID, viewerID, upload("photo.png", pngHeader))
+
+		var appErr *clients.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "ErrNoPermission", appErr.Key)
+	})
+
+	t.Run("the blob is deleted when saving fails", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		var key string
+		m.blobs.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { key = args.String(0) }).Return(nil).Once()
+		m.repo.On("Save", mock.Anything).Return(errors.New("db down")).Once()
+		m.blobs.On("Delete", mock.MatchedBy(func(k string) bool { return k == key })).Return(nil).Once()
+
+		_, err := attachmentService.UploadAttachment(item.ID, ownerID, upload("photo.png", pngHeader))
+
+		assert.Error(t, err)
+		m.blobs.AssertExpectations(t)
+	})
+}
+
+func TestOpenAttachment(t *testing.T) {
+	attachment := &domain.Attachment{ID: uuid.New(), ItemID: item.ID, BlobKey: "items/1/2"}
+
+	t.Run("viewers can download", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		m.repo.On("GetAttachment", map[string]any{"id": attachment.ID, "item_id": item.ID}).Return(attachment, nil).Once()
+		m.blobs.On("Open", "items/1/2").Return(nopCloser{bytes.NewReader([]byte("hello"))}, nil).Once()
+
+		got, content, err := attachmentService.OpenAttachment(item.ID, attachment.ID, viewerID)
+
+		require.NoError(t, err)
+		assert.Equal(t, attachment, got)
+		body, _ := io.ReadAll(content)
+		assert.Equal(t, "hello", string(body))
+	})
+
+	t.Run("strangers can not download", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		_, _, err := attachmentService.OpenAttachment(item.ID, attachment.ID, strangerID)
+
+		var appErr *clients.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "ErrNoPermission", appErr.Key)
+		m.blobs.AssertNotCalled(t, "Open", mock.Anything)
+	})
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	attachment := &domain.Attachment{ID: uuid.New(), ItemID: item.ID, BlobKey: "items/1/2"}
+
+	t.Run("editors delete the blob and the attachment", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		m.repo.On("GetAttachment", map[string]any{"id": attachment.ID, "item_id": item.ID}).Return(attachment, nil).Once()
+		m.blobs.On("Delete", "items/1/2").Return(nil).Once()
+		m.repo.On("Delete", attachment.ID).Return(nil).Once()
+
+		require.NoError(t, attachmentService.DeleteAttachment(item.ID, attachment.ID, ownerID))
+		m.blobs.AssertExpectations(t)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("viewers can not delete", func(t *testing.T) {
+		m, attachmentService := setupAttachmentService()
+
+		err := attachmentService.DeleteAttachment(item.ID, attachment.ID, viewerID)
+
+		assert.Error(t, err)
+		m.blobs.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
+
+func TestPurgeOrphans(t *testing.T) {
+	m, attachmentService := setupAttachmentService()
+
+	orphans := []domain.Attachment{{ID: uuid.New(), BlobKey: "items/1/a"}, {ID: uuid.New(), BlobKey: "items/1/b"}}
+	m.repo.On("ListOrphans", 100).Return(orphans, nil).Once()
+	for _, orphan := range orphans {
+		m.blobs.On("Delete", orphan.BlobKey).Return(nil).Once()
+		m.repo.On("Delete", orphan.ID).Return(nil).Once()
+	}
+
+	purged, err := attachmentService.PurgeOrphans()
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, purged)
+	m.blobs.AssertExpectations(t)
+	m.repo.AssertExpectations(t)
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,218 @@
+package comment
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name CommentRepo
+type CommentRepo interface {
+	// Save creates the comment together with its mentions.
+	Save(comment *domain.Comment) error
+	GetComment(conditions map[string]any) (*domain.Comment, error)
+	// ListComments lists the matching comments with their mentions, oldest
+	// first.
+	ListComments(conditions map[string]any, paging *clients.Paging) ([]domain.Comment, error)
+	// Update replaces the body and the mentions of the comment.
+	Update(id uuid.UUID, body string, mentions []domain.Mention, updatedAt time.Time) error
+	Delete(id uuid.UUID) error
+}
+
+//go:generate mockery --name ItemGetter
+type ItemGetter interface {
+	GetItem(filter map[string]any) (domain.Item, error)
+}
+
+//go:generate mockery --name PermissionResolver
+type PermissionResolver interface {
+	ItemAccess(item domain.Item, userID uuid.UUID) (domain.Access, error)
+}
+
+//go:generate mockery --name UserGetter
+type UserGetter interface {
+	GetUser(conditions map[string]any) (*domain.User, error)
+}
+
+type commentService struct {
+	commentRepo CommentRepo
+	items       ItemGetter
+	permissions PermissionResolver
+	users       UserGetter
+}
+
+// NewCommentService creates the comment service. Everyone who can view an
+// item can read and write its comments; only their authors can change them.
+func NewCommentService(repo CommentRepo, items ItemGetter, permissions PermissionResolver, users UserGetter) *commentService {
+	return &commentService{
+		commentRepo: repo,
+		items:       items,
+		permissions: permissions,
+		users:       users,
+	}
+}
+
+// CreateComment adds a comment by the user to a live item.
+func (s *commentService) CreateComment(itemID, userID uuid.UUID, data *domain.CommentCreation) (*domain.Comment, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	item, err := s.getItem(itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Status == domain.Deleted {
+		return nil, clients.ErrEntityDeleted(item.TableName(), nil)
+	}
+
+	mentions, err := s.resolveMentions(item, data.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	comment := &domain.Comment{
+		ID:        uuid.New(),
+		ItemID:    itemID,
+		UserID:    userID,
+		Body:      data.Body,
+		Mentions:  mentions,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+
+	if err := s.commentRepo.Save(comment); err != nil {
+		return nil, clients.ErrCannotCreateEntity(comment.TableName(), err)
+	}
+
+	return comment, nil
+}
+
+// GetComments lists the comments of an item the user can view, oldest first.
+func (s *commentService) GetComments(itemID, userID uuid.UUID, paging *clients.Paging) ([]domain.Comment, error) {
+	if _, err := s.getItem(itemID, userID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.ListComments(map[string]any{"item_id": itemID}, paging)
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Comment{}.TableName(), err)
+	}
+
+	return comments, nil
+}
+
+// UpdateComment replaces the body of a comment of the user, resolving its
+// mentions again.
+func (s *commentService) UpdateComment(id, userID uuid.UUID, data *domain.CommentCreation) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	comment, item, err := s.getOwnComment(id, userID)
+	if err != nil {
+		return err
+	}
+
+	mentions, err := s.resolveMentions(item, data.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.Update(comment.ID, data.Body, mentions, time.Now()); err != nil {
+		return clients.ErrCannotUpdateEntity(comment.TableName(), err)
+	}
+
+	return nil
+}
+
+// DeleteComment deletes a comment of the user.
+func (s *commentService) DeleteComment(id, userID uuid.UUID) error {
+	comment, _, err := s.getOwnComment(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.Delete(comment.ID); err != nil {
+		return clients.ErrCannotDeleteEntity(comment.TableName(), err)
+	}
+
+	return nil
+}
+
+// getItem returns the item when the user can view it.
+func (s *commentService) getItem(itemID, userID uuid.UUID) (domain.Item, error) {
+	item, err := s.items.GetItem(map[string]any{"id": itemID})
+	if err != nil {
+		return domain.Item{}, clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	access, err := s.permissions.ItemAccess(item, userID)
+	if err != nil {
+		return domain.Item{}, clients.ErrInternal(err)
+	}
+
+	if access < domain.AccessView {
+		return domain.Item{}, clients.ErrNoPermission(errors.New("no access to the item"))
+	}
+
+	return item, nil
+}
+
+// getOwnComment returns a comment written by the user together with its
+// item, which the user still has to be able to view.
+func (s *commentService) getOwnComment(id, userID uuid.UUID) (*domain.Comment, domain.Item, error) {
+	comment, err := s.commentRepo.GetComment(map[string]any{"id": id})
+	if err != nil {
+		return nil, domain.Item{}, clients.ErrCannotGetEntity(domain.Comment{}.TableName(), err)
+	}
+
+	if comment.UserID != userID {
+		return nil, domain.Item{}, domain.ErrNotCommentAuthor
+	}
+
+	item, err := s.getItem(comment.ItemID, userID)
+	if err != nil {
+		return nil, domain.Item{}, err
+	}
+
+	return comment, item, nil
+}
+
+// resolveMentions returns the users mentioned in the body that can view the
+// item. Mentions of unknown emails or of users without access are left as
+// plain text. The mentioned emails are lowercased, and users are found by
+// email regardless of the case they registered with.
+func (s *commentService) resolveMentions(item domain.Item, body string) ([]domain.Mention, error) {
+	mentions := []domain.Mention{}
+
+	for _, email := range domain.ParseMentions(body) {
+		user, err := s.users.GetUser(map[string]any{"email": email})
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, clients.ErrCannotGetEntity(domain.EntityName, err)
+		}
+
+		if user.Status != clients.Active {
+			continue
+		}
+
+		access, err := s.permissions.ItemAccess(item, user.ID)
+		if err != nil {
+			return nil, clients.ErrInternal(err)
+		}
+
+		if access >= domain.AccessView {
+			mentions = append(mentions, domain.Mention{UserID: user.ID, Email: email})
+		}
+	}
+
+	return mentions, nil
+}
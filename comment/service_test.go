@@ -0,0 +1,220 @@
+package comment_test
+
+import (
+	"testing"
+	"time"
+	service "todo-app/comment"
+	"todo-app/comment/mocks"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// commentService lists the methods under test, as the service type itself is
+// unexported.
+type commentService interface {
+	CreateComment(itemID, userID uuid.UUID, data *domain.CommentCreation) (*domain.Comment, error)
+	GetComments(itemID, userID uuid.UUID, paging *clients.Paging) ([]domain.Comment, error)
+	UpdateComment(id, userID uuid.UUID, data *domain.CommentCreation) error
+	DeleteComment(id, userID uuid.UUID) error
+}
+
+type commentMocks struct {
+	repo        *mocks.CommentRepo
+	items       *mocks.ItemGetter
+	permissions *mocks.PermissionResolver
+	users       *mocks.UserGetter
+}
+
+var (
+	ownerID    = uuid.New()
+	viewerID   = uuid.New()
+	strangerID = uuid.New()
+	item       = domain.Item{ID: uuid.New(), UserID: ownerID, Status: domain.Active}
+)
+
+// setupCommentService wires the service to mocks where the owner and a viewer
+// can view the item and a stranger can not.
+func setupCommentService() (commentMocks, commentService) {
+	m := commentMocks{
+		repo:        new(mocks.CommentRepo),
+		items:       new(mocks.ItemGetter),
+		permissions: new(mocks.PermissionResolver),
+		users:       new(mocks.UserGetter),
+	}
+
+	m.items.On("GetItem", map[string]any{"id": item.ID}).Return(item, nil).Maybe()
+	m.permissions.On("ItemAccess", item, ownerID).Return(domain.AccessOwner, nil).Maybe()
+	m.permissions.On("ItemAccess", item, viewerID).Return(domain.AccessView, nil).Maybe()
+	m.permissions.On("ItemAccess", item, strangerID).Return(domain.AccessNone, nil).Maybe()
+
+	return m, service.NewCommentService(m.repo, m.items, m.permissions, m.users)
+}
+
+func TestCreateComment(t *testing.T) {
+	t.Run("resolves mentions of users who can view the item", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		m.users.On("GetUser", map[string]any{"email": "viewer@example.com"}).
+			Return(&domain.User{ID: viewerID, Email: "viewer@example.com", Status: clients.Active}, nil).Once()
+		m.users.On("GetUser", map[string]any{"email": "stranger@example.com"}).
+			Return(&domain.User{ID: strangerID, Email: "stranger@example.com", Status: clients.Active}, nil).Once()
+		m.users.On("GetUser", map[string]any{"email": "nobody@example.com"}).Return(nil, clients.ErrRecordNotFound).Once()
+		m.repo.On("Save", mock.MatchedBy(func(comment *domain.Comment) bool {
+			return comment.ItemID == item.ID && comment.UserID == ownerID
+		})).Return(nil).Once()
+
+		comment, err := commentService.CreateComment(item.ID, ownerID, &domain.CommentCreation{
+			Body: "@Viewer@example.com can you check with @stranger@example.com and @nobody@example.com?",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Mention{{UserID: viewerID, Email: "viewer@example.com"}}, comment.Mentions)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("resolves mentions of users registered with capitals", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		m.users.On("GetUser", map[string]any{"email": "viewer@example.com"}).
+			Return(&domain.User{ID: viewerID, Email: "Viewer@Example.com", Status: clients.Active}, nil).Once()
+		m.repo.On("Save", mock.Anything).Return(nil).Once()
+
+		comment, err := commentService.CreateComment(item.ID, ownerID, &domain.CommentCreation{Body: "@VIEWER@example.com ping"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Mention{{UserID: viewerID, Email: "viewer@example.com"}}, comment.Mentions)
+		m.users.AssertExpectations(t)
+	})
+
+	t.Run("viewers can comment", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		m.repo.On("Save", mock.Anything).Return(nil).Once()
+
+		_, err := commentService.CreateComment(item.ID, viewerID, &domain.CommentCreation{Body: "Looks good"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("strangers can not comment", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		_, err := commentService.CreateComment(item.ID, strangerID, &domain.CommentCreation{Body: "Hi"})
+
+		require.Error(t, err)
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		m.repo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("trashed item", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		trashed := item
+		trashed.Status = domain.Deleted
+		m.items.ExpectedCalls = nil
+		m.items.On("GetItem", map[string]any{"id": item.ID}).Return(trashed, nil).Once()
+		m.permissions.On("ItemAccess", trashed, ownerID).Return(domain.AccessOwner, nil).Once()
+
+		_, err := commentService.CreateComment(item.ID, ownerID, &domain.CommentCreation{Body: "Hi"})
+
+		assert.Error(t, err)
+		m.repo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		_, err := commentService.CreateComment(item.ID, ownerID, &domain.CommentCreation{Body: "  "})
+
+		assert.Error(t, err)
+		m.items.AssertNotCalled(t, "GetItem", mock.Anything)
+	})
+}
+
+func TestGetComments(t *testing.T) {
+	m, commentService := setupCommentService()
+	paging := &clients.Paging{Limit: 10, Page: 1}
+
+	m.repo.On("ListComments", map[string]any{"item_id": item.ID}, paging).
+		Return([]domain.Comment{{ID: uuid.New(), ItemID: item.ID, UserID: ownerID, Body: "First"}}, nil).Once()
+
+	comments, err := commentService.GetComments(item.ID, viewerID, paging)
+	require.NoError(t, err)
+	assert.Len(t, comments, 1)
+
+	_, err = commentService.GetComments(item.ID, strangerID, paging)
+	assert.Error(t, err)
+	m.repo.AssertNumberOfCalls(t, "ListComments", 1)
+}
+
+func TestUpdateComment(t *testing.T) {
+	commentID := uuid.New()
+	written := &domain.Comment{ID: commentID, ItemID: item.ID, UserID: viewerID, Body: "Old"}
+
+	t.Run("author edits", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		m.repo.On("GetComment", map[string]any{"id": commentID}).Return(written, nil).Once()
+		m.users.On("GetUser", map[string]any{"email": "owner@example.com"}).
+			Return(&domain.User{ID: ownerID, Status: clients.Active}, nil).Once()
+		m.repo.On("Update", commentID, "Ping @owner@example.com",
+			[]domain.Mention{{UserID: ownerID, Email: "owner@example.com"}}, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		err := commentService.UpdateComment(commentID, viewerID, &domain.CommentCreation{Body: "Ping @owner@example.com"})
+
+		assert.NoError(t, err)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("others can not edit", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		m.repo.On("GetComment", map[string]any{"id": commentID}).Return(written, nil).Once()
+
+		err := commentService.UpdateComment(commentID, ownerID, &domain.CommentCreation{Body: "Changed"})
+
+		assert.ErrorIs(t, err, domain.ErrNotCommentAuthor)
+		m.repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteComment(t *testing.T) {
+	commentID := uuid.New()
+	now := time.Now()
+	written := &domain.Comment{ID: commentID, ItemID: item.ID, UserID: viewerID, Body: "Old", CreatedAt: &now}
+
+	t.Run("author deletes", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		m.repo.On("GetComment", map[string]any{"id": commentID}).Return(written, nil).Once()
+		m.repo.On("Delete", commentID).Return(nil).Once()
+
+		assert.NoError(t, commentService.DeleteComment(commentID, viewerID))
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("the item owner can not delete", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		m.repo.On("GetComment", map[string]any{"id": commentID}).Return(written, nil).Once()
+
+		assert.ErrorIs(t, commentService.DeleteComment(commentID, ownerID), domain.ErrNotCommentAuthor)
+		m.repo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("author who lost access", func(t *testing.T) {
+		m, commentService := setupCommentService()
+
+		lost := *written
+		lost.UserID = strangerID
+		m.repo.On("GetComment", map[string]any{"id": commentID}).Return(&lost, nil).Once()
+
+		assert.Error(t, commentService.DeleteComment(commentID, strangerID))
+		m.repo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
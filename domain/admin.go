@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditListUsers  = "users.list"
+	AuditViewUser   = "users.view"
+	AuditBanUser    = "users.ban"
+	AuditUnbanUser  = "users.unban"
+	AuditChangeRole = "users.role"
+	AuditViewItems  = "items.view"
+	AuditViewAudit  = "audit.view"
+)
+
+// AuditEntry records an admin action. Details holds the parameters of the
+// action as a JSON object.
+type AuditEntry struct {
+	ID        uuid.UUID  `json:"id"`
+	ActorID   uuid.UUID  `json:"actor_id" gorm:"index"`
+	Action    string     `json:"action" gorm:"index"`
+	TargetID  *uuid.UUID `json:"target_id" gorm:"index"`
+	Details   string     `json:"details"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (AuditEntry) TableName() string { return "audit_log" }
+
+// UserFilter narrows the user listing of admins. Query matches the email and
+// names.
+type UserFilter struct {
+	Query  string          `json:"q,omitempty" form:"q"`
+	Status *clients.Status `json:"status,omitempty" form:"status"`
+	Role   string          `json:"role,omitempty" form:"role"`
+}
+
+func (f *UserFilter) Validate() error {
+	var validationErrors []string
+
+	f.Query = strings.TrimSpace(f.Query)
+	if len(f.Query) > maxSearchLength {
+		validationErrors = append(validationErrors, "q is too long")
+	}
+
+	if f.Role != "" {
+		if _, err := ParseUserRole(f.Role); err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// AuditFilter narrows the audit log listing.
+type AuditFilter struct {
+	ActorID  *uuid.UUID `json:"actor_id,omitempty" form:"actor_id"`
+	TargetID *uuid.UUID `json:"target_id,omitempty" form:"target_id"`
+	Action   string     `json:"action,omitempty" form:"action"`
+}
+
+type RoleChange struct {
+	Role string `json:"role"`
+}
+
+var (
+	ErrCannotModerateSelf = clients.NewCustomError(
+		errors.New("admins can not ban or change the role of themselves"),
+		"admins can not ban or change the role of themselves",
+		"ErrCannotModerateSelf",
+	)
+
+	ErrUserNotBanned = clients.NewCustomError(
+		errors.New("user is not banned"),
+		"user is not banned",
+		"ErrUserNotBanned",
+	)
+
+	ErrUserDeleted = clients.NewCustomError(
+		errors.New("user deleted their account"),
+		"user deleted their account",
+		"ErrUserDeleted",
+	)
+)
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// Scopes of an API key. A read key can only make GET and HEAD requests, a
+// write key can make any request.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// APIKeyPrefix starts every API key, so leaked keys are easy to recognise.
+const APIKeyPrefix = "tdk_"
+
+const maxAPIKeyNameLength = 100
+
+// APIKey is a long-lived credential for scripts and integrations. Only the
+// hash of the key is stored; Prefix keeps its first characters so users can
+// tell their keys apart.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"-" gorm:"index"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-" gorm:"uniqueIndex"`
+	Scopes     string     `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"-"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+func (APIKey) TableName() string { return "api_keys" }
+
+// HasScope reports whether the key was granted scope.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range strings.Split(k.Scopes, ",") {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allows reports whether the key may make a request with the given method.
+func (k *APIKey) Allows(method string) bool {
+	if k.HasScope(ScopeWrite) {
+		return true
+	}
+
+	return k.HasScope(ScopeRead) && (method == http.MethodGet || method == http.MethodHead)
+}
+
+// Active reports whether the key can still be used at the given time.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+type APIKeyCreation struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Validate trims the name and defaults the scopes to read.
+func (c *APIKeyCreation) Validate() error {
+	var validationErrors []string
+
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+
+	if len(c.Name) > maxAPIKeyNameLength {
+		validationErrors = append(validationErrors, "name is too long")
+	}
+
+	if len(c.Scopes) == 0 {
+		c.Scopes = []string{ScopeRead}
+	}
+
+	for _, scope := range c.Scopes {
+		if scope != ScopeRead && scope != ScopeWrite {
+			validationErrors = append(validationErrors, "scopes must be read or write")
+			break
+		}
+	}
+
+	if c.ExpiresAt != nil && !c.ExpiresAt.After(time.Now()) {
+		validationErrors = append(validationErrors, "expires_at must be in the future")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// APIKeyCreated is returned once, on creation. Key is never shown again.
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+var (
+	ErrAPIKeyInvalid = clients.NewUnauthorized(
+		errors.New("api key is invalid, expired or revoked"),
+		"api key is invalid, expired or revoked",
+		"ErrAPIKeyInvalid",
+	)
+
+	ErrAPIKeyScope = clients.NewCustomError(
+		errors.New("api key does not have the scope for this request"),
+		"api key does not have the scope for this request",
+		"ErrAPIKeyScope",
+	)
+
+	ErrSessionRequired = clients.NewCustomError(
+		errors.New("this endpoint needs a login session, not an api key"),
+		"this endpoint needs a login session, not an api key",
+		"ErrSessionRequired",
+	)
+)
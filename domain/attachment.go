@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const maxFileNameLength = 255
+
+// Attachment is a file attached to an item. Its content lives in the blob
+// store under BlobKey.
+type Attachment struct {
+	ID          uuid.UUID  `json:"id"`
+	ItemID      uuid.UUID  `json:"item_id" gorm:"index"`
+	UserID      uuid.UUID  `json:"user_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	BlobKey     string     `json:"-"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+func (Attachment) TableName() string { return "attachments" }
+
+// AttachmentUpload is a file uploaded to an item. Its content type is sniffed
+// from Content rather than taken from the client.
+type AttachmentUpload struct {
+	FileName string
+	Size     int64
+	Content  io.ReadSeeker
+}
+
+// Validate cleans up the file name: only its base name is kept, without
+// control characters and cut to 255 bytes.
+func (a *AttachmentUpload) Validate() error {
+	if a.Content == nil {
+		return errors.New("file can not be null")
+	}
+
+	a.FileName = sanitizeFileName(a.FileName)
+
+	return nil
+}
+
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "file"
+	}
+
+	for len(name) > maxFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return name
+}
+
+// AttachmentLimits restricts the size and the content types of attachments.
+// A type ending in /* allows all its subtypes.
+type AttachmentLimits struct {
+	MaxSize int64
+	Types   []string
+}
+
+func (l AttachmentLimits) Allows(contentType string) bool {
+	for _, allowed := range l.Types {
+		if allowed == contentType {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(contentType, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	ErrAttachmentTooLarge = clients.NewCustomError(
+		errors.New("the file is too large"),
+		"the file is larger than allowed",
+		"ErrAttachmentTooLarge",
+	)
+	ErrAttachmentType = clients.NewCustomError(
+		errors.New("the content type is not allowed"),
+		"files of this type can not be attached",
+		"ErrAttachmentType",
+	)
+)
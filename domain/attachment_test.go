@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"todo-app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentFileName(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\ann\photo.png`, "photo.png"},
+		{"bad\r\nname.txt", "badname.txt"},
+		{"", "file"},
+		{"..", "file"},
+		{"/", "file"},
+		{strings.Repeat("é", 200), strings.Repeat("é", 127)},
+	}
+
+	for _, c := range cases {
+		upload := domain.AttachmentUpload{FileName: c.name, Content: strings.NewReader("")}
+		require.NoError(t, upload.Validate())
+		assert.Equal(t, c.want, upload.FileName, c.name)
+	}
+}
+
+func TestAttachmentLimitsAllows(t *testing.T) {
+	limits := domain.AttachmentLimits{Types: []string{"image/*", "application/pdf"}}
+
+	assert.True(t, limits.Allows("image/png"))
+	assert.True(t, limits.Allows("application/pdf"))
+	assert.False(t, limits.Allows("application/zip"))
+	assert.False(t, limits.Allows("imagex/png"))
+	assert.False(t, limits.Allows("text/html"))
+}
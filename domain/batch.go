@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// Operations of an item batch.
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+// Modes of an item batch. An atomic batch is rolled back as a whole when one
+// operation fails; a best-effort batch only drops the failing operations.
+const (
+	BatchAtomic     = "atomic"
+	BatchBestEffort = "best_effort"
+)
+
+// MaxBatchOperations bounds the number of operations of one batch.
+const MaxBatchOperations = 100
+
+type ItemBatch struct {
+	Mode       string               `json:"mode"`
+	Operations []ItemBatchOperation `json:"operations"`
+}
+
+// ItemBatchOperation creates an item from Create, or updates or deletes the
+// item identified by ID.
+type ItemBatchOperation struct {
+	Op     string        `json:"op"`
+	ID     *uuid.UUID    `json:"id"`
+	Create *ItemCreation `json:"create"`
+	Update *ItemUpdate   `json:"update"`
+}
+
+// Validate checks the shape of the batch and defaults the mode to atomic. The
+// operations themselves are validated when they are applied.
+func (b *ItemBatch) Validate() error {
+	var validationErrors []string
+
+	if b.Mode == "" {
+		b.Mode = BatchAtomic
+	}
+
+	if b.Mode != BatchAtomic && b.Mode != BatchBestEffort {
+		validationErrors = append(validationErrors, "mode must be atomic or best_effort")
+	}
+
+	if len(b.Operations) == 0 {
+		validationErrors = append(validationErrors, "operations can not be empty")
+	}
+
+	if len(b.Operations) > MaxBatchOperations {
+		validationErrors = append(validationErrors, fmt.Sprintf("a batch can not hold more than %d operations", MaxBatchOperations))
+	}
+
+	for i, op := range b.Operations {
+		switch {
+		case op.Op != BatchCreate && op.Op != BatchUpdate && op.Op != BatchDelete:
+			validationErrors = append(validationErrors, fmt.Sprintf("operations[%d]: op must be create, update or delete", i))
+		case op.Op == BatchCreate && op.Create == nil:
+			validationErrors = append(validationErrors, fmt.Sprintf("operations[%d]: create can not be null", i))
+		case op.Op != BatchCreate && op.ID == nil:
+			validationErrors = append(validationErrors, fmt.Sprintf("operations[%d]: id can not be null", i))
+		case op.Op == BatchUpdate && op.Update == nil:
+			validationErrors = append(validationErrors, fmt.Sprintf("operations[%d]: update can not be null", i))
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// ItemBatchResult reports the outcome of one operation. Applied is false for
+// every operation of a rolled back atomic batch.
+type ItemBatchResult struct {
+	Index   int               `json:"index"`
+	Op      string            `json:"op"`
+	ID      *uuid.UUID        `json:"id,omitempty"`
+	Applied bool              `json:"applied"`
+	Error   *clients.AppError `json:"error,omitempty"`
+}
+
+// ItemBatchReport is the outcome of a batch. Committed is false when an atomic
+// batch was rolled back.
+type ItemBatchReport struct {
+	Mode      string            `json:"mode"`
+	Committed bool              `json:"committed"`
+	Results   []ItemBatchResult `json:"results"`
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxCommentLength = 10000
+	maxMentions      = 20
+)
+
+// mentionPattern matches a mention: @ followed by the email of a user.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)+)`)
+
+// Comment is a message of a user about an item. Body is markdown and is
+// stored as written; Mentions are the users mentioned in it that can view the
+// item.
+type Comment struct {
+	ID        uuid.UUID  `json:"id"`
+	ItemID    uuid.UUID  `json:"item_id" gorm:"index"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"index"`
+	Body      string     `json:"body"`
+	Mentions  []Mention  `json:"mentions" gorm:"-"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func (Comment) TableName() string { return "comments" }
+
+// Mention is a user mentioned in a comment, with the email they were
+// mentioned by.
+type Mention struct {
+	CommentID uuid.UUID `json:"-" gorm:"primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"primaryKey;index"`
+	Email     string    `json:"email"`
+}
+
+func (Mention) TableName() string { return "comment_mentions" }
+
+// CommentCreation holds the body of a new or edited comment.
+type CommentCreation struct {
+	Body string `json:"body"`
+}
+
+func (c *CommentCreation) Validate() error {
+	c.Body = strings.TrimSpace(c.Body)
+
+	if c.Body == "" {
+		return errors.New("body can not be null")
+	}
+
+	if len(c.Body) > maxCommentLength {
+		return fmt.Errorf("body can not be longer than %d characters", maxCommentLength)
+	}
+
+	if len(ParseMentions(c.Body)) > maxMentions {
+		return fmt.Errorf("a comment can mention at most %d users", maxMentions)
+	}
+
+	return nil
+}
+
+// ParseMentions returns the emails mentioned in a comment body as @email,
+// lowercased and without repeats, in order of appearance.
+func ParseMentions(body string) []string {
+	var emails []string
+	seen := map[string]bool{}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(match[1])
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+var ErrNotCommentAuthor = clients.NewCustomError(
+	errors.New("only the author can change a comment"),
+	"only the author can change a comment",
+	"ErrNotCommentAuthor",
+)
@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"todo-app/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	cases := []struct {
+		body string
+		want []string
+	}{
+		{"no mentions here", nil},
+		{"@Ann@Example.com please review", []string{"ann@example.com"}},
+		{"cc @bob@example.com, @ann@example.com and @bob@example.com.", []string{"bob@example.com", "ann@example.com"}},
+		{"(@ann@example.com) **@bob@example.com**", []string{"ann@example.com", "bob@example.com"}},
+		{"mail ann@example.com or @ann", nil},
+		{"`@ann` and name@@example.com", nil},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, domain.ParseMentions(c.body), c.body)
+	}
+}
+
+func TestCommentCreationValidate(t *testing.T) {
+	data := &domain.CommentCreation{Body: "  **done**  "}
+	assert.NoError(t, data.Validate())
+	assert.Equal(t, "**done**", data.Body)
+
+	assert.Error(t, (&domain.CommentCreation{Body: " \n "}).Validate())
+	assert.Error(t, (&domain.CommentCreation{Body: strings.Repeat("a", 10001)}).Validate())
+
+	var mentions []string
+	for i := 0; i < 21; i++ {
+		mentions = append(mentions, "@user"+strings.Repeat("x", i)+"@example.com")
+	}
+	assert.Error(t, (&domain.CommentCreation{Body: strings.Join(mentions, " ")}).Validate())
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// Events recorded in the history of an item.
+const (
+	ItemEventAssigned   = "item.assigned"
+	ItemEventUnassigned = "item.unassigned"
+)
+
+// ItemEvent records a change of an item. Details holds the parameters of the
+// change as a JSON object.
+type ItemEvent struct {
+	ID        uuid.UUID  `json:"id"`
+	ItemID    uuid.UUID  `json:"item_id" gorm:"index"`
+	ActorID   uuid.UUID  `json:"actor_id"`
+	Action    string     `json:"action"`
+	Details   string     `json:"details"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (ItemEvent) TableName() string { return "item_events" }
+
+// ItemAssignment hands an item to AssigneeID, or takes it back from its
+// assignee when AssigneeID is null.
+type ItemAssignment struct {
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+}
+
+func (a *ItemAssignment) Validate() error {
+	if a.AssigneeID != nil && *a.AssigneeID == uuid.Nil {
+		return errors.New("assignee_id is invalid; use null to unassign")
+	}
+
+	return nil
+}
+
+var ErrAssigneeNoAccess = clients.NewCustomError(
+	errors.New("the assignee has no access to the item"),
+	"the assignee has no access to the item",
+	"ErrAssigneeNoAccess",
+)
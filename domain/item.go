@@ -2,29 +2,111 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
+	"todo-app/pkg/clients"
 
 	"github.com/google/uuid"
 )
 
+// Item is a todo of a user. Trashed items have the Deleted status and a
+// DeletedAt time; RestoreStatus keeps the status they get back on restore.
+// A recurring item carries an RRULE in Recurrence and the due date of the
+// first occurrence of its series in RecurrenceStart. Items of a workspace
+// carry its WorkspaceID and belong to the team rather than to their creator.
+// AssigneeID is the user the item is handed to, who can change its status
+// even without the right to edit it. Listings fill in CommentCount.
 type Item struct {
-	ID          uuid.UUID  `json:"id"`
-	UserID      uuid.UUID  `json:"-"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      Status     `json:"status" gorm:"column:status"`
-	CreatedAt   *time.Time `json:"created_at"`
-	UpdatedAt   *time.Time `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+	UserID          uuid.UUID  `json:"-"`
+	WorkspaceID     *uuid.UUID `json:"workspace_id,omitempty" gorm:"index"`
+	AssigneeID      *uuid.UUID `json:"assignee_id" gorm:"index"`
+	ParentID        *uuid.UUID `json:"parent_id" gorm:"index"`
+	ListID          *uuid.UUID `json:"list_id" gorm:"index"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Status          Status     `json:"status" gorm:"column:status"`
+	DueAt           *time.Time `json:"due_at"`
+	DueTimezone     string     `json:"due_timezone"`
+	RemindAt        *time.Time `json:"remind_at"`
+	Recurrence      string     `json:"recurrence,omitempty"`
+	RecurrenceStart *time.Time `json:"recurrence_start,omitempty"`
+	CreatedAt       *time.Time `json:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+	RestoreStatus   Status     `json:"-"`
+	Tags            []Tag      `json:"tags,omitempty" gorm:"-"`
+	Subtasks        []Item     `json:"subtasks,omitempty" gorm:"-"`
+	Progress        *float64   `json:"progress,omitempty" gorm:"-"`
+	CommentCount    *int64     `json:"comment_count,omitempty" gorm:"-"`
 }
 
 func (Item) TableName() string { return "items" }
 
+// Tenant returns the space the item belongs to.
+func (i *Item) Tenant() Tenant {
+	return Tenant{UserID: i.UserID, WorkspaceID: i.WorkspaceID}
+}
+
+// Location returns the timezone of the due date, UTC when it has none.
+func (i *Item) Location() *time.Location {
+	if loc, err := time.LoadLocation(i.DueTimezone); err == nil && i.DueTimezone != "" {
+		return loc
+	}
+
+	return time.UTC
+}
+
+// ComputeProgress sets the completion percentage of the item and of every
+// subtask below it. A leaf counts as complete once it is Done; an item with
+// subtasks is the average of its non-deleted subtasks.
+func (i *Item) ComputeProgress() float64 {
+	var total float64
+	var count int
+
+	for idx := range i.Subtasks {
+		subtask := &i.Subtasks[idx]
+		progress := subtask.ComputeProgress()
+
+		if subtask.Status == Deleted {
+			continue
+		}
+
+		total += progress
+		count++
+	}
+
+	progress := 0.0
+	switch {
+	case count > 0:
+		progress = total / float64(count)
+	case i.Status == Done:
+		progress = 100
+	}
+
+	i.Progress = &progress
+
+	return progress
+}
+
 type ItemCreation struct {
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
+	ID              uuid.UUID   `json:"id"`
+	UserID          uuid.UUID   `json:"user_id"`
+	WorkspaceID     *uuid.UUID  `json:"-"`
+	AssigneeID      *uuid.UUID  `json:"-"`
+	ParentID        *uuid.UUID  `json:"parent_id"`
+	ListID          *uuid.UUID  `json:"list_id"`
+	Title           string      `json:"title"`
+	Description     string      `json:"description"`
+	Status          Status      `json:"-"`
+	DueAt           *time.Time  `json:"due_at"`
+	DueTimezone     string      `json:"due_timezone"`
+	RemindAt        *time.Time  `json:"remind_at"`
+	Recurrence      string      `json:"recurrence"`
+	RecurrenceStart *time.Time  `json:"-"`
+	TagIDs          []uuid.UUID `json:"tag_ids" gorm:"-"`
+	AllowPastDue    bool        `json:"allow_past_due" gorm:"-"`
 }
 
 func (ItemCreation) TableName() string { return Item{}.TableName() }
@@ -36,6 +118,16 @@ func (ic *ItemCreation) Validate() error {
 		validationErrors = append(validationErrors, "title can not be null")
 	}
 
+	validationErrors = append(validationErrors, validateDue(ic.DueAt, &ic.DueTimezone, ic.RemindAt, ic.AllowPastDue)...)
+
+	if ic.Recurrence != "" {
+		if ic.DueAt == nil {
+			validationErrors = append(validationErrors, "recurrence needs a due_at")
+		}
+
+		validationErrors = append(validationErrors, normalizeRecurrence(&ic.Recurrence)...)
+	}
+
 	if len(validationErrors) > 0 {
 		return errors.New(strings.Join(validationErrors, "; "))
 	}
@@ -43,11 +135,250 @@ func (ic *ItemCreation) Validate() error {
 	return nil
 }
 
+// ItemUpdate holds the fields of an item that can be changed. Setting ListID
+// to the nil UUID moves the item back to the inbox. A non-nil TagIDs replaces
+// the tags of the item, so an empty list removes them all. An empty
+// Recurrence stops the item from recurring.
 type ItemUpdate struct {
-	Title       *string   `json:"title"`
-	Description *string   `json:"description"`
-	Status      *Status   `json:"status"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ListID          *uuid.UUID  `json:"list_id"`
+	Title           *string     `json:"title"`
+	Description     *string     `json:"description"`
+	Status          *Status     `json:"status"`
+	DueAt           *time.Time  `json:"due_at"`
+	DueTimezone     *string     `json:"due_timezone"`
+	RemindAt        *time.Time  `json:"remind_at"`
+	Recurrence      *string     `json:"recurrence"`
+	RecurrenceStart *time.Time  `json:"-"`
+	TagIDs          []uuid.UUID `json:"tag_ids" gorm:"-"`
+	AllowPastDue    bool        `json:"allow_past_due" gorm:"-"`
+	UpdatedAt       time.Time   `json:"updated_at"`
 }
 
 func (ItemUpdate) TableName() string { return Item{}.TableName() }
+
+// StatusOnly reports whether the update changes the status and nothing else.
+func (iu *ItemUpdate) StatusOnly() bool {
+	return iu.Status != nil && iu.ListID == nil && iu.Title == nil && iu.Description == nil &&
+		iu.DueAt == nil && iu.DueTimezone == nil && iu.RemindAt == nil && iu.Recurrence == nil && iu.TagIDs == nil
+}
+
+func (iu *ItemUpdate) Validate() error {
+	var validationErrors []string
+
+	if iu.Title != nil && *iu.Title == "" {
+		validationErrors = append(validationErrors, "title can not be null")
+	}
+
+	if iu.Status != nil && (*iu.Status < Active || *iu.Status > Archived) {
+		validationErrors = append(validationErrors, "status must be active, done or archived; use DELETE to move an item to the trash")
+	}
+
+	validationErrors = append(validationErrors, validateDue(iu.DueAt, iu.DueTimezone, iu.RemindAt, iu.AllowPastDue)...)
+
+	if iu.Recurrence != nil && *iu.Recurrence != "" {
+		validationErrors = append(validationErrors, normalizeRecurrence(iu.Recurrence)...)
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// normalizeRecurrence checks a recurrence rule and rewrites it in canonical
+// form.
+func normalizeRecurrence(recurrence *string) []string {
+	rule, err := ParseRRule(*recurrence)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	*recurrence = rule.String()
+
+	return nil
+}
+
+// validateDue checks the due date, its timezone and the reminder time.
+// A valid timezone is normalized in place to its canonical IANA name.
+func validateDue(dueAt *time.Time, timezone *string, remindAt *time.Time, allowPast bool) []string {
+	var validationErrors []string
+
+	if timezone != nil && *timezone != "" {
+		loc, err := time.LoadLocation(*timezone)
+		if err != nil {
+			validationErrors = append(validationErrors, "due_timezone is not a valid IANA timezone")
+		} else {
+			*timezone = loc.String()
+		}
+	}
+
+	if dueAt != nil && !allowPast && dueAt.Before(time.Now()) {
+		validationErrors = append(validationErrors, "due_at can not be in the past")
+	}
+
+	if remindAt != nil {
+		if dueAt != nil && remindAt.After(*dueAt) {
+			validationErrors = append(validationErrors, "remind_at can not be after due_at")
+		}
+
+		if !allowPast && remindAt.Before(time.Now()) {
+			validationErrors = append(validationErrors, "remind_at can not be in the past")
+		}
+	}
+
+	return validationErrors
+}
+
+var ErrItemHasActiveSubtasks = clients.NewCustomError(
+	errors.New("item has active subtasks"),
+	"item has active subtasks",
+	"ErrItemHasActiveSubtasks",
+)
+
+var ErrItemNotTrashed = clients.NewCustomError(
+	errors.New("item is not in the trash"),
+	"item is not in the trash",
+	"ErrItemNotTrashed",
+)
+
+var ErrParentTrashed = clients.NewCustomError(
+	errors.New("parent item is in the trash"),
+	"parent item is in the trash, restore it first",
+	"ErrParentTrashed",
+)
+
+// Due views supported by the item listing.
+const (
+	DueViewToday    = "today"
+	DueViewUpcoming = "upcoming"
+	DueViewOverdue  = "overdue"
+)
+
+// Fields the item listing can be sorted by.
+var itemSortFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"due_at":     true,
+	"title":      true,
+	"status":     true,
+}
+
+// Sort directions of the item listing.
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// maxSearchLength bounds the substring accepted by the listing search.
+const maxSearchLength = 100
+
+// ItemFilter holds the query parameters accepted by the item listing.
+type ItemFilter struct {
+	Status       []Status   `json:"status,omitempty" form:"status"`
+	Search       string     `json:"search,omitempty" form:"search"`
+	CreatedFrom  *time.Time `json:"created_from,omitempty" form:"created_from"`
+	CreatedTo    *time.Time `json:"created_to,omitempty" form:"created_to"`
+	UpdatedFrom  *time.Time `json:"updated_from,omitempty" form:"updated_from"`
+	UpdatedTo    *time.Time `json:"updated_to,omitempty" form:"updated_to"`
+	DueBefore    *time.Time `json:"due_before,omitempty" form:"due_before"`
+	Overdue      bool       `json:"overdue,omitempty" form:"overdue"`
+	ListID       string     `json:"list_id,omitempty" form:"list_id"`
+	AssignedToMe bool       `json:"assigned_to_me,omitempty" form:"assigned_to_me"`
+	Tags         []string   `json:"tags,omitempty" form:"tag"`
+	TagMode      string     `json:"tag_mode,omitempty" form:"tag_mode"`
+	SortBy       string     `json:"sort_by" form:"sort_by"`
+	SortDir      string     `json:"sort_dir" form:"sort_dir"`
+}
+
+// Validate checks the filter and fills in the default tag mode and sort
+// order (newest first).
+func (f *ItemFilter) Validate() error {
+	var validationErrors []string
+
+	for _, status := range f.Status {
+		switch {
+		case status == Deleted:
+			validationErrors = append(validationErrors, "trashed items are listed by the trash")
+		case status < Deleted || status > Archived:
+			validationErrors = append(validationErrors, fmt.Sprintf("status %d is unknown", status))
+		}
+	}
+
+	f.Search = strings.TrimSpace(f.Search)
+	if len(f.Search) > maxSearchLength {
+		validationErrors = append(validationErrors, fmt.Sprintf("search can not be longer than %d characters", maxSearchLength))
+	}
+
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		validationErrors = append(validationErrors, "created_from can not be after created_to")
+	}
+
+	if f.UpdatedFrom != nil && f.UpdatedTo != nil && f.UpdatedFrom.After(*f.UpdatedTo) {
+		validationErrors = append(validationErrors, "updated_from can not be after updated_to")
+	}
+
+	if len(f.Tags) > 0 && f.TagMode == "" {
+		f.TagMode = TagMatchAny
+	}
+
+	if f.TagMode != "" && f.TagMode != TagMatchAny && f.TagMode != TagMatchAll {
+		validationErrors = append(validationErrors, "tag_mode must be any or all")
+	}
+
+	if f.SortBy == "" {
+		f.SortBy = "created_at"
+	}
+
+	if !itemSortFields[f.SortBy] {
+		validationErrors = append(validationErrors, "sort_by must be one of created_at, updated_at, due_at, title, status")
+	}
+
+	if f.SortDir == "" {
+		f.SortDir = SortDesc
+	}
+
+	if f.SortDir != SortAsc && f.SortDir != SortDesc {
+		validationErrors = append(validationErrors, "sort_dir must be asc or desc")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// ItemSort is the order in which the item repository returns items. Ties are
+// broken by ID in the same direction.
+type ItemSort struct {
+	Field string
+	Desc  bool
+}
+
+// ItemSearch holds the query parameters of the full-text item search.
+type ItemSearch struct {
+	Query string `json:"q" form:"q"`
+}
+
+func (s *ItemSearch) Validate() error {
+	s.Query = strings.TrimSpace(s.Query)
+
+	if s.Query == "" {
+		return errors.New("q can not be empty")
+	}
+
+	if len(s.Query) > maxSearchLength {
+		return fmt.Errorf("q can not be longer than %d characters", maxSearchLength)
+	}
+
+	return nil
+}
+
+// ItemSearchResult is an item matched by the full-text search, with its
+// relevance and an excerpt where the matched terms are wrapped in <mark>.
+type ItemSearchResult struct {
+	Item
+	Rank    float64 `json:"rank" gorm:"->"`
+	Snippet string  `json:"snippet" gorm:"->"`
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// List groups items. The lists of a workspace only hold its items.
+type List struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"-"`
+	WorkspaceID *uuid.UUID `json:"workspace_id,omitempty" gorm:"index"`
+	Name        string     `json:"name"`
+	Status      Status     `json:"status"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+func (List) TableName() string { return "lists" }
+
+type ListCreation struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	WorkspaceID *uuid.UUID `json:"-"`
+	Name        string     `json:"name"`
+	Status      Status     `json:"-"`
+}
+
+func (ListCreation) TableName() string { return List{}.TableName() }
+
+func (lc *ListCreation) Validate() error {
+	var validationErrors []string
+
+	if strings.TrimSpace(lc.Name) == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type ListUpdate struct {
+	Name      *string   `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (ListUpdate) TableName() string { return List{}.TableName() }
+
+func (lu *ListUpdate) Validate() error {
+	if lu.Name != nil && strings.TrimSpace(*lu.Name) == "" {
+		return errors.New("name can not be null")
+	}
+
+	return nil
+}
+
+// Ways of deleting a list.
+const (
+	// ListDeleteInbox deletes the list and moves its items back to the inbox.
+	ListDeleteInbox = "inbox"
+	// ListDeleteArchive keeps the list and its items but archives them.
+	ListDeleteArchive = "archive"
+)
+
+// InboxListID is the list_id filter value selecting items that belong to no list.
+const InboxListID = "inbox"
+
+var ErrListArchived = clients.NewCustomError(
+	errors.New("list has been archived"),
+	"list has been archived",
+	"ErrListArchived",
+)
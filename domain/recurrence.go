@@ -0,0 +1,347 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+)
+
+// Frequencies of the supported RRULE subset.
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxRecurrencePeriods bounds how many periods are walked when looking for
+// occurrences, so a rule that rarely matches can not loop for long.
+const maxRecurrencePeriods = 100000
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// WeekdayNum is a BYDAY entry. N selects the nth weekday of the month,
+// counting from the end when negative; zero means every such weekday.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	code := strings.ToUpper(w.Day.String()[:2])
+	if w.N == 0 {
+		return code
+	}
+
+	return strconv.Itoa(w.N) + code
+}
+
+// RRule is the supported subset of an RFC 5545 recurrence rule: FREQ,
+// INTERVAL, BYDAY, COUNT and UNTIL. Ordinal BYDAY entries such as 1MO or -1FR
+// are only allowed with FREQ=MONTHLY, and BYDAY is not allowed with
+// FREQ=YEARLY.
+type RRule struct {
+	Freq     string
+	Interval int
+	ByDay    []WeekdayNum
+	Count    int
+	// Until is inclusive. A date-only UNTIL covers the whole day in the
+	// timezone of the occurrences.
+	Until     *time.Time
+	UntilDate bool
+}
+
+// ParseRRule parses a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE". An
+// "RRULE:" prefix is accepted.
+func ParseRRule(value string) (*RRule, error) {
+	rule := &RRule{Interval: 1}
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("recurrence part %q is malformed", part)
+		}
+
+		switch key {
+		case "FREQ":
+			rule.Freq = val
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, errors.New("recurrence INTERVAL must be a positive number")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, errors.New("recurrence COUNT must be a positive number")
+			}
+			rule.Count = count
+		case "UNTIL":
+			if until, err := time.Parse("20060102T150405Z", val); err == nil {
+				rule.Until = &until
+			} else if until, err := time.Parse("20060102", val); err == nil {
+				rule.Until, rule.UntilDate = &until, true
+			} else {
+				return nil, errors.New("recurrence UNTIL must look like 20061231 or 20061231T235959Z")
+			}
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, err := parseWeekdayNum(code)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		default:
+			return nil, fmt.Errorf("recurrence part %s is not supported", key)
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func parseWeekdayNum(code string) (WeekdayNum, error) {
+	if len(code) < 2 {
+		return WeekdayNum{}, fmt.Errorf("recurrence BYDAY %q is not a weekday", code)
+	}
+
+	day, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("recurrence BYDAY %q is not a weekday", code)
+	}
+
+	var n int
+	if prefix := code[:len(code)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("recurrence BYDAY %q has an invalid ordinal", code)
+		}
+	}
+
+	return WeekdayNum{N: n, Day: day}, nil
+}
+
+func (r *RRule) validate() error {
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	case "":
+		return errors.New("recurrence FREQ can not be null")
+	default:
+		return errors.New("recurrence FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY")
+	}
+
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("recurrence can not have both COUNT and UNTIL")
+	}
+
+	if r.Freq == FreqYearly && len(r.ByDay) > 0 {
+		return errors.New("recurrence BYDAY is not supported with FREQ=YEARLY")
+	}
+
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != FreqMonthly {
+			return errors.New("recurrence BYDAY ordinals are only supported with FREQ=MONTHLY")
+		}
+	}
+
+	return nil
+}
+
+// String returns the canonical form of the rule.
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if r.Until != nil {
+		if r.UntilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns up to n occurrences strictly after the given time of the
+// series starting at start. Occurrences keep the wall clock time of start in
+// loc, across daylight saving changes. COUNT is counted from start.
+func (r *RRule) Occurrences(start time.Time, loc *time.Location, after time.Time, n int) []time.Time {
+	start = start.In(loc)
+
+	var occurrences []time.Time
+	seen := 0
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, candidate := range r.candidates(start, loc, period*r.Interval) {
+			if candidate.Before(start) {
+				continue
+			}
+
+			seen++
+			if (r.Count > 0 && seen > r.Count) || r.pastUntil(candidate, loc) {
+				return occurrences
+			}
+
+			if candidate.After(after) {
+				occurrences = append(occurrences, candidate)
+				if len(occurrences) == n {
+					return occurrences
+				}
+			}
+		}
+	}
+
+	return occurrences
+}
+
+func (r *RRule) pastUntil(candidate time.Time, loc *time.Location) bool {
+	if r.Until == nil {
+		return false
+	}
+
+	if r.UntilDate {
+		y, m, d := r.Until.Date()
+		return !candidate.Before(time.Date(y, m, d+1, 0, 0, 0, 0, loc))
+	}
+
+	return candidate.After(*r.Until)
+}
+
+// candidates returns the sorted occurrences of the period offset periods after
+// the one holding start.
+func (r *RRule) candidates(start time.Time, loc *time.Location, offset int) []time.Time {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, loc)
+	}
+
+	var candidates []time.Time
+
+	switch r.Freq {
+	case FreqDaily:
+		day := at(start.Year(), start.Month(), start.Day()+offset)
+		if len(r.ByDay) == 0 || r.onDay(day.Weekday()) {
+			candidates = append(candidates, day)
+		}
+	case FreqWeekly:
+		monday := start.Day() - (int(start.Weekday())+6)%7 + 7*offset
+		for i := 0; i < 7; i++ {
+			day := at(start.Year(), start.Month(), monday+i)
+			if (len(r.ByDay) == 0 && day.Weekday() == start.Weekday()) || r.onDay(day.Weekday()) {
+				candidates = append(candidates, day)
+			}
+		}
+	case FreqMonthly:
+		first := at(start.Year(), start.Month()+time.Month(offset), 1)
+		if len(r.ByDay) == 0 {
+			if day := at(first.Year(), first.Month(), start.Day()); day.Month() == first.Month() {
+				candidates = append(candidates, day)
+			}
+			break
+		}
+
+		for _, byDay := range r.ByDay {
+			candidates = append(candidates, monthlyByDay(first, byDay, at)...)
+		}
+		candidates = sortUnique(candidates)
+	case FreqYearly:
+		if day := at(start.Year()+offset, start.Month(), start.Day()); day.Month() == start.Month() {
+			candidates = append(candidates, day)
+		}
+	}
+
+	return candidates
+}
+
+func (r *RRule) onDay(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Day == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// monthlyByDay returns the days of the month starting at first that match
+// byDay.
+func monthlyByDay(first time.Time, byDay WeekdayNum, at func(int, time.Month, int) time.Time) []time.Time {
+	var days []time.Time
+	for day := 1 + (int(byDay.Day)-int(first.Weekday())+7)%7; ; day += 7 {
+		date := at(first.Year(), first.Month(), day)
+		if date.Month() != first.Month() {
+			break
+		}
+		days = append(days, date)
+	}
+
+	switch {
+	case byDay.N == 0:
+		return days
+	case byDay.N > 0 && byDay.N <= len(days):
+		return days[byDay.N-1 : byDay.N]
+	case byDay.N < 0 && -byDay.N <= len(days):
+		return days[len(days)+byDay.N : len(days)+byDay.N+1]
+	}
+
+	return nil
+}
+
+func sortUnique(times []time.Time) []time.Time {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	result := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+var ErrRecurrenceEnded = clients.NewCustomError(
+	errors.New("recurrence has no further occurrences"),
+	"recurrence has no further occurrences",
+	"ErrRecurrenceEnded",
+)
+
+var ErrItemNotRecurring = clients.NewCustomError(
+	errors.New("item does not recur"),
+	"item does not recur",
+	"ErrItemNotRecurring",
+)
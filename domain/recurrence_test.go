@@ -0,0 +1,119 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRRule(t *testing.T) {
+	t.Run("canonical form", func(t *testing.T) {
+		rule, err := domain.ParseRRule("RRULE:byday=we,mo;freq=weekly;interval=2;until=20261231")
+
+		require.NoError(t, err)
+		assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=WE,MO;UNTIL=20261231", rule.String())
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		invalid := []string{
+			"",
+			"INTERVAL=2",
+			"FREQ=HOURLY",
+			"FREQ=DAILY;INTERVAL=0",
+			"FREQ=DAILY;COUNT=-1",
+			"FREQ=DAILY;COUNT=3;UNTIL=20261231",
+			"FREQ=DAILY;UNTIL=tomorrow",
+			"FREQ=WEEKLY;BYDAY=XX",
+			"FREQ=WEEKLY;BYDAY=1MO",
+			"FREQ=MONTHLY;BYDAY=6MO",
+			"FREQ=YEARLY;BYDAY=MO",
+			"FREQ=DAILY;BYMONTH=1",
+		}
+
+		for _, value := range invalid {
+			_, err := domain.ParseRRule(value)
+
+			assert.Error(t, err, value)
+		}
+	})
+}
+
+func TestRRuleOccurrences(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	occurrences := func(t *testing.T, value string, start time.Time, n int) []time.Time {
+		rule, err := domain.ParseRRule(value)
+		require.NoError(t, err)
+
+		return rule.Occurrences(start, time.UTC, start, n)
+	}
+
+	t.Run("daily with interval", func(t *testing.T) {
+		got := occurrences(t, "FREQ=DAILY;INTERVAL=3", date(2026, 1, 30, 9), 2)
+
+		assert.Equal(t, []time.Time{date(2026, 2, 2, 9), date(2026, 2, 5, 9)}, got)
+	})
+
+	t.Run("weekly by day", func(t *testing.T) {
+		// 2026-01-07 is a Wednesday
+		got := occurrences(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR", date(2026, 1, 7, 9), 4)
+
+		assert.Equal(t, []time.Time{
+			date(2026, 1, 9, 9), date(2026, 1, 19, 9), date(2026, 1, 21, 9), date(2026, 1, 23, 9),
+		}, got)
+	})
+
+	t.Run("monthly skips short months", func(t *testing.T) {
+		got := occurrences(t, "FREQ=MONTHLY", date(2026, 1, 31, 9), 3)
+
+		assert.Equal(t, []time.Time{date(2026, 3, 31, 9), date(2026, 5, 31, 9), date(2026, 7, 31, 9)}, got)
+	})
+
+	t.Run("monthly by ordinal day", func(t *testing.T) {
+		got := occurrences(t, "FREQ=MONTHLY;BYDAY=1MO,-1FR", date(2026, 1, 5, 9), 3)
+
+		assert.Equal(t, []time.Time{date(2026, 1, 30, 9), date(2026, 2, 2, 9), date(2026, 2, 27, 9)}, got)
+	})
+
+	t.Run("yearly on leap day", func(t *testing.T) {
+		got := occurrences(t, "FREQ=YEARLY", date(2024, 2, 29, 9), 1)
+
+		assert.Equal(t, []time.Time{date(2028, 2, 29, 9)}, got)
+	})
+
+	t.Run("count includes the start", func(t *testing.T) {
+		got := occurrences(t, "FREQ=DAILY;COUNT=3", date(2026, 1, 1, 9), 10)
+
+		assert.Equal(t, []time.Time{date(2026, 1, 2, 9), date(2026, 1, 3, 9)}, got)
+	})
+
+	t.Run("until is inclusive", func(t *testing.T) {
+		got := occurrences(t, "FREQ=DAILY;UNTIL=20260103", date(2026, 1, 1, 9), 10)
+		assert.Equal(t, []time.Time{date(2026, 1, 2, 9), date(2026, 1, 3, 9)}, got)
+
+		got = occurrences(t, "FREQ=DAILY;UNTIL=20260103T090000Z", date(2026, 1, 1, 9), 10)
+		assert.Equal(t, []time.Time{date(2026, 1, 2, 9), date(2026, 1, 3, 9)}, got)
+	})
+
+	t.Run("keeps the wall clock across daylight saving", func(t *testing.T) {
+		loc, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		rule, err := domain.ParseRRule("FREQ=WEEKLY")
+		require.NoError(t, err)
+
+		start := time.Date(2026, 3, 1, 9, 0, 0, 0, loc)
+		got := rule.Occurrences(start, loc, start, 2)
+
+		require.Len(t, got, 2)
+		for _, occurrence := range got {
+			assert.Equal(t, 9, occurrence.Hour())
+		}
+		assert.Equal(t, 167*time.Hour, got[0].Sub(start))
+	})
+}
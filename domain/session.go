@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// ClientInfo describes the client a login came from.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// Session is a login of a user on a device. Its refresh tokens and the access
+// tokens issued with them carry its ID, so revoking the session signs the
+// device out.
+type Session struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"-" gorm:"index"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"-"`
+	CreatedAt  *time.Time `json:"created_at"`
+	// Current marks the session of the request listing the sessions.
+	Current bool `json:"current" gorm:"-"`
+}
+
+func (Session) TableName() string { return "sessions" }
+
+var ErrSessionRevoked = clients.NewUnauthorized(
+	errors.New("session has been revoked"),
+	"session has been revoked",
+	"ErrSessionRevoked",
+)
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// Roles of a collaborator. A viewer can read a shared item, an editor can
+// also change it. Only the owner can delete it or share it further.
+const (
+	ShareViewer = "viewer"
+	ShareEditor = "editor"
+)
+
+// Access is what a user may do with an item, in increasing order.
+type Access int
+
+const (
+	AccessNone Access = iota
+	AccessView
+	AccessEdit
+	AccessOwner
+)
+
+// Share grants a collaborator access to an item, its subtasks included, or
+// to every item of a list. It starts as an invitation to an email address;
+// accepting it binds the share to the user with that verified email.
+type Share struct {
+	ID         uuid.UUID  `json:"id"`
+	OwnerID    uuid.UUID  `json:"owner_id" gorm:"index"`
+	ItemID     *uuid.UUID `json:"item_id" gorm:"index"`
+	ListID     *uuid.UUID `json:"list_id" gorm:"index"`
+	Email      string     `json:"email" gorm:"index"`
+	UserID     *uuid.UUID `json:"user_id" gorm:"index"`
+	Role       string     `json:"role"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+func (Share) TableName() string { return "shares" }
+
+// Access returns the access the share grants once accepted.
+func (s *Share) Access() Access {
+	if s.AcceptedAt == nil {
+		return AccessNone
+	}
+
+	if s.Role == ShareEditor {
+		return AccessEdit
+	}
+
+	return AccessView
+}
+
+type ShareCreation struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// Validate normalizes the email and defaults the role to viewer.
+func (s *ShareCreation) Validate() error {
+	var validationErrors []string
+
+	s.Email = strings.ToLower(strings.TrimSpace(s.Email))
+	if _, err := mail.ParseAddress(s.Email); err != nil {
+		validationErrors = append(validationErrors, "email is invalid")
+	}
+
+	if s.Role == "" {
+		s.Role = ShareViewer
+	}
+
+	if s.Role != ShareViewer && s.Role != ShareEditor {
+		validationErrors = append(validationErrors, "role must be viewer or editor")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+var (
+	ErrShareExisted = clients.NewCustomError(
+		errors.New("already shared with this email"),
+		"already shared with this email",
+		"ErrShareExisted",
+	)
+
+	ErrShareWithSelf = clients.NewCustomError(
+		errors.New("can not share with yourself"),
+		"can not share with yourself",
+		"ErrShareWithSelf",
+	)
+
+	ErrShareAccepted = clients.NewCustomError(
+		errors.New("invitation has already been accepted"),
+		"invitation has already been accepted",
+		"ErrShareAccepted",
+	)
+)
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity links a user to their account at an OpenID Connect provider.
+// Users are found by it on later logins, even if their email changed.
+type UserIdentity struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"-" gorm:"index"`
+	Issuer    string     `json:"issuer" gorm:"uniqueIndex:idx_user_identities_subject"`
+	Subject   string     `json:"subject" gorm:"uniqueIndex:idx_user_identities_subject"`
+	Email     string     `json:"email"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (UserIdentity) TableName() string { return "user_identities" }
+
+// ExternalIdentity is a user as vouched for by an OpenID Connect provider.
+type ExternalIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+}
+
+// SSOState is kept between sending the user to the provider and their
+// return, under the state parameter of the request.
+type SSOState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// SSOStart is where to send the user to log in at the provider.
+type SSOStart struct {
+	AuthURL string `json:"auth_url"`
+}
+
+// SSOCallback carries the parameters the provider sent the user back with.
+type SSOCallback struct {
+	Code             string     `json:"code" form:"code"`
+	State            string     `json:"state" form:"state"`
+	Error            string     `json:"error" form:"error"`
+	ErrorDescription string     `json:"error_description" form:"error_description"`
+	Client           ClientInfo `json:"-" form:"-"`
+}
+
+func (c *SSOCallback) Validate() error {
+	var validationErrors []string
+
+	if c.State == "" {
+		validationErrors = append(validationErrors, "state can not be null")
+	}
+
+	if c.Code == "" && c.Error == "" {
+		validationErrors = append(validationErrors, "code can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+var (
+	ErrSSOStateInvalid = clients.NewUnauthorized(
+		errors.New("login state is invalid or expired"),
+		"login state is invalid or expired",
+		"ErrSSOStateInvalid",
+	)
+
+	ErrSSOLoginFailed = clients.NewUnauthorized(
+		errors.New("login at the identity provider failed"),
+		"login at the identity provider failed",
+		"ErrSSOLoginFailed",
+	)
+
+	ErrSSOEmailNotVerified = clients.NewCustomError(
+		errors.New("the identity provider has not verified the email"),
+		"the identity provider has not verified the email",
+		"ErrSSOEmailNotVerified",
+	)
+)
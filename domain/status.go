@@ -6,4 +6,8 @@ const (
 	Deleted Status = iota
 	Active
 	Done
+	Archived
 )
+
+// LiveStatuses are the statuses of items that are not in the trash.
+var LiveStatuses = []Status{Active, Done, Archived}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTagColor is used when a tag is created without a color.
+const DefaultTagColor = "#9e9e9e"
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type Tag struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"-" gorm:"uniqueIndex:idx_tags_user_name"`
+	Name      string     `json:"name" gorm:"uniqueIndex:idx_tags_user_name"`
+	Color     string     `json:"color"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func (Tag) TableName() string { return "tags" }
+
+type TagCreation struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	Color  string    `json:"color"`
+}
+
+func (TagCreation) TableName() string { return Tag{}.TableName() }
+
+func (tc *TagCreation) Validate() error {
+	var validationErrors []string
+
+	tc.Name = strings.TrimSpace(tc.Name)
+	if tc.Name == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+
+	if tc.Color == "" {
+		tc.Color = DefaultTagColor
+	}
+
+	if !tagColorPattern.MatchString(tc.Color) {
+		validationErrors = append(validationErrors, "color must be a hex color like #ff8800")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type TagUpdate struct {
+	Name      *string   `json:"name"`
+	Color     *string   `json:"color"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (TagUpdate) TableName() string { return Tag{}.TableName() }
+
+func (tu *TagUpdate) Validate() error {
+	var validationErrors []string
+
+	if tu.Name != nil {
+		*tu.Name = strings.TrimSpace(*tu.Name)
+		if *tu.Name == "" {
+			validationErrors = append(validationErrors, "name can not be null")
+		}
+	}
+
+	if tu.Color != nil && !tagColorPattern.MatchString(*tu.Color) {
+		validationErrors = append(validationErrors, "color must be a hex color like #ff8800")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// ItemTag links an item to one of its tags.
+type ItemTag struct {
+	ItemID uuid.UUID `gorm:"primaryKey"`
+	TagID  uuid.UUID `gorm:"primaryKey;index"`
+}
+
+func (ItemTag) TableName() string { return "item_tags" }
+
+// Tag matching modes of the item listing.
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
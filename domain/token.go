@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/tokenprovider"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is the server-side record of a refresh token. Only the hash of
+// the token is stored. A token is used once: refreshing revokes it and points
+// ReplacedBy at its successor, which belongs to the same session.
+type RefreshToken struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"-" gorm:"index"`
+	SessionID  *uuid.UUID `json:"-" gorm:"index"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	ReplacedBy *uuid.UUID `json:"-"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+func (RefreshToken) TableName() string { return "refresh_tokens" }
+
+// HashToken returns the hash under which a refresh or user token is stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// TokenPair is issued on login and on every refresh.
+type TokenPair struct {
+	AccessToken      tokenprovider.Token `json:"access_token"`
+	RefreshToken     string              `json:"refresh_token"`
+	RefreshExpiresAt time.Time           `json:"refresh_expires_at"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (r *RefreshRequest) Validate() error {
+	if r.RefreshToken == "" {
+		return errors.New("refresh_token can not be null")
+	}
+
+	return nil
+}
+
+// LogoutRequest optionally names the refresh token to revoke along with the
+// access token of the request. All revokes every refresh token of the user.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	All          bool   `json:"all"`
+}
+
+// Purposes of a user token.
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposeTwoFactor         = "two_factor_challenge"
+)
+
+// UserToken is a single-use token mailed to a user to reset their password or
+// verify their email, or handed out as the challenge of a two-factor login.
+// Like refresh tokens, only its hash is stored.
+type UserToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"-" gorm:"index"`
+	Purpose   string     `json:"purpose"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (UserToken) TableName() string { return "user_tokens" }
+
+// EmailRequest names the account a password reset or a verification email is
+// requested for.
+type EmailRequest struct {
+	Email string `json:"email"`
+}
+
+func (r *EmailRequest) Validate() error {
+	r.Email = strings.TrimSpace(r.Email)
+	if r.Email == "" {
+		return errors.New("email can not be null")
+	}
+
+	return nil
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+func (r *VerifyEmailRequest) Validate() error {
+	if r.Token == "" {
+		return errors.New("token can not be null")
+	}
+
+	return nil
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func (r *ResetPasswordRequest) Validate() error {
+	var validationErrors []string
+
+	if r.Token == "" {
+		validationErrors = append(validationErrors, "token can not be null")
+	}
+	if r.Password == "" {
+		validationErrors = append(validationErrors, "password can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+var (
+	ErrUserTokenInvalid = clients.NewCustomError(
+		errors.New("token is invalid, used or expired"),
+		"token is invalid, used or expired",
+		"ErrUserTokenInvalid",
+	)
+
+	ErrRefreshTokenInvalid = clients.NewUnauthorized(
+		errors.New("refresh token is invalid or expired"),
+		"refresh token is invalid or expired",
+		"ErrRefreshTokenInvalid",
+	)
+
+	ErrRefreshTokenReused = clients.NewUnauthorized(
+		errors.New("refresh token has already been used"),
+		"refresh token has already been used",
+		"ErrRefreshTokenReused",
+	)
+)
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCode is a single-use code that stands in for a TOTP code when the
+// authenticator is lost. Only its hash is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"-" gorm:"index"`
+	CodeHash  string     `json:"-" gorm:"uniqueIndex"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (RecoveryCode) TableName() string { return "recovery_codes" }
+
+// NormalizeRecoveryCode returns the form of a recovery code that is hashed,
+// so codes are accepted regardless of case and dashes.
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// TwoFactorEnrollment is the secret of a pending enrollment. URI is usually
+// shown as a QR code, Secret is for manual entry.
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TwoFactorCodeRequest carries a TOTP code or, where accepted, a recovery
+// code.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+func (r *TwoFactorCodeRequest) Validate() error {
+	if strings.TrimSpace(r.Code) == "" {
+		return errors.New("code can not be null")
+	}
+
+	return nil
+}
+
+// TwoFactorLoginRequest completes a login that returned a challenge.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string     `json:"challenge_token"`
+	Code           string     `json:"code"`
+	Client         ClientInfo `json:"-" form:"-"`
+}
+
+func (r *TwoFactorLoginRequest) Validate() error {
+	var validationErrors []string
+
+	if r.ChallengeToken == "" {
+		validationErrors = append(validationErrors, "challenge_token can not be null")
+	}
+
+	if strings.TrimSpace(r.Code) == "" {
+		validationErrors = append(validationErrors, "code can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// RecoveryCodes are shown once, when two-factor authentication is enabled.
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// LoginResult is the outcome of a password login: the token pair, or for
+// users with two-factor authentication a challenge to complete with a code.
+type LoginResult struct {
+	*TokenPair
+	TwoFactorRequired  bool       `json:"two_factor_required"`
+	ChallengeToken     string     `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt *time.Time `json:"challenge_expires_at,omitempty"`
+}
+
+var (
+	ErrTwoFactorCodeInvalid = clients.NewCustomError(
+		errors.New("two-factor code is invalid"),
+		"two-factor code is invalid",
+		"ErrTwoFactorCodeInvalid",
+	)
+
+	ErrTwoFactorEnabled = clients.NewCustomError(
+		errors.New("two-factor authentication is already enabled"),
+		"two-factor authentication is already enabled",
+		"ErrTwoFactorEnabled",
+	)
+
+	ErrTwoFactorNotEnrolled = clients.NewCustomError(
+		errors.New("two-factor authentication has not been set up"),
+		"two-factor authentication has not been set up",
+		"ErrTwoFactorNotEnrolled",
+	)
+
+	ErrTwoFactorChallengeInvalid = clients.NewUnauthorized(
+		errors.New("two-factor challenge is invalid or expired"),
+		"two-factor challenge is invalid or expired",
+		"ErrTwoFactorChallengeInvalid",
+	)
+)
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
 	"time"
 	"todo-app/pkg/clients"
@@ -11,6 +13,10 @@ import (
 
 const EntityName = "User"
 
+const maxNameLength = 50
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ().-]{5,19}$`)
+
 type UserRole int
 
 const (
@@ -19,26 +25,52 @@ const (
 )
 
 func (role UserRole) String() string {
-	switch role {
-	case RoleAdmin:
+	if role.Has(RoleAdmin) {
 		return "admin"
-	default:
-		return "user"
 	}
+
+	return "user"
+}
+
+// Has reports whether the role includes other.
+func (role UserRole) Has(other UserRole) bool {
+	return role&other != 0
+}
+
+// ParseUserRole returns the role named by String.
+func ParseUserRole(name string) (UserRole, error) {
+	switch name {
+	case "user":
+		return RoleUser, nil
+	case "admin":
+		return RoleAdmin, nil
+	}
+
+	return 0, errors.New("role must be user or admin")
 }
 
+// User is an account. EmailVerifiedAt is set once the user followed the link
+// of the verification email. A banned user has the Deleted status and a
+// BannedAt time, which tells them apart from users who deleted themselves.
+// Two-factor authentication is on once TOTPEnabledAt is set; TOTPLastStep is
+// the time step of the last accepted code, which can not be used again.
 type User struct {
-	ID        uuid.UUID
-	Email     string         `json:"email"`
-	Password  string         `json:"-"`
-	FirstName string         `json:"first_name"`
-	LastName  string         `json:"last_name"`
-	Phone     string         `json:"phone"`
-	Role      UserRole       `json:"role"`
-	Salt      string         `json:"-"`
-	Status    clients.Status `json:"status"`
-	CreatedAt *time.Time     `json:"created_at"`
-	UpdatedAt *time.Time     `json:"updated_at"`
+	ID              uuid.UUID
+	Email           string         `json:"email"`
+	Password        string         `json:"-"`
+	FirstName       string         `json:"first_name"`
+	LastName        string         `json:"last_name"`
+	Phone           string         `json:"phone"`
+	Role            UserRole       `json:"role"`
+	Salt            string         `json:"-"`
+	Status          clients.Status `json:"status"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at"`
+	BannedAt        *time.Time     `json:"banned_at,omitempty"`
+	TOTPSecret      string         `json:"-"`
+	TOTPEnabledAt   *time.Time     `json:"two_factor_enabled_at"`
+	TOTPLastStep    int64          `json:"-"`
+	CreatedAt       *time.Time     `json:"created_at"`
+	UpdatedAt       *time.Time     `json:"updated_at"`
 }
 
 func (User) TableName() string {
@@ -59,12 +91,13 @@ func (u *User) GetRole() string {
 
 type UserCreate struct {
 	ID        uuid.UUID
-	Email     string   `json:"email"`
-	Password  string   `json:"password"`
-	FirstName string   `json:"first_name"`
-	LastName  string   `json:"last_name"`
-	Role      UserRole `json:"-"`
-	Salt      string   `json:"-"`
+	Email     string         `json:"email"`
+	Password  string         `json:"password"`
+	FirstName string         `json:"first_name"`
+	LastName  string         `json:"last_name"`
+	Role      UserRole       `json:"-"`
+	Salt      string         `json:"-"`
+	Status    clients.Status `json:"-"`
 }
 
 func (UserCreate) TableName() string {
@@ -88,9 +121,79 @@ func (ic *UserCreate) Validate() error {
 	return nil
 }
 
+// UserUpdate holds the profile fields a user can change about themselves.
+type UserUpdate struct {
+	FirstName *string   `json:"first_name"`
+	LastName  *string   `json:"last_name"`
+	Phone     *string   `json:"phone"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func (UserUpdate) TableName() string {
+	return User{}.TableName()
+}
+
+func (uu *UserUpdate) Validate() error {
+	var validationErrors []string
+
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{{"first_name", uu.FirstName}, {"last_name", uu.LastName}} {
+		if field.value == nil {
+			continue
+		}
+
+		*field.value = strings.TrimSpace(*field.value)
+		if len(*field.value) > maxNameLength {
+			validationErrors = append(validationErrors, fmt.Sprintf("%s can not be longer than %d characters", field.name, maxNameLength))
+		}
+	}
+
+	if uu.Phone != nil {
+		*uu.Phone = strings.TrimSpace(*uu.Phone)
+		if *uu.Phone != "" && !phonePattern.MatchString(*uu.Phone) {
+			validationErrors = append(validationErrors, "phone must be a phone number like +84 912 345 678")
+		}
+	}
+
+	if uu.FirstName == nil && uu.LastName == nil && uu.Phone == nil {
+		validationErrors = append(validationErrors, "nothing to update")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+func (r *ChangePasswordRequest) Validate() error {
+	var validationErrors []string
+
+	if r.CurrentPassword == "" {
+		validationErrors = append(validationErrors, "current_password can not be null")
+	}
+	if r.NewPassword == "" {
+		validationErrors = append(validationErrors, "new_password can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
 type UserLogin struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string     `json:"email"`
+	Password string     `json:"password"`
+	Client   ClientInfo `json:"-" form:"-"`
 }
 
 func (UserLogin) TableName() string {
@@ -109,4 +212,16 @@ var (
 		"email has already existed",
 		"ErrEmailExisted",
 	)
+
+	ErrCurrentPasswordInvalid = clients.NewCustomError(
+		errors.New("current password is invalid"),
+		"current password is invalid",
+		"ErrCurrentPasswordInvalid",
+	)
+
+	ErrEmailNotVerified = clients.NewCustomError(
+		errors.New("email has not been verified"),
+		"email has not been verified",
+		"ErrEmailNotVerified",
+	)
 )
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceHeader selects the workspace a request works in. Without it the
+// request works in the personal space of the user.
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceRole is the role of a member within a workspace.
+type WorkspaceRole string
+
+// Roles of a workspace member, in increasing order. Viewers read the items
+// of the workspace, members also create and change them, admins also delete
+// them and manage the members. Owners can also promote owners and delete the
+// workspace.
+const (
+	WorkspaceViewer WorkspaceRole = "viewer"
+	WorkspaceMember WorkspaceRole = "member"
+	WorkspaceAdmin  WorkspaceRole = "admin"
+	WorkspaceOwner  WorkspaceRole = "owner"
+)
+
+var workspaceRoleRanks = map[WorkspaceRole]int{
+	WorkspaceViewer: 1,
+	WorkspaceMember: 2,
+	WorkspaceAdmin:  3,
+	WorkspaceOwner:  4,
+}
+
+// Valid reports whether the role is one of the workspace roles.
+func (r WorkspaceRole) Valid() bool {
+	return workspaceRoleRanks[r] > 0
+}
+
+// Has reports whether the role is at least role.
+func (r WorkspaceRole) Has(role WorkspaceRole) bool {
+	return workspaceRoleRanks[r] >= workspaceRoleRanks[role]
+}
+
+// Access returns the access the role gives to the items of the workspace.
+func (r WorkspaceRole) Access() Access {
+	switch {
+	case r.Has(WorkspaceAdmin):
+		return AccessOwner
+	case r.Has(WorkspaceMember):
+		return AccessEdit
+	case r.Has(WorkspaceViewer):
+		return AccessView
+	default:
+		return AccessNone
+	}
+}
+
+// Workspace is a team sharing items and lists. Role is the role of the user
+// the workspace was loaded for.
+type Workspace struct {
+	ID        uuid.UUID     `json:"id"`
+	Name      string        `json:"name"`
+	CreatedBy uuid.UUID     `json:"created_by"`
+	Role      WorkspaceRole `json:"role,omitempty" gorm:"-"`
+	CreatedAt *time.Time    `json:"created_at"`
+	UpdatedAt *time.Time    `json:"updated_at"`
+}
+
+func (Workspace) TableName() string { return "workspaces" }
+
+// Membership is the role of a user in a workspace. Email is read from the
+// user when listing the members.
+type Membership struct {
+	WorkspaceID uuid.UUID     `json:"workspace_id" gorm:"primaryKey"`
+	UserID      uuid.UUID     `json:"user_id" gorm:"primaryKey;index"`
+	Role        WorkspaceRole `json:"role"`
+	Email       string        `json:"email" gorm:"->;-:migration"`
+	CreatedAt   *time.Time    `json:"created_at"`
+}
+
+func (Membership) TableName() string { return "workspace_members" }
+
+const maxWorkspaceNameLength = 100
+
+type WorkspaceCreation struct {
+	Name string `json:"name"`
+}
+
+func (w *WorkspaceCreation) Validate() error {
+	w.Name = strings.TrimSpace(w.Name)
+
+	if w.Name == "" {
+		return errors.New("name can not be null")
+	}
+
+	if len(w.Name) > maxWorkspaceNameLength {
+		return errors.New("name is too long")
+	}
+
+	return nil
+}
+
+// MemberCreation adds the user registered with Email to a workspace.
+type MemberCreation struct {
+	Email string        `json:"email"`
+	Role  WorkspaceRole `json:"role"`
+}
+
+// Validate normalizes the email and defaults the role to member.
+func (m *MemberCreation) Validate() error {
+	var validationErrors []string
+
+	m.Email = strings.ToLower(strings.TrimSpace(m.Email))
+	if _, err := mail.ParseAddress(m.Email); err != nil {
+		validationErrors = append(validationErrors, "email is invalid")
+	}
+
+	if m.Role == "" {
+		m.Role = WorkspaceMember
+	}
+
+	if !m.Role.Valid() {
+		validationErrors = append(validationErrors, "role must be viewer, member, admin or owner")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type MemberUpdate struct {
+	Role WorkspaceRole `json:"role"`
+}
+
+func (m *MemberUpdate) Validate() error {
+	if !m.Role.Valid() {
+		return errors.New("role must be viewer, member, admin or owner")
+	}
+
+	return nil
+}
+
+// Tenant is the space a request works in: the workspace when WorkspaceID is
+// set, otherwise the personal space of the user.
+type Tenant struct {
+	UserID      uuid.UUID
+	WorkspaceID *uuid.UUID
+}
+
+// Conditions returns the repository conditions confining a query to the
+// tenant. Personal data is the data of the user outside of any workspace.
+func (t Tenant) Conditions() map[string]any {
+	if t.WorkspaceID != nil {
+		return map[string]any{"workspace_id": *t.WorkspaceID}
+	}
+
+	return map[string]any{"user_id": t.UserID, "workspace_id": nil}
+}
+
+var (
+	ErrNotWorkspaceMember = clients.NewCustomError(
+		errors.New("not a member of the workspace"),
+		"not a member of the workspace",
+		"ErrNotWorkspaceMember",
+	)
+
+	ErrMemberExisted = clients.NewCustomError(
+		errors.New("user is already a member of the workspace"),
+		"user is already a member of the workspace",
+		"ErrMemberExisted",
+	)
+
+	ErrLastOwner = clients.NewCustomError(
+		errors.New("a workspace needs at least one owner"),
+		"a workspace needs at least one owner",
+		"ErrLastOwner",
+	)
+
+	ErrSoleWorkspaceOwner = clients.NewCustomError(
+		errors.New("user is the only owner of a workspace"),
+		"hand over or delete the workspaces you are the only owner of first",
+		"ErrSoleWorkspaceOwner",
+	)
+
+	ErrWorkspaceShare = clients.NewCustomError(
+		errors.New("workspace items are shared through the workspace members"),
+		"workspace items are shared through the workspace members",
+		"ErrWorkspaceShare",
+	)
+)
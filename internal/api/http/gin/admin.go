@@ -0,0 +1,309 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminService interface {
+	ListUsers(actorID uuid.UUID, filter *domain.UserFilter, paging *clients.Paging) ([]domain.User, error)
+	GetUser(actorID, id uuid.UUID) (*domain.User, error)
+	BanUser(actorID, id uuid.UUID) error
+	UnbanUser(actorID, id uuid.UUID) error
+	ChangeRole(actorID, id uuid.UUID, data *domain.RoleChange) error
+	GetUserItems(actorID, id uuid.UUID, filter *domain.ItemFilter, paging *clients.Paging) ([]domain.Item, error)
+	GetAuditLog(actorID uuid.UUID, filter *domain.AuditFilter, paging *clients.Paging) ([]domain.AuditEntry, error)
+}
+
+type adminHandler struct {
+	adminService AdminService
+}
+
+func NewAdminHandler(apiVersion *gin.RouterGroup, svc AdminService, middlewareAuth, middlewareSession, middlewareAdmin func(c *gin.Context)) {
+	adminHandler := &adminHandler{
+		adminService: svc,
+	}
+
+	admin := apiVersion.Group("/admin", middlewareAuth, middlewareSession, middlewareAdmin)
+	admin.GET("/users", adminHandler.ListUsersHandler)
+	admin.GET("/users/:id", adminHandler.GetUserHandler)
+	admin.POST("/users/:id/ban", adminHandler.BanUserHandler)
+	admin.POST("/users/:id/unban", adminHandler.UnbanUserHandler)
+	admin.PUT("/users/:id/role", adminHandler.ChangeRoleHandler)
+	admin.GET("/users/:id/items", adminHandler.GetUserItemsHandler)
+	admin.GET("/audit", adminHandler.GetAuditLogHandler)
+}
+
+// ListUsersHandler lists and searches the users.
+//
+// @Summary      List users
+// @Description  This endpoint lets admins list users, newest first, optionally searched by email or name and filtered by status or role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        q       query     string              false  "Case-insensitive substring of the email, first or last name"
+// @Param        status  query     int                 false  "Only users with this status"
+// @Param        role    query     string              false  "Only users with this role, user or admin"
+// @Param        page    query     int                 false  "Page number"
+// @Param        limit   query     int                 false  "Page size"
+// @Success      200  {object}  clients.SuccessRes  "List of users retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request or no permission"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /admin/users [get]
+func (h *adminHandler) ListUsersHandler(c *gin.Context) {
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	var filter domain.UserFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	users, err := h.adminService.ListUsers(requester.GetUserID(), &filter, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(users, paging, filter))
+}
+
+// GetUserHandler retrieves any user by their ID.
+//
+// @Summary      Get a user
+// @Description  This endpoint lets admins retrieve any user by their ID.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string              true  "User ID"
+// @Success      200  {object}  clients.SuccessRes  "User retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format or no permission"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /admin/users/{id} [get]
+func (h *adminHandler) GetUserHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	user, err := h.adminService.GetUser(requester.GetUserID(), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(user))
+}
+
+// BanUserHandler bans a user.
+//
+// @Summary      Ban a user
+// @Description  This endpoint lets admins deactivate a user and sign them out everywhere. Their data is kept.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string              true  "User ID"
+// @Success      200  {object}  clients.SuccessRes  "User banned successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format, own account or no permission"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /admin/users/{id}/ban [post]
+func (h *adminHandler) BanUserHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.adminService.BanUser(requester.GetUserID(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// UnbanUserHandler lifts the ban of a user.
+//
+// @Summary      Unban a user
+// @Description  This endpoint lets admins reactivate a banned user. Users who deleted their account can not be unbanned.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string              true  "User ID"
+// @Success      200  {object}  clients.SuccessRes  "User unbanned successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format, user not banned or no permission"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /admin/users/{id}/unban [post]
+func (h *adminHandler) UnbanUserHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.adminService.UnbanUser(requester.GetUserID(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// ChangeRoleHandler changes the role of a user.
+//
+// @Summary      Change the role of a user
+// @Description  This endpoint lets admins make a user an admin or a regular user again.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string             true  "User ID"
+// @Param        role  body      domain.RoleChange  true  "New role, user or admin"
+// @Success      200  {object}  clients.SuccessRes  "Role changed successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format or role, own account or no permission"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /admin/users/{id}/role [put]
+func (h *adminHandler) ChangeRoleHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var data domain.RoleChange
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.adminService.ChangeRole(requester.GetUserID(), id, &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// GetUserItemsHandler lists the items of any user.
+//
+// @Summary      List the items of a user
+// @Description  This endpoint lets admins list the items of any user, with the filters of the item listing.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string              true   "User ID"
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Success      200  {object}  clients.SuccessRes  "List of items retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request or no permission"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /admin/users/{id}/items [get]
+func (h *adminHandler) GetUserItemsHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	var filter domain.ItemFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	items, err := h.adminService.GetUserItems(requester.GetUserID(), id, &filter, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(items, paging, filter))
+}
+
+// GetAuditLogHandler lists the audit log of admin actions.
+//
+// @Summary      Get the audit log
+// @Description  This endpoint lists the recorded admin actions, newest first.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        actor_id   query     string              false  "Only actions of this admin"
+// @Param        target_id  query     string              false  "Only actions on this user"
+// @Param        action     query     string              false  "Only this action, e.g. users.ban"
+// @Param        page       query     int                 false  "Page number"
+// @Param        limit      query     int                 false  "Page size"
+// @Success      200  {object}  clients.SuccessRes  "Audit log retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request or no permission"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /admin/audit [get]
+func (h *adminHandler) GetAuditLogHandler(c *gin.Context) {
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	var filter domain.AuditFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	entries, err := h.adminService.GetAuditLog(requester.GetUserID(), &filter, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(entries, paging, filter))
+}
@@ -0,0 +1,225 @@
+package gin
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// multipartOverhead is the room left for the multipart framing on top of the
+// largest attachment.
+const multipartOverhead = 1 << 20
+
+type AttachmentService interface {
+	UploadAttachment(itemID, userID uuid.UUID, upload *domain.AttachmentUpload) (*domain.Attachment, error)
+	GetAttachments(itemID, userID uuid.UUID) ([]domain.Attachment, error)
+	OpenAttachment(itemID, id, userID uuid.UUID) (*domain.Attachment, io.ReadSeekCloser, error)
+	DeleteAttachment(itemID, id, userID uuid.UUID) error
+}
+
+type attachmentHandler struct {
+	attachmentService AttachmentService
+	maxSize           int64
+}
+
+// NewAttachmentHandler registers the attachment routes. Request bodies larger
+// than maxSize plus the multipart framing are cut off before being parsed.
+func NewAttachmentHandler(apiVersion *gin.RouterGroup, svc AttachmentService, middlewareAuth func(c *gin.Context), maxSize int64) {
+	attachmentHandler := &attachmentHandler{
+		attachmentService: svc,
+		maxSize:           maxSize,
+	}
+
+	items := apiVersion.Group("/items", middlewareAuth)
+	items.POST("/:id/attachments", attachmentHandler.UploadAttachmentHandler)
+	items.GET("/:id/attachments", attachmentHandler.GetAttachmentsHandler)
+	items.GET("/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachmentHandler)
+	items.DELETE("/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachmentHandler)
+}
+
+// UploadAttachmentHandler attaches a file to an item.
+//
+// @Summary      Attach a file to an item
+// @Description  This endpoint uploads a file as multipart form data and attaches it to an item the current user can edit. The content type is detected from the file and has to be one of the allowed types; files larger than the configured limit are refused.
+// @Tags         Attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      string              true  "Item ID"
+// @Param        file  formData  file                true  "File to attach"
+// @Success      200   {object}  clients.SuccessRes  "Attachment created"
+// @Failure      400   {object}  clients.AppError    "Bad Request"
+// @Failure      500   {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/attachments [post]
+func (h *attachmentHandler) UploadAttachmentHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusBadRequest, domain.ErrAttachmentTooLarge)
+
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	defer file.Close()
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	attachment, err := h.attachmentService.UploadAttachment(itemID, requester.GetUserID(), &domain.AttachmentUpload{
+		FileName: header.Filename,
+		Size:     header.Size,
+		Content:  file,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(attachment))
+}
+
+// GetAttachmentsHandler lists the attachments of an item.
+//
+// @Summary      Get the attachments of an item
+// @Description  This endpoint lists the attachments of an item the current user can view, oldest first.
+// @Tags         Attachments
+// @Produce      json
+// @Param        id   path      string              true  "Item ID"
+// @Success      200  {object}  clients.SuccessRes  "Attachments retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/attachments [get]
+func (h *attachmentHandler) GetAttachmentsHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	attachments, err := h.attachmentService.GetAttachments(itemID, requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(attachments))
+}
+
+// DownloadAttachmentHandler streams the content of an attachment.
+//
+// @Summary      Download an attachment
+// @Description  This endpoint streams the content of an attachment of an item the current user can view. Range requests are supported, so downloads can be resumed.
+// @Tags         Attachments
+// @Produce      octet-stream
+// @Param        id             path      string            true   "Item ID"
+// @Param        attachment_id  path      string            true   "Attachment ID"
+// @Param        Range          header    string            false  "Byte range, e.g. bytes=0-1023"
+// @Success      200            {file}    file              "Attachment content"
+// @Success      206            {file}    file              "Partial attachment content"
+// @Failure      400            {object}  clients.AppError  "Bad Request"
+// @Failure      500            {object}  clients.AppError  "Internal Server Error"
+// @Router       /items/{id}/attachments/{attachment_id} [get]
+func (h *attachmentHandler) DownloadAttachmentHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("attachment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	attachment, content, err := h.attachmentService.OpenAttachment(itemID, id, requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+	defer content.Close()
+
+	var modified time.Time
+	if attachment.CreatedAt != nil {
+		modified = *attachment.CreatedAt
+	}
+
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, "", modified, content)
+}
+
+// DeleteAttachmentHandler removes an attachment.
+//
+// @Summary      Delete an attachment
+// @Description  This endpoint removes an attachment, and its content, from an item the current user can edit.
+// @Tags         Attachments
+// @Produce      json
+// @Param        id             path      string              true  "Item ID"
+// @Param        attachment_id  path      string              true  "Attachment ID"
+// @Success      200            {object}  clients.SuccessRes  "Attachment deleted"
+// @Failure      400            {object}  clients.AppError    "Bad Request"
+// @Failure      500            {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/attachments/{attachment_id} [delete]
+func (h *attachmentHandler) DeleteAttachmentHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("attachment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.attachmentService.DeleteAttachment(itemID, id, requester.GetUserID()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
@@ -0,0 +1,185 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CommentService interface {
+	CreateComment(itemID, userID uuid.UUID, data *domain.CommentCreation) (*domain.Comment, error)
+	GetComments(itemID, userID uuid.UUID, paging *clients.Paging) ([]domain.Comment, error)
+	UpdateComment(id, userID uuid.UUID, data *domain.CommentCreation) error
+	DeleteComment(id, userID uuid.UUID) error
+}
+
+type commentHandler struct {
+	commentService CommentService
+}
+
+func NewCommentHandler(apiVersion *gin.RouterGroup, svc CommentService, middlewareAuth func(c *gin.Context)) {
+	commentHandler := &commentHandler{
+		commentService: svc,
+	}
+
+	items := apiVersion.Group("/items", middlewareAuth)
+	items.POST("/:id/comments", commentHandler.CreateCommentHandler)
+	items.GET("/:id/comments", commentHandler.GetCommentsHandler)
+
+	comments := apiVersion.Group("/comments", middlewareAuth)
+	comments.PATCH("/:id", commentHandler.UpdateCommentHandler)
+	comments.DELETE("/:id", commentHandler.DeleteCommentHandler)
+}
+
+// CreateCommentHandler adds a comment to an item.
+//
+// @Summary      Comment on an item
+// @Description  This endpoint adds a markdown comment to an item the current user can view. Users mentioned as @email who can view the item are listed in the mentions of the comment.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Item ID"
+// @Param        comment  body      domain.CommentCreation  true  "Comment payload"
+// @Success      200      {object}  clients.SuccessRes      "Comment created"
+// @Failure      400      {object}  clients.AppError        "Bad Request"
+// @Failure      500      {object}  clients.AppError        "Internal Server Error"
+// @Router       /items/{id}/comments [post]
+func (h *commentHandler) CreateCommentHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var data domain.CommentCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	comment, err := h.commentService.CreateComment(itemID, requester.GetUserID(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(comment))
+}
+
+// GetCommentsHandler lists the comments of an item.
+//
+// @Summary      Get the comments of an item
+// @Description  This endpoint lists the comments of an item the current user can view, oldest first.
+// @Tags         Comments
+// @Produce      json
+// @Param        id     path      string              true   "Item ID"
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Success      200    {object}  clients.SuccessRes  "Comments retrieved successfully"
+// @Failure      400    {object}  clients.AppError    "Bad Request"
+// @Failure      500    {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/comments [get]
+func (h *commentHandler) GetCommentsHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	comments, err := h.commentService.GetComments(itemID, requester.GetUserID(), &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(comments, paging, nil))
+}
+
+// UpdateCommentHandler edits a comment.
+//
+// @Summary      Edit a comment
+// @Description  This endpoint replaces the body of a comment written by the current user.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Comment ID"
+// @Param        comment  body      domain.CommentCreation  true  "Comment payload"
+// @Success      200      {object}  clients.SuccessRes      "Comment updated"
+// @Failure      400      {object}  clients.AppError        "Bad Request"
+// @Failure      500      {object}  clients.AppError        "Internal Server Error"
+// @Router       /comments/{id} [patch]
+func (h *commentHandler) UpdateCommentHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var data domain.CommentCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.commentService.UpdateComment(id, requester.GetUserID(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// DeleteCommentHandler deletes a comment.
+//
+// @Summary      Delete a comment
+// @Description  This endpoint deletes a comment written by the current user.
+// @Tags         Comments
+// @Produce      json
+// @Param        id   path      string              true  "Comment ID"
+// @Success      200  {object}  clients.SuccessRes  "Comment deleted"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /comments/{id} [delete]
+func (h *commentHandler) DeleteCommentHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.commentService.DeleteComment(id, requester.GetUserID()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
@@ -2,6 +2,8 @@ package gin
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 	"todo-app/domain"
 	"todo-app/pkg/clients"
 
@@ -11,27 +13,54 @@ import (
 
 type ItemService interface {
 	CreateItem(item *domain.ItemCreation) error
-	GetAllItem(userID uuid.UUID, paging *clients.Paging) ([]domain.Item, error)
+	GetAllItem(tenant domain.Tenant, filter *domain.ItemFilter, paging *clients.Paging) ([]domain.Item, error)
+	SearchItems(tenant domain.Tenant, search *domain.ItemSearch, paging *clients.Paging) ([]domain.ItemSearchResult, error)
+	GetDueItems(tenant domain.Tenant, view string, loc *time.Location, paging *clients.Paging) ([]domain.Item, error)
+	GetSubtasks(id, userID uuid.UUID, paging *clients.Paging) ([]domain.Item, error)
 	GetItemByID(id, userID uuid.UUID) (domain.Item, error)
 	UpdateItem(id, userID uuid.UUID, item *domain.ItemUpdate) error
 	DeleteItem(id, userID uuid.UUID) error
+	BatchItems(tenant domain.Tenant, batch *domain.ItemBatch) (*domain.ItemBatchReport, error)
+	GetTrash(tenant domain.Tenant, paging *clients.Paging) ([]domain.Item, error)
+	GetSharedItems(userID uuid.UUID, paging *clients.Paging) ([]domain.Item, error)
+	RestoreItem(id, userID uuid.UUID) error
+	PurgeItem(id, userID uuid.UUID) error
+	PreviewOccurrences(id, userID uuid.UUID, count int) ([]time.Time, error)
+	SkipOccurrence(id, userID uuid.UUID) (time.Time, error)
+	AssignItem(id, userID uuid.UUID, assignment *domain.ItemAssignment) error
+	GetItemHistory(id, userID uuid.UUID, paging *clients.Paging) ([]domain.ItemEvent, error)
 }
 
 type itemHandler struct {
 	itemService ItemService
 }
 
-func NewItemHandler(apiVersion *gin.RouterGroup, svc ItemService, middlewareAuth func(c *gin.Context), middlewareRateLimit func(c *gin.Context)) {
+func NewItemHandler(apiVersion *gin.RouterGroup, svc ItemService, middlewareAuth func(c *gin.Context), middlewareWorkspace func(c *gin.Context), middlewareRateLimit func(c *gin.Context)) {
 	itemHandler := &itemHandler{
 		itemService: svc,
 	}
 
-	items := apiVersion.Group("/items", middlewareAuth)
+	items := apiVersion.Group("/items", middlewareAuth, middlewareWorkspace)
 	items.POST("", itemHandler.CreateItemHandler)
+	items.POST("/batch", itemHandler.BatchItemsHandler)
 	items.GET("", middlewareRateLimit, itemHandler.GetAllItemHandler)
+	items.GET("/search", middlewareRateLimit, itemHandler.SearchItemsHandler)
+	items.GET("/today", middlewareRateLimit, itemHandler.GetTodayItemsHandler)
+	items.GET("/upcoming", middlewareRateLimit, itemHandler.GetUpcomingItemsHandler)
+	items.GET("/overdue", middlewareRateLimit, itemHandler.GetOverdueItemsHandler)
+	items.GET("/trash", itemHandler.GetTrashHandler)
+	items.GET("/shared", middlewareRateLimit, itemHandler.GetSharedItemsHandler)
 	items.GET("/:id", itemHandler.GetItemHandler)
 	items.PATCH("/:id", itemHandler.UpdateItemHandler)
 	items.DELETE("/:id", itemHandler.DeleteItemHandler)
+	items.POST("/:id/restore", itemHandler.RestoreItemHandler)
+	items.DELETE("/:id/purge", itemHandler.PurgeItemHandler)
+	items.GET("/:id/occurrences", itemHandler.GetOccurrencesHandler)
+	items.POST("/:id/skip", itemHandler.SkipOccurrenceHandler)
+	items.POST("/:id/assign", itemHandler.AssignItemHandler)
+	items.GET("/:id/history", itemHandler.GetItemHistoryHandler)
+	items.GET("/:id/subtasks", itemHandler.GetSubtasksHandler)
+	items.POST("/:id/subtasks", itemHandler.CreateSubtaskHandler)
 }
 
 // CreateItemHandler handles the creation of a new item.
@@ -42,6 +71,7 @@ func NewItemHandler(apiVersion *gin.RouterGroup, svc ItemService, middlewareAuth
 // @Accept       json
 // @Produce      json
 // @Param        item  body      domain.ItemCreation  true  "Item creation payload"
+// @Param        X-Workspace-ID header    string               false "Workspace to work in, the personal space when absent"
 // @Success      200   {object}  clients.SuccessRes   "Item successfully created"
 // @Failure      400   {object}  clients.AppError     "Bad Request"
 // @Failure      401   {object}  clients.AppError     "Unauthorized"
@@ -56,8 +86,9 @@ func (h *itemHandler) CreateItemHandler(c *gin.Context) {
 		return
 	}
 
-	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
-	item.UserID = requester.GetUserID()
+	tenant := currentTenant(c)
+	item.UserID = tenant.UserID
+	item.WorkspaceID = tenant.WorkspaceID
 	if err := h.itemService.CreateItem(&item); err != nil {
 		c.JSON(http.StatusBadRequest, err)
 
@@ -67,14 +98,66 @@ func (h *itemHandler) CreateItemHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(item.ID))
 }
 
+// BatchItemsHandler applies several item operations at once.
+//
+// @Summary      Create, update and delete items in one request
+// @Description  This endpoint applies up to 100 create, update or delete operations in one transaction. In atomic mode (default) any failure rolls back the whole batch; in best_effort mode only the failing operations are dropped. The result of every operation is reported.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        batch  body      domain.ItemBatch    true  "Batch of operations"
+// @Param        X-Workspace-ID header    string              false "Workspace to work in, the personal space when absent"
+// @Success      200    {object}  clients.SuccessRes  "Batch report, with committed false when an atomic batch was rolled back"
+// @Failure      400    {object}  clients.AppError    "Bad Request"
+// @Failure      401    {object}  clients.AppError    "Unauthorized"
+// @Failure      500    {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/batch [post]
+func (h *itemHandler) BatchItemsHandler(c *gin.Context) {
+	var batch domain.ItemBatch
+
+	if err := c.ShouldBind(&batch); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	report, err := h.itemService.BatchItems(currentTenant(c), &batch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(report))
+}
+
 // GetAllItemHandler retrieves all items.
 //
 // @Summary      Get all items
-// @Description  This endpoint retrieves a list of all items.
+// @Description  This endpoint retrieves a list of all items, optionally filtered, searched and sorted. The applied filter is echoed back.
 // @Tags         Items
 // @Accept       json
 // @Produce      json
+// @Param        page          query     int                 false  "Page number"
+// @Param        limit         query     int                 false  "Page size"
+// @Param        cursor        query     string              false  "next_cursor of the previous page; replaces page when sorting by created_at"
+// @Param        status        query     []int               false  "Only items with these statuses" collectionFormat(multi)
+// @Param        search        query     string              false  "Case-insensitive substring of the title or description"
+// @Param        created_from  query     string              false  "Only items created at or after this RFC 3339 time"
+// @Param        created_to    query     string              false  "Only items created at or before this RFC 3339 time"
+// @Param        updated_from  query     string              false  "Only items updated at or after this RFC 3339 time"
+// @Param        updated_to    query     string              false  "Only items updated at or before this RFC 3339 time"
+// @Param        due_before  query     string              false  "Only items due before this RFC 3339 time"
+// @Param        overdue     query     bool                false  "Only active items whose due date has passed"
+// @Param        list_id     query     string              false  "Only items of this list, or inbox for items without a list"
+// @Param        assigned_to_me  query  bool             false  "Only items assigned to the current user"
+// @Param        tag         query     []string            false  "Only items with these tag names" collectionFormat(multi)
+// @Param        tag_mode    query     string              false  "any (default) or all of the tags must match"
+// @Param        sort_by     query     string              false  "created_at (default), updated_at, due_at, title or status"
+// @Param        sort_dir    query     string              false  "asc or desc (default)"
+// @Param        X-Workspace-ID header    string              false  "Workspace to work in, the personal space when absent"
 // @Success      200  {object}  clients.SuccessRes  "List of items retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
 // @Failure      500  {object}  clients.AppError    "Internal Server Error"
 // @Router       /items [get]
 func (h *itemHandler) GetAllItemHandler(c *gin.Context) {
@@ -86,22 +169,149 @@ func (h *itemHandler) GetAllItemHandler(c *gin.Context) {
 	}
 	paging.Process()
 
-	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+	var filter domain.ItemFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	items, err := h.itemService.GetAllItem(currentTenant(c), &filter, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(items, paging, filter))
+}
+
+// SearchItemsHandler runs a full-text search over the user's items.
+//
+// @Summary      Search items
+// @Description  This endpoint searches the titles and descriptions of the user's items, most relevant first. Matched terms are wrapped in <mark> in the snippet.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        q      query     string              true   "Search query"
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Param        X-Workspace-ID header    string              false  "Workspace to work in, the personal space when absent"
+// @Success      200  {object}  clients.SuccessRes  "Ranked search results"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/search [get]
+func (h *itemHandler) SearchItemsHandler(c *gin.Context) {
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	var search domain.ItemSearch
+	if err := c.ShouldBind(&search); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	results, err := h.itemService.SearchItems(currentTenant(c), &search, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(results, paging, search))
+}
+
+// GetTodayItemsHandler retrieves the active items due today.
+//
+// @Summary      Get items due today
+// @Description  This endpoint retrieves the active items due during the current day in the given timezone.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        tz     query     string              false  "IANA timezone used to compute the day, defaults to UTC"
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Param        X-Workspace-ID header    string              false  "Workspace to work in, the personal space when absent"
+// @Success      200  {object}  clients.SuccessRes  "List of items retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/today [get]
+func (h *itemHandler) GetTodayItemsHandler(c *gin.Context) {
+	h.listDueItems(c, domain.DueViewToday)
+}
+
+// GetUpcomingItemsHandler retrieves the active items due within the next week.
+//
+// @Summary      Get upcoming items
+// @Description  This endpoint retrieves the active items due within the next seven days.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Param        X-Workspace-ID header    string              false  "Workspace to work in, the personal space when absent"
+// @Success      200  {object}  clients.SuccessRes  "List of items retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/upcoming [get]
+func (h *itemHandler) GetUpcomingItemsHandler(c *gin.Context) {
+	h.listDueItems(c, domain.DueViewUpcoming)
+}
+
+// GetOverdueItemsHandler retrieves the active items whose due date has passed.
+//
+// @Summary      Get overdue items
+// @Description  This endpoint retrieves the active items whose due date has passed.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Param        X-Workspace-ID header    string              false  "Workspace to work in, the personal space when absent"
+// @Success      200  {object}  clients.SuccessRes  "List of items retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/overdue [get]
+func (h *itemHandler) GetOverdueItemsHandler(c *gin.Context) {
+	h.listDueItems(c, domain.DueViewOverdue)
+}
+
+func (h *itemHandler) listDueItems(c *gin.Context, view string) {
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
 
-	items, err := h.itemService.GetAllItem(requester.GetUserID(), &paging)
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", "UTC"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
 
 		return
 	}
 
+	items, err := h.itemService.GetDueItems(currentTenant(c), view, loc, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
 	c.JSON(http.StatusOK, clients.NewSuccessResponse(items, paging, nil))
 }
 
 // GetItemHandler retrieves an item by its ID.
 //
 // @Summary      Get an item by ID
-// @Description  This endpoint retrieves a single item by its unique identifier.
+// @Description  This endpoint retrieves a single item by its unique identifier, with its subtasks and completion percentage.
 // @Tags         Items
 // @Accept       json
 // @Produce      json
@@ -171,10 +381,10 @@ func (h *itemHandler) UpdateItemHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
 }
 
-// DeleteItemHandler deletes an item by its ID.
+// DeleteItemHandler moves an item to the trash by its ID.
 //
 // @Summary      Delete an item
-// @Description  This endpoint deletes an item identified by its unique ID.
+// @Description  This endpoint moves the item identified by its unique ID, together with its subtasks, to the trash.
 // @Tags         Items
 // @Accept       json
 // @Produce      json
@@ -202,3 +412,371 @@ func (h *itemHandler) DeleteItemHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
 }
+
+// GetTrashHandler retrieves the trashed items.
+//
+// @Summary      Get trashed items
+// @Description  This endpoint retrieves the items in the trash, most recently deleted first.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Param        X-Workspace-ID header    string              false  "Workspace to work in, the personal space when absent"
+// @Success      200  {object}  clients.SuccessRes  "List of trashed items retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/trash [get]
+func (h *itemHandler) GetTrashHandler(c *gin.Context) {
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	items, err := h.itemService.GetTrash(currentTenant(c), &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(items, paging, nil))
+}
+
+// GetSharedItemsHandler retrieves the items shared with the user.
+//
+// @Summary      Get items shared with me
+// @Description  This endpoint retrieves the items other users shared with the current user, directly or through a shared list, newest first.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Param        cursor query     string              false  "next_cursor of the previous page"
+// @Success      200  {object}  clients.SuccessRes  "List of shared items retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/shared [get]
+func (h *itemHandler) GetSharedItemsHandler(c *gin.Context) {
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	items, err := h.itemService.GetSharedItems(requester.GetUserID(), &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(items, paging, nil))
+}
+
+// RestoreItemHandler restores a trashed item by its ID.
+//
+// @Summary      Restore an item
+// @Description  This endpoint brings a trashed item back with its previous status, together with the subtasks trashed along with it.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string                 true  "Item ID"
+// @Success      200  {object}  clients.SuccessRes     "Item restored successfully"
+// @Failure      400  {object}  clients.AppError       "Invalid ID format, item not in the trash or parent still trashed"
+// @Failure      500  {object}  clients.AppError       "Internal Server Error"
+// @Router       /items/{id}/restore [post]
+func (h *itemHandler) RestoreItemHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.itemService.RestoreItem(id, requester.GetUserID()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// PurgeItemHandler permanently deletes a trashed item by its ID.
+//
+// @Summary      Purge an item
+// @Description  This endpoint permanently deletes a trashed item and all of its subtasks.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string                 true  "Item ID"
+// @Success      200  {object}  clients.SuccessRes     "Item purged successfully"
+// @Failure      400  {object}  clients.AppError       "Invalid ID format or item not in the trash"
+// @Failure      500  {object}  clients.AppError       "Internal Server Error"
+// @Router       /items/{id}/purge [delete]
+func (h *itemHandler) PurgeItemHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.itemService.PurgeItem(id, requester.GetUserID()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// GetOccurrencesHandler previews the upcoming occurrences of a recurring item.
+//
+// @Summary      Preview occurrences
+// @Description  This endpoint lists the due dates of the next occurrences of a recurring item after its current one.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string              true   "Item ID"
+// @Param        count  query     int                 false  "Number of occurrences, 1 to 50, defaults to 5"
+// @Success      200  {object}  clients.SuccessRes  "Occurrences retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format, invalid count or item not recurring"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/occurrences [get]
+func (h *itemHandler) GetOccurrencesHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", "5"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	occurrences, err := h.itemService.PreviewOccurrences(id, requester.GetUserID(), count)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(occurrences))
+}
+
+// SkipOccurrenceHandler moves a recurring item on to its next occurrence.
+//
+// @Summary      Skip an occurrence
+// @Description  This endpoint moves the due date and reminder of a recurring item to its next occurrence without completing it, and returns the new due date.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string              true  "Item ID"
+// @Success      200  {object}  clients.SuccessRes  "Occurrence skipped successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format, item not recurring or no further occurrence"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/skip [post]
+func (h *itemHandler) SkipOccurrenceHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	dueAt, err := h.itemService.SkipOccurrence(id, requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(dueAt))
+}
+
+// CreateSubtaskHandler creates a subtask below an existing item.
+//
+// @Summary      Create a subtask
+// @Description  This endpoint creates a new item as a subtask of the item identified by its ID.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string               true  "Parent item ID"
+// @Param        item  body      domain.ItemCreation  true  "Subtask creation payload"
+// @Param        X-Workspace-ID header    string               false "Workspace to work in, the personal space when absent"
+// @Success      200   {object}  clients.SuccessRes   "Subtask successfully created"
+// @Failure      400   {object}  clients.AppError     "Bad Request"
+// @Failure      401   {object}  clients.AppError     "Unauthorized"
+// @Failure      500   {object}  clients.AppError     "Internal Server Error"
+// @Router       /items/{id}/subtasks [post]
+func (h *itemHandler) CreateSubtaskHandler(c *gin.Context) {
+	parentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var item domain.ItemCreation
+	if err := c.ShouldBind(&item); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	tenant := currentTenant(c)
+	item.UserID = tenant.UserID
+	item.WorkspaceID = tenant.WorkspaceID
+	item.ParentID = &parentID
+	if err := h.itemService.CreateItem(&item); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(item.ID))
+}
+
+// GetSubtasksHandler retrieves the direct subtasks of an item.
+//
+// @Summary      Get subtasks of an item
+// @Description  This endpoint retrieves the direct subtasks of the item identified by its ID.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string              true   "Parent item ID"
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Success      200  {object}  clients.SuccessRes  "List of subtasks retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format or bad request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/subtasks [get]
+func (h *itemHandler) GetSubtasksHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	items, err := h.itemService.GetSubtasks(id, requester.GetUserID(), &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(items, paging, nil))
+}
+
+// AssignItemHandler hands an item to a user or takes it back.
+//
+// @Summary      Assign an item
+// @Description  This endpoint sets the assignee of the item identified by its ID; a null assignee_id unassigns it. The assignee must be able to view the item and can then change its status, but not delete it.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                 true  "Item ID"
+// @Param        assignment  body      domain.ItemAssignment  true  "Assignee of the item"
+// @Success      200  {object}  clients.SuccessRes  "Item assigned successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format, no permission or assignee without access"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/assign [post]
+func (h *itemHandler) AssignItemHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var assignment domain.ItemAssignment
+	if err := c.ShouldBind(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.itemService.AssignItem(id, requester.GetUserID(), &assignment); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// GetItemHistoryHandler lists the recorded changes of an item.
+//
+// @Summary      Get the history of an item
+// @Description  This endpoint lists the recorded changes of the item identified by its ID, such as changes of its assignee, newest first.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string              true   "Item ID"
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Success      200  {object}  clients.SuccessRes  "History retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format or bad request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /items/{id}/history [get]
+func (h *itemHandler) GetItemHistoryHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	events, err := h.itemService.GetItemHistory(id, requester.GetUserID(), &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(events, paging, nil))
+}
@@ -0,0 +1,37 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/pkg/tokenprovider"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KeySet interface {
+	JWKS() tokenprovider.JSONWebKeySet
+}
+
+type jwksHandler struct {
+	keys KeySet
+}
+
+func NewJWKSHandler(r *gin.RouterGroup, keys KeySet) {
+	jwksHandler := &jwksHandler{
+		keys: keys,
+	}
+
+	r.GET("/.well-known/jwks.json", jwksHandler.GetJWKSHandler)
+}
+
+// GetJWKSHandler publishes the public keys verifying the access tokens.
+//
+// @Summary      Get the token signing keys
+// @Description  Returns the JSON Web Key Set of the keys verifying access tokens. Tokens name their key in the kid header; keys being rotated out stay listed until the tokens they signed expire.
+// @Tags         Keys
+// @Produce      json
+// @Success      200  {object}  tokenprovider.JSONWebKeySet
+// @Router       /.well-known/jwks.json [get]
+func (h *jwksHandler) GetJWKSHandler(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -0,0 +1,204 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ListService interface {
+	CreateList(list *domain.ListCreation) error
+	GetAllList(tenant domain.Tenant, paging *clients.Paging) ([]domain.List, error)
+	GetListByID(id uuid.UUID, tenant domain.Tenant) (domain.List, error)
+	UpdateList(id uuid.UUID, tenant domain.Tenant, list *domain.ListUpdate) error
+	DeleteList(id uuid.UUID, tenant domain.Tenant, mode string) error
+}
+
+type listHandler struct {
+	listService ListService
+}
+
+func NewListHandler(apiVersion *gin.RouterGroup, svc ListService, middlewareAuth func(c *gin.Context), middlewareWorkspace func(c *gin.Context)) {
+	listHandler := &listHandler{
+		listService: svc,
+	}
+
+	lists := apiVersion.Group("/lists", middlewareAuth, middlewareWorkspace)
+	lists.POST("", listHandler.CreateListHandler)
+	lists.GET("", listHandler.GetAllListHandler)
+	lists.GET("/:id", listHandler.GetListHandler)
+	lists.PATCH("/:id", listHandler.UpdateListHandler)
+	lists.DELETE("/:id", listHandler.DeleteListHandler)
+}
+
+// CreateListHandler handles the creation of a new list.
+//
+// @Summary      Create a new list
+// @Description  This endpoint allows authenticated users to create a named list to group items.
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        list  body      domain.ListCreation  true  "List creation payload"
+// @Param        X-Workspace-ID header    string               false "Workspace to work in, the personal space when absent"
+// @Success      200   {object}  clients.SuccessRes   "List successfully created"
+// @Failure      400   {object}  clients.AppError     "Bad Request"
+// @Failure      401   {object}  clients.AppError     "Unauthorized"
+// @Failure      500   {object}  clients.AppError     "Internal Server Error"
+// @Router       /lists [post]
+func (h *listHandler) CreateListHandler(c *gin.Context) {
+	var list domain.ListCreation
+
+	if err := c.ShouldBind(&list); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	tenant := currentTenant(c)
+	list.UserID = tenant.UserID
+	list.WorkspaceID = tenant.WorkspaceID
+	if err := h.listService.CreateList(&list); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(list.ID))
+}
+
+// GetAllListHandler retrieves the lists of the current user.
+//
+// @Summary      Get all lists
+// @Description  This endpoint retrieves the active and archived lists of the current user.
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int                 false  "Page number"
+// @Param        limit  query     int                 false  "Page size"
+// @Param        X-Workspace-ID header    string              false  "Workspace to work in, the personal space when absent"
+// @Success      200  {object}  clients.SuccessRes  "List of lists retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /lists [get]
+func (h *listHandler) GetAllListHandler(c *gin.Context) {
+	var paging clients.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+	paging.Process()
+
+	lists, err := h.listService.GetAllList(currentTenant(c), &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.NewSuccessResponse(lists, paging, nil))
+}
+
+// GetListHandler retrieves a list by its ID.
+//
+// @Summary      Get a list by ID
+// @Description  This endpoint retrieves a single list by its unique identifier.
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string              true  "List ID"
+// @Param        X-Workspace-ID header    string              false "Workspace to work in, the personal space when absent"
+// @Success      200  {object}  clients.SuccessRes  "List retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format or bad request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /lists/{id} [get]
+func (h *listHandler) GetListHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	list, err := h.listService.GetListByID(id, currentTenant(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(list))
+}
+
+// UpdateListHandler renames a list.
+//
+// @Summary      Update a list
+// @Description  This endpoint allows renaming an existing list by its ID.
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string             true  "List ID"
+// @Param        list  body      domain.ListUpdate  true  "List update payload"
+// @Param        X-Workspace-ID header    string             false "Workspace to work in, the personal space when absent"
+// @Success      200   {object}  clients.SuccessRes "List updated successfully"
+// @Failure      400   {object}  clients.AppError   "Invalid input or bad request"
+// @Failure      500   {object}  clients.AppError   "Internal Server Error"
+// @Router       /lists/{id} [patch]
+func (h *listHandler) UpdateListHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	list := domain.ListUpdate{}
+	if err := c.ShouldBind(&list); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	if err := h.listService.UpdateList(id, currentTenant(c), &list); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// DeleteListHandler deletes or archives a list.
+//
+// @Summary      Delete a list
+// @Description  With mode=inbox (default) the list is deleted and its items move back to the inbox; with mode=archive the list and its items are archived.
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string              true   "List ID"
+// @Param        mode  query     string              false  "inbox or archive"
+// @Param        X-Workspace-ID header    string              false  "Workspace to work in, the personal space when absent"
+// @Success      200   {object}  clients.SuccessRes  "List deleted successfully"
+// @Failure      400   {object}  clients.AppError    "Invalid ID format or bad request"
+// @Failure      500   {object}  clients.AppError    "Internal Server Error"
+// @Router       /lists/{id} [delete]
+func (h *listHandler) DeleteListHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	mode := c.DefaultQuery("mode", domain.ListDeleteInbox)
+	if err := h.listService.DeleteList(id, currentTenant(c), mode); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
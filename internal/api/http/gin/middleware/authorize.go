@@ -8,26 +8,85 @@ import (
 	"todo-app/pkg/tokenprovider"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type AuthenRepo interface {
 	GetUser(conditions map[string]interface{}) (*domain.User, error)
 }
 
-func RequiredAuth(tokenProvider tokenprovider.Provider, userRepo AuthenRepo) func(c *gin.Context) {
+// RevocationList tells whether an access token was revoked, by its ID.
+type RevocationList interface {
+	IsRevoked(jti string) (bool, error)
+}
+
+// APIKeyAuthenticator resolves an API key to the active key record.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(key string) (*domain.APIKey, error)
+}
+
+// SessionAuthenticator checks that the session of an access token is active.
+type SessionAuthenticator interface {
+	AuthenticateSession(id uuid.UUID) error
+}
+
+// APIKeyHeader carries an API key instead of a bearer token.
+const APIKeyHeader = "X-API-Key"
+
+// RequiredAuth authenticates the request by the bearer access token, or by
+// the API key header when there is one. Either way the user is stored under
+// clients.CurrentUser; the token payload is only stored for access tokens
+// and the key only for API keys. Access tokens of revoked sessions are
+// rejected.
+func RequiredAuth(tokenProvider tokenprovider.Provider, userRepo AuthenRepo, revocations RevocationList, apiKeys APIKeyAuthenticator, sessions SessionAuthenticator) func(c *gin.Context) {
 	return func(c *gin.Context) {
-		token, err := extractTokenFromHeaderString(c.GetHeader("Authorization"))
+		var userID uuid.UUID
 
-		if err != nil {
-			panic(err)
-		}
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			apiKey, err := apiKeys.AuthenticateAPIKey(key)
+			if err != nil {
+				panic(err)
+			}
 
-		payload, err := tokenProvider.Validate(token)
-		if err != nil {
-			panic(err)
+			if !apiKey.Allows(c.Request.Method) {
+				panic(domain.ErrAPIKeyScope)
+			}
+
+			userID = apiKey.UserID
+			c.Set(clients.CurrentAPIKey, apiKey)
+		} else {
+			token, err := extractTokenFromHeaderString(c.GetHeader("Authorization"))
+
+			if err != nil {
+				panic(err)
+			}
+
+			payload, err := tokenProvider.Validate(token)
+			if err != nil {
+				panic(err)
+			}
+
+			revoked, err := revocations.IsRevoked(payload.TokenID())
+			if err != nil {
+				panic(clients.ErrInternal(err))
+			}
+
+			if revoked {
+				panic(tokenprovider.ErrTokenRevoked)
+			}
+
+			// Tokens issued before sessions were tracked have none.
+			if sessionID := payload.SessionID(); sessionID != uuid.Nil {
+				if err := sessions.AuthenticateSession(sessionID); err != nil {
+					panic(err)
+				}
+			}
+
+			userID = payload.UserID()
+			c.Set(clients.CurrentToken, payload)
 		}
 
-		user, err := userRepo.GetUser(map[string]interface{}{"id": payload.UserID()})
+		user, err := userRepo.GetUser(map[string]interface{}{"id": userID})
 		if err != nil {
 			panic(err)
 		}
@@ -41,6 +100,19 @@ func RequiredAuth(tokenProvider tokenprovider.Provider, userRepo AuthenRepo) fun
 	}
 }
 
+// RequiredSession refuses requests authenticated by an API key. It guards
+// the endpoints managing the account and its credentials, so a leaked key
+// can not be used to take the account over. It runs after RequiredAuth.
+func RequiredSession() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(clients.CurrentToken); !ok {
+			panic(domain.ErrSessionRequired)
+		}
+
+		c.Next()
+	}
+}
+
 func extractTokenFromHeaderString(s string) (string, error) {
 	parts := strings.Split(s, " ")
 	//"Authorization" : "Bearer {token}"
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequiredRole only lets users holding role through. It runs after
+// RequiredAuth and checks the stored role of the user rather than the one in
+// the token, so a role change applies right away.
+func RequiredRole(role domain.UserRole) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		user, ok := c.MustGet(clients.CurrentUser).(*domain.User)
+		if !ok || !user.Role.Has(role) {
+			panic(clients.ErrNoPermission(errors.New("requires the " + role.String() + " role")))
+		}
+
+		c.Next()
+	}
+}
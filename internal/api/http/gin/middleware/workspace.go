@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WorkspaceMembers looks up the membership of a user in a workspace.
+type WorkspaceMembers interface {
+	GetMembership(workspaceID, userID uuid.UUID) (*domain.Membership, error)
+}
+
+// WorkspaceScope selects the workspace named by the workspace header for the
+// request, storing the membership of the user under clients.CurrentWorkspace.
+// Users outside of the workspace are refused. Without the header the request
+// works in the personal space of the user. It runs after RequiredAuth.
+func WorkspaceScope(members WorkspaceMembers) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		header := c.GetHeader(domain.WorkspaceHeader)
+		if header == "" {
+			c.Next()
+
+			return
+		}
+
+		workspaceID, err := uuid.Parse(header)
+		if err != nil {
+			panic(clients.ErrInvalidRequest(err))
+		}
+
+		user := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+		membership, err := members.GetMembership(workspaceID, user.GetUserID())
+		if err != nil {
+			panic(err)
+		}
+
+		c.Set(clients.CurrentWorkspace, membership)
+		c.Next()
+	}
+}
@@ -0,0 +1,232 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ShareService interface {
+	ShareItem(ownerID, itemID uuid.UUID, data *domain.ShareCreation) (*domain.Share, error)
+	ShareList(ownerID, listID uuid.UUID, data *domain.ShareCreation) (*domain.Share, error)
+	GetItemShares(ownerID, itemID uuid.UUID) ([]domain.Share, error)
+	GetListShares(ownerID, listID uuid.UUID) ([]domain.Share, error)
+	GetInvitations(userID uuid.UUID) ([]domain.Share, error)
+	AcceptInvitation(userID, id uuid.UUID) error
+	DeleteShare(userID, id uuid.UUID) error
+}
+
+type shareHandler struct {
+	shareService ShareService
+}
+
+func NewShareHandler(apiVersion *gin.RouterGroup, svc ShareService, middlewareAuth func(c *gin.Context)) {
+	shareHandler := &shareHandler{
+		shareService: svc,
+	}
+
+	items := apiVersion.Group("/items", middlewareAuth)
+	items.POST("/:id/shares", shareHandler.ShareItemHandler)
+	items.GET("/:id/shares", shareHandler.GetItemSharesHandler)
+
+	lists := apiVersion.Group("/lists", middlewareAuth)
+	lists.POST("/:id/shares", shareHandler.ShareListHandler)
+	lists.GET("/:id/shares", shareHandler.GetListSharesHandler)
+
+	shares := apiVersion.Group("/shares", middlewareAuth)
+	shares.GET("/invitations", shareHandler.GetInvitationsHandler)
+	shares.POST("/:id/accept", shareHandler.AcceptInvitationHandler)
+	shares.DELETE("/:id", shareHandler.DeleteShareHandler)
+}
+
+// ShareItemHandler invites a user to an item.
+//
+// @Summary      Share an item
+// @Description  This endpoint invites an email address to an item of the current user and its subtasks, as viewer or editor. The invitee is notified by mail.
+// @Tags         Shares
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                true  "Item ID"
+// @Param        share  body      domain.ShareCreation  true  "Invitation payload"
+// @Success      200    {object}  clients.SuccessRes    "Invitation created"
+// @Failure      400    {object}  clients.AppError      "Bad Request"
+// @Failure      500    {object}  clients.AppError      "Internal Server Error"
+// @Router       /items/{id}/shares [post]
+func (h *shareHandler) ShareItemHandler(c *gin.Context) {
+	h.share(c, h.shareService.ShareItem)
+}
+
+// ShareListHandler invites a user to a list.
+//
+// @Summary      Share a list
+// @Description  This endpoint invites an email address to every item of a list of the current user, as viewer or editor. The invitee is notified by mail.
+// @Tags         Shares
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                true  "List ID"
+// @Param        share  body      domain.ShareCreation  true  "Invitation payload"
+// @Success      200    {object}  clients.SuccessRes    "Invitation created"
+// @Failure      400    {object}  clients.AppError      "Bad Request"
+// @Failure      500    {object}  clients.AppError      "Internal Server Error"
+// @Router       /lists/{id}/shares [post]
+func (h *shareHandler) ShareListHandler(c *gin.Context) {
+	h.share(c, h.shareService.ShareList)
+}
+
+func (h *shareHandler) share(c *gin.Context, share func(ownerID, id uuid.UUID, data *domain.ShareCreation) (*domain.Share, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var data domain.ShareCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	created, err := share(requester.GetUserID(), id, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(created))
+}
+
+// GetItemSharesHandler lists the collaborators of an item.
+//
+// @Summary      Get the shares of an item
+// @Description  This endpoint lists the collaborators and pending invitations of an item of the current user.
+// @Tags         Shares
+// @Produce      json
+// @Param        id   path      string              true  "Item ID"
+// @Success      200  {object}  clients.SuccessRes  "Shares retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /items/{id}/shares [get]
+func (h *shareHandler) GetItemSharesHandler(c *gin.Context) {
+	h.listShares(c, h.shareService.GetItemShares)
+}
+
+// GetListSharesHandler lists the collaborators of a list.
+//
+// @Summary      Get the shares of a list
+// @Description  This endpoint lists the collaborators and pending invitations of a list of the current user.
+// @Tags         Shares
+// @Produce      json
+// @Param        id   path      string              true  "List ID"
+// @Success      200  {object}  clients.SuccessRes  "Shares retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /lists/{id}/shares [get]
+func (h *shareHandler) GetListSharesHandler(c *gin.Context) {
+	h.listShares(c, h.shareService.GetListShares)
+}
+
+func (h *shareHandler) listShares(c *gin.Context, list func(ownerID, id uuid.UUID) ([]domain.Share, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	shares, err := list(requester.GetUserID(), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(shares))
+}
+
+// GetInvitationsHandler lists the pending invitations of the current user.
+//
+// @Summary      Get my invitations
+// @Description  This endpoint lists the invitations to the email of the current user that are not accepted yet.
+// @Tags         Shares
+// @Produce      json
+// @Success      200  {object}  clients.SuccessRes  "Invitations retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /shares/invitations [get]
+func (h *shareHandler) GetInvitationsHandler(c *gin.Context) {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	shares, err := h.shareService.GetInvitations(requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(shares))
+}
+
+// AcceptInvitationHandler accepts an invitation.
+//
+// @Summary      Accept an invitation
+// @Description  This endpoint accepts an invitation to the email of the current user, which has to be verified.
+// @Tags         Shares
+// @Produce      json
+// @Param        id   path      string              true  "Share ID"
+// @Success      200  {object}  clients.SuccessRes  "Invitation accepted"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /shares/{id}/accept [post]
+func (h *shareHandler) AcceptInvitationHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.shareService.AcceptInvitation(requester.GetUserID(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// DeleteShareHandler removes a share.
+//
+// @Summary      Delete a share
+// @Description  This endpoint lets the owner revoke a share, the collaborator leave it, or the invitee decline it.
+// @Tags         Shares
+// @Produce      json
+// @Param        id   path      string              true  "Share ID"
+// @Success      200  {object}  clients.SuccessRes  "Share deleted"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /shares/{id} [delete]
+func (h *shareHandler) DeleteShareHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.shareService.DeleteShare(requester.GetUserID(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
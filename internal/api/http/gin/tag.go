@@ -0,0 +1,159 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagService interface {
+	CreateTag(tag *domain.TagCreation) error
+	GetAllTag(userID uuid.UUID) ([]domain.Tag, error)
+	UpdateTag(id, userID uuid.UUID, tag *domain.TagUpdate) error
+	DeleteTag(id, userID uuid.UUID) error
+}
+
+type tagHandler struct {
+	tagService TagService
+}
+
+func NewTagHandler(apiVersion *gin.RouterGroup, svc TagService, middlewareAuth func(c *gin.Context)) {
+	tagHandler := &tagHandler{
+		tagService: svc,
+	}
+
+	tags := apiVersion.Group("/tags", middlewareAuth)
+	tags.POST("", tagHandler.CreateTagHandler)
+	tags.GET("", tagHandler.GetAllTagHandler)
+	tags.PATCH("/:id", tagHandler.UpdateTagHandler)
+	tags.DELETE("/:id", tagHandler.DeleteTagHandler)
+}
+
+// CreateTagHandler handles the creation of a new tag.
+//
+// @Summary      Create a new tag
+// @Description  This endpoint allows authenticated users to create a colored tag. Tag names are unique per user.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        tag  body      domain.TagCreation  true  "Tag creation payload"
+// @Success      200  {object}  clients.SuccessRes  "Tag successfully created"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Failure      401  {object}  clients.AppError    "Unauthorized"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /tags [post]
+func (h *tagHandler) CreateTagHandler(c *gin.Context) {
+	var tag domain.TagCreation
+
+	if err := c.ShouldBind(&tag); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+	tag.UserID = requester.GetUserID()
+	if err := h.tagService.CreateTag(&tag); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(tag.ID))
+}
+
+// GetAllTagHandler retrieves the tags of the current user.
+//
+// @Summary      Get all tags
+// @Description  This endpoint retrieves every tag of the current user ordered by name.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  clients.SuccessRes  "List of tags retrieved successfully"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /tags [get]
+func (h *tagHandler) GetAllTagHandler(c *gin.Context) {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	tags, err := h.tagService.GetAllTag(requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(tags))
+}
+
+// UpdateTagHandler renames or recolors a tag.
+//
+// @Summary      Update a tag
+// @Description  This endpoint allows changing the name or color of an existing tag by its ID.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string              true  "Tag ID"
+// @Param        tag  body      domain.TagUpdate    true  "Tag update payload"
+// @Success      200  {object}  clients.SuccessRes  "Tag updated successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid input or bad request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /tags/{id} [patch]
+func (h *tagHandler) UpdateTagHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	tag := domain.TagUpdate{}
+	if err := c.ShouldBind(&tag); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.tagService.UpdateTag(id, requester.GetUserID(), &tag); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// DeleteTagHandler deletes a tag by its ID.
+//
+// @Summary      Delete a tag
+// @Description  This endpoint deletes a tag and removes it from every item.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string              true  "Tag ID"
+// @Success      200  {object}  clients.SuccessRes  "Tag deleted successfully"
+// @Failure      400  {object}  clients.AppError    "Invalid ID format or bad request"
+// @Failure      500  {object}  clients.AppError    "Internal Server Error"
+// @Router       /tags/{id} [delete]
+func (h *tagHandler) DeleteTagHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.tagService.DeleteTag(id, requester.GetUserID()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
@@ -1,31 +1,98 @@
 package gin
 
 import (
+	"context"
 	"net/http"
 	"todo-app/domain"
 	"todo-app/pkg/clients"
 	"todo-app/pkg/tokenprovider"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type UserService interface {
 	Register(data *domain.UserCreate) error
-	Login(data *domain.UserLogin) (tokenprovider.Token, error)
+	Login(data *domain.UserLogin) (*domain.LoginResult, error)
+	LoginTwoFactor(data *domain.TwoFactorLoginRequest) (*domain.TokenPair, error)
+	Refresh(data *domain.RefreshRequest) (*domain.TokenPair, error)
+	Logout(userID uuid.UUID, accessToken tokenprovider.TokenPayload, data *domain.LogoutRequest) error
+	VerifyEmail(data *domain.VerifyEmailRequest) error
+	ResendVerification(data *domain.EmailRequest) error
+	ForgotPassword(data *domain.EmailRequest) error
+	ResetPassword(data *domain.ResetPasswordRequest) error
+	GetProfile(userID uuid.UUID) (*domain.User, error)
+	UpdateProfile(userID uuid.UUID, data *domain.UserUpdate) error
+	ChangePassword(userID uuid.UUID, data *domain.ChangePasswordRequest) error
+	DeleteAccount(userID uuid.UUID, accessToken tokenprovider.TokenPayload) error
+	CreateAPIKey(userID uuid.UUID, data *domain.APIKeyCreation) (*domain.APIKeyCreated, error)
+	ListAPIKeys(userID uuid.UUID) ([]domain.APIKey, error)
+	RevokeAPIKey(userID, id uuid.UUID) error
+	ListSessions(userID, current uuid.UUID) ([]domain.Session, error)
+	RevokeSession(userID, id uuid.UUID) error
+	EnrollTwoFactor(userID uuid.UUID) (*domain.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(userID uuid.UUID, data *domain.TwoFactorCodeRequest) (*domain.RecoveryCodes, error)
+	DisableTwoFactor(userID uuid.UUID, data *domain.TwoFactorCodeRequest) error
+}
+
+type SSOService interface {
+	Start(ctx context.Context) (*domain.SSOStart, error)
+	Callback(ctx context.Context, data *domain.SSOCallback) (*domain.LoginResult, error)
 }
 
 type userHandler struct {
 	userService UserService
+	ssoService  SSOService
 }
 
-func NewUserHandler(apiVersion *gin.RouterGroup, svc UserService) {
+// NewUserHandler registers the user routes. ssoSvc is nil when no OpenID
+// Connect provider is configured, which leaves out the SSO routes.
+func NewUserHandler(apiVersion *gin.RouterGroup, svc UserService, ssoSvc SSOService, middlewareAuth func(c *gin.Context), middlewareSession func(c *gin.Context)) {
 	userHandler := &userHandler{
 		userService: svc,
+		ssoService:  ssoSvc,
 	}
 
 	users := apiVersion.Group("/users")
 	users.POST("/register", userHandler.RegisterUserHandler)
 	users.POST("/login", userHandler.LoginHandler)
+	users.POST("/login/2fa", userHandler.LoginTwoFactorHandler)
+	users.POST("/refresh", userHandler.RefreshHandler)
+	users.POST("/logout", middlewareAuth, middlewareSession, userHandler.LogoutHandler)
+	users.POST("/verify-email", userHandler.VerifyEmailHandler)
+	users.POST("/verify-email/resend", userHandler.ResendVerificationHandler)
+	users.POST("/forgot-password", userHandler.ForgotPasswordHandler)
+	users.POST("/reset-password", userHandler.ResetPasswordHandler)
+
+	if ssoSvc != nil {
+		// The provider may redirect to the callback itself, or the app may
+		// post the parameters it got.
+		users.GET("/oidc/login", userHandler.SSOStartHandler)
+		users.GET("/oidc/callback", userHandler.SSOCallbackHandler)
+		users.POST("/oidc/callback", userHandler.SSOCallbackHandler)
+	}
+
+	// API keys can read the profile; managing the account and its
+	// credentials needs a login session.
+	me := users.Group("/me", middlewareAuth)
+	me.GET("", userHandler.GetProfileHandler)
+	me.PATCH("", middlewareSession, userHandler.UpdateProfileHandler)
+	me.POST("/password", middlewareSession, userHandler.ChangePasswordHandler)
+	me.DELETE("", middlewareSession, userHandler.DeleteAccountHandler)
+
+	apiKeys := me.Group("/api-keys", middlewareSession)
+	apiKeys.POST("", userHandler.CreateAPIKeyHandler)
+	apiKeys.GET("", userHandler.ListAPIKeysHandler)
+	apiKeys.DELETE("/:id", userHandler.RevokeAPIKeyHandler)
+
+	sessions := me.Group("/sessions", middlewareSession)
+	sessions.GET("", userHandler.ListSessionsHandler)
+	sessions.DELETE("/:id", userHandler.RevokeSessionHandler)
+
+	twoFactor := me.Group("/2fa", middlewareSession)
+	twoFactor.POST("/enroll", userHandler.EnrollTwoFactorHandler)
+	twoFactor.POST("/confirm", userHandler.ConfirmTwoFactorHandler)
+	twoFactor.POST("/disable", userHandler.DisableTwoFactorHandler)
 }
 
 func (h *userHandler) RegisterUserHandler(c *gin.Context) {
@@ -55,6 +122,8 @@ func (h *userHandler) LoginHandler(c *gin.Context) {
 		return
 	}
 
+	data.Client = clientInfo(c)
+
 	token, err := h.userService.Login(&data)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, err)
@@ -64,3 +133,385 @@ func (h *userHandler) LoginHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(token))
 }
+
+func (h *userHandler) RefreshHandler(c *gin.Context) {
+	var data domain.RefreshRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	token, err := h.userService.Refresh(&data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(token))
+}
+
+func (h *userHandler) LogoutHandler(c *gin.Context) {
+	var data domain.LogoutRequest
+
+	// The body is optional: without it only the access token is revoked.
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBind(&data); err != nil {
+			c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+			return
+		}
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+	accessToken := c.MustGet(clients.CurrentToken).(tokenprovider.TokenPayload)
+
+	if err := h.userService.Logout(requester.GetUserID(), accessToken, &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) VerifyEmailHandler(c *gin.Context) {
+	var data domain.VerifyEmailRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	if err := h.userService.VerifyEmail(&data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) ResendVerificationHandler(c *gin.Context) {
+	var data domain.EmailRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	if err := h.userService.ResendVerification(&data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) ForgotPasswordHandler(c *gin.Context) {
+	var data domain.EmailRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	if err := h.userService.ForgotPassword(&data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) ResetPasswordHandler(c *gin.Context) {
+	var data domain.ResetPasswordRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	if err := h.userService.ResetPassword(&data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) GetProfileHandler(c *gin.Context) {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	user, err := h.userService.GetProfile(requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(user))
+}
+
+func (h *userHandler) UpdateProfileHandler(c *gin.Context) {
+	var data domain.UserUpdate
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.userService.UpdateProfile(requester.GetUserID(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) ChangePasswordHandler(c *gin.Context) {
+	var data domain.ChangePasswordRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.userService.ChangePassword(requester.GetUserID(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) DeleteAccountHandler(c *gin.Context) {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+	accessToken := c.MustGet(clients.CurrentToken).(tokenprovider.TokenPayload)
+
+	if err := h.userService.DeleteAccount(requester.GetUserID(), accessToken); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) CreateAPIKeyHandler(c *gin.Context) {
+	var data domain.APIKeyCreation
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	key, err := h.userService.CreateAPIKey(requester.GetUserID(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(key))
+}
+
+func (h *userHandler) ListAPIKeysHandler(c *gin.Context) {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	keys, err := h.userService.ListAPIKeys(requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(keys))
+}
+
+func (h *userHandler) RevokeAPIKeyHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.userService.RevokeAPIKey(requester.GetUserID(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) ListSessionsHandler(c *gin.Context) {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+	accessToken := c.MustGet(clients.CurrentToken).(tokenprovider.TokenPayload)
+
+	sessions, err := h.userService.ListSessions(requester.GetUserID(), accessToken.SessionID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(sessions))
+}
+
+func (h *userHandler) RevokeSessionHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.userService.RevokeSession(requester.GetUserID(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) LoginTwoFactorHandler(c *gin.Context) {
+	var data domain.TwoFactorLoginRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	data.Client = clientInfo(c)
+
+	token, err := h.userService.LoginTwoFactor(&data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(token))
+}
+
+func (h *userHandler) EnrollTwoFactorHandler(c *gin.Context) {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	enrollment, err := h.userService.EnrollTwoFactor(requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(enrollment))
+}
+
+func (h *userHandler) ConfirmTwoFactorHandler(c *gin.Context) {
+	var data domain.TwoFactorCodeRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	codes, err := h.userService.ConfirmTwoFactor(requester.GetUserID(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(codes))
+}
+
+func (h *userHandler) DisableTwoFactorHandler(c *gin.Context) {
+	var data domain.TwoFactorCodeRequest
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.userService.DisableTwoFactor(requester.GetUserID(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+func (h *userHandler) SSOStartHandler(c *gin.Context) {
+	start, err := h.ssoService.Start(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(start))
+}
+
+func (h *userHandler) SSOCallbackHandler(c *gin.Context) {
+	var data domain.SSOCallback
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	data.Client = clientInfo(c)
+
+	result, err := h.ssoService.Callback(c.Request.Context(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(result))
+}
+
+// clientInfo describes the client of a login request for its session.
+func clientInfo(c *gin.Context) domain.ClientInfo {
+	return domain.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
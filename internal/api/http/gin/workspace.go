@@ -0,0 +1,362 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WorkspaceService interface {
+	CreateWorkspace(userID uuid.UUID, data *domain.WorkspaceCreation) (*domain.Workspace, error)
+	GetWorkspaces(userID uuid.UUID) ([]domain.Workspace, error)
+	GetWorkspace(id, userID uuid.UUID) (*domain.Workspace, error)
+	UpdateWorkspace(id, userID uuid.UUID, data *domain.WorkspaceCreation) error
+	DeleteWorkspace(id, userID uuid.UUID) error
+	GetMembers(id, userID uuid.UUID) ([]domain.Membership, error)
+	AddMember(id, userID uuid.UUID, data *domain.MemberCreation) (*domain.Membership, error)
+	UpdateMember(id, userID, memberID uuid.UUID, data *domain.MemberUpdate) error
+	RemoveMember(id, userID, memberID uuid.UUID) error
+}
+
+type workspaceHandler struct {
+	workspaceService WorkspaceService
+}
+
+func NewWorkspaceHandler(apiVersion *gin.RouterGroup, svc WorkspaceService, middlewareAuth func(c *gin.Context)) {
+	workspaceHandler := &workspaceHandler{
+		workspaceService: svc,
+	}
+
+	workspaces := apiVersion.Group("/workspaces", middlewareAuth)
+	workspaces.POST("", workspaceHandler.CreateWorkspaceHandler)
+	workspaces.GET("", workspaceHandler.GetWorkspacesHandler)
+	workspaces.GET("/:id", workspaceHandler.GetWorkspaceHandler)
+	workspaces.PATCH("/:id", workspaceHandler.UpdateWorkspaceHandler)
+	workspaces.DELETE("/:id", workspaceHandler.DeleteWorkspaceHandler)
+	workspaces.GET("/:id/members", workspaceHandler.GetMembersHandler)
+	workspaces.POST("/:id/members", workspaceHandler.AddMemberHandler)
+	workspaces.PATCH("/:id/members/:user_id", workspaceHandler.UpdateMemberHandler)
+	workspaces.DELETE("/:id/members/:user_id", workspaceHandler.RemoveMemberHandler)
+}
+
+// currentTenant returns the space the request works in: the workspace
+// selected by the workspace middleware, otherwise the personal space of the
+// current user.
+func currentTenant(c *gin.Context) domain.Tenant {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+	tenant := domain.Tenant{UserID: requester.GetUserID()}
+
+	if membership, ok := c.Get(clients.CurrentWorkspace); ok {
+		workspaceID := membership.(*domain.Membership).WorkspaceID
+		tenant.WorkspaceID = &workspaceID
+	}
+
+	return tenant
+}
+
+// CreateWorkspaceHandler creates a workspace.
+//
+// @Summary      Create a workspace
+// @Description  This endpoint creates a workspace owned by the current user.
+// @Tags         Workspaces
+// @Accept       json
+// @Produce      json
+// @Param        workspace  body      domain.WorkspaceCreation  true  "Workspace creation payload"
+// @Success      200        {object}  clients.SuccessRes        "Workspace created"
+// @Failure      400        {object}  clients.AppError          "Bad Request"
+// @Router       /workspaces [post]
+func (h *workspaceHandler) CreateWorkspaceHandler(c *gin.Context) {
+	var data domain.WorkspaceCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	workspace, err := h.workspaceService.CreateWorkspace(requester.GetUserID(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(workspace))
+}
+
+// GetWorkspacesHandler lists the workspaces of the current user.
+//
+// @Summary      Get my workspaces
+// @Description  This endpoint lists the workspaces the current user is a member of, with their role in each.
+// @Tags         Workspaces
+// @Produce      json
+// @Success      200  {object}  clients.SuccessRes  "Workspaces retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /workspaces [get]
+func (h *workspaceHandler) GetWorkspacesHandler(c *gin.Context) {
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	workspaces, err := h.workspaceService.GetWorkspaces(requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(workspaces))
+}
+
+// GetWorkspaceHandler retrieves a workspace.
+//
+// @Summary      Get a workspace
+// @Description  This endpoint retrieves a workspace the current user is a member of.
+// @Tags         Workspaces
+// @Produce      json
+// @Param        id   path      string              true  "Workspace ID"
+// @Success      200  {object}  clients.SuccessRes  "Workspace retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /workspaces/{id} [get]
+func (h *workspaceHandler) GetWorkspaceHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	workspace, err := h.workspaceService.GetWorkspace(id, requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(workspace))
+}
+
+// UpdateWorkspaceHandler renames a workspace.
+//
+// @Summary      Update a workspace
+// @Description  This endpoint renames a workspace. It takes the admin role in the workspace.
+// @Tags         Workspaces
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                    true  "Workspace ID"
+// @Param        workspace  body      domain.WorkspaceCreation  true  "Workspace update payload"
+// @Success      200        {object}  clients.SuccessRes        "Workspace updated"
+// @Failure      400        {object}  clients.AppError          "Bad Request"
+// @Router       /workspaces/{id} [patch]
+func (h *workspaceHandler) UpdateWorkspaceHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var data domain.WorkspaceCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.workspaceService.UpdateWorkspace(id, requester.GetUserID(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// DeleteWorkspaceHandler deletes a workspace.
+//
+// @Summary      Delete a workspace
+// @Description  This endpoint deletes a workspace together with its lists and items. It takes the owner role in the workspace.
+// @Tags         Workspaces
+// @Produce      json
+// @Param        id   path      string              true  "Workspace ID"
+// @Success      200  {object}  clients.SuccessRes  "Workspace deleted"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /workspaces/{id} [delete]
+func (h *workspaceHandler) DeleteWorkspaceHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.workspaceService.DeleteWorkspace(id, requester.GetUserID()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// GetMembersHandler lists the members of a workspace.
+//
+// @Summary      Get the members of a workspace
+// @Description  This endpoint lists the members of a workspace the current user is a member of, with their roles and emails.
+// @Tags         Workspaces
+// @Produce      json
+// @Param        id   path      string              true  "Workspace ID"
+// @Success      200  {object}  clients.SuccessRes  "Members retrieved successfully"
+// @Failure      400  {object}  clients.AppError    "Bad Request"
+// @Router       /workspaces/{id}/members [get]
+func (h *workspaceHandler) GetMembersHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	members, err := h.workspaceService.GetMembers(id, requester.GetUserID())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(members))
+}
+
+// AddMemberHandler adds a member to a workspace.
+//
+// @Summary      Add a member
+// @Description  This endpoint adds the user registered with the email to a workspace as viewer, member (default), admin or owner. It takes the admin role, and the owner role to add an owner.
+// @Tags         Workspaces
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                 true  "Workspace ID"
+// @Param        member  body      domain.MemberCreation  true  "Member payload"
+// @Success      200     {object}  clients.SuccessRes     "Member added"
+// @Failure      400     {object}  clients.AppError       "Bad Request"
+// @Router       /workspaces/{id}/members [post]
+func (h *workspaceHandler) AddMemberHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	var data domain.MemberCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	member, err := h.workspaceService.AddMember(id, requester.GetUserID(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(member))
+}
+
+// UpdateMemberHandler changes the role of a member.
+//
+// @Summary      Change the role of a member
+// @Description  This endpoint changes the role of a member of a workspace. It takes the admin role, and the owner role when an owner is involved. The last owner can not step down.
+// @Tags         Workspaces
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string               true  "Workspace ID"
+// @Param        user_id  path      string               true  "User ID of the member"
+// @Param        member   body      domain.MemberUpdate  true  "Role payload"
+// @Success      200      {object}  clients.SuccessRes   "Role changed"
+// @Failure      400      {object}  clients.AppError     "Bad Request"
+// @Router       /workspaces/{id}/members/{user_id} [patch]
+func (h *workspaceHandler) UpdateMemberHandler(c *gin.Context) {
+	id, memberID, ok := memberParams(c)
+	if !ok {
+		return
+	}
+
+	var data domain.MemberUpdate
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.workspaceService.UpdateMember(id, requester.GetUserID(), memberID, &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// RemoveMemberHandler removes a member from a workspace.
+//
+// @Summary      Remove a member
+// @Description  This endpoint removes a member from a workspace, or lets the current user leave it. Removing others takes the admin role, and the owner role to remove an owner. The last owner can not leave.
+// @Tags         Workspaces
+// @Produce      json
+// @Param        id       path      string              true  "Workspace ID"
+// @Param        user_id  path      string              true  "User ID of the member"
+// @Success      200      {object}  clients.SuccessRes  "Member removed"
+// @Failure      400      {object}  clients.AppError    "Bad Request"
+// @Router       /workspaces/{id}/members/{user_id} [delete]
+func (h *workspaceHandler) RemoveMemberHandler(c *gin.Context) {
+	id, memberID, ok := memberParams(c)
+	if !ok {
+		return
+	}
+
+	requester := c.MustGet(clients.CurrentUser).(clients.Requester)
+
+	if err := h.workspaceService.RemoveMember(id, requester.GetUserID(), memberID); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, clients.SimpleSuccessResponse(true))
+}
+
+// memberParams parses the workspace and member IDs of the path, answering
+// the request itself when one is malformed.
+func memberParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, clients.ErrInvalidRequest(err))
+
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, memberID, true
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"errors"
+	"strings"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type adminRepo struct {
+	db *gorm.DB
+}
+
+func NewAdminRepo(db *gorm.DB) *adminRepo {
+	return &adminRepo{
+		db: db,
+	}
+}
+
+// ListUsers lists the matching users, newest first. search is matched as a
+// case-insensitive substring of the email and names.
+func (r *adminRepo) ListUsers(filter map[string]any, search string, paging *clients.Paging) ([]domain.User, error) {
+	users := []domain.User{}
+	query := r.db.Table(domain.User{}.TableName()).Where(filter)
+
+	if search != "" {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(search)) + "%"
+		query = query.Where(`(LOWER(email) LIKE ? ESCAPE '\' OR LOWER(first_name) LIKE ? ESCAPE '\' OR LOWER(last_name) LIKE ? ESCAPE '\')`,
+			pattern, pattern, pattern)
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Select("id").Count(&paging.Total).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	query = query.Order("created_at desc").Order("id").Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+
+	if err := query.Find(&users).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return users, nil
+}
+
+func (r *adminRepo) GetUser(conditions map[string]any) (*domain.User, error) {
+	var user domain.User
+
+	if err := r.db.Where(conditions).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &user, nil
+}
+
+func (r *adminRepo) UpdateUser(id uuid.UUID, fields map[string]any) error {
+	if err := r.db.Table(domain.User{}.TableName()).Where("id = ?", id).Updates(fields).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *adminRepo) SaveAudit(entry *domain.AuditEntry) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// ListAudit lists the matching audit entries, newest first.
+func (r *adminRepo) ListAudit(filter map[string]any, paging *clients.Paging) ([]domain.AuditEntry, error) {
+	entries := []domain.AuditEntry{}
+	query := r.db.Table(domain.AuditEntry{}.TableName()).Where(filter).Session(&gorm.Session{})
+
+	if err := query.Select("id").Count(&paging.Total).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	query = query.Order("created_at desc").Order("id").Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return entries, nil
+}
+
+// Transaction runs fn against a repository bound to a database transaction,
+// committing when fn returns nil.
+func (r *adminRepo) Transaction(fn func(repo *adminRepo) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&adminRepo{db: tx})
+	})
+}
@@ -0,0 +1,65 @@
+package postgres_test
+
+import (
+	"testing"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminListUsers(t *testing.T) {
+	db := setupUserTestDB(t)
+	userRepo := postgres.NewUserRepo(db)
+	repo := postgres.NewAdminRepo(db)
+
+	for _, user := range []*domain.UserCreate{
+		{ID: uuid.New(), Email: "ann@example.com", FirstName: "Ann", Status: clients.Active, Role: domain.RoleUser},
+		{ID: uuid.New(), Email: "bob@example.com", LastName: "Annan", Status: clients.Deleted, Role: domain.RoleUser},
+		{ID: uuid.New(), Email: "carl@example.com", FirstName: "Carl", Status: clients.Active, Role: domain.RoleAdmin},
+	} {
+		require.NoError(t, userRepo.Save(user))
+	}
+
+	paging := &clients.Paging{Page: 1, Limit: 10}
+	users, err := repo.ListUsers(map[string]any{}, "ANN", paging)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, int64(2), paging.Total)
+
+	paging = &clients.Paging{Page: 1, Limit: 10}
+	users, err = repo.ListUsers(map[string]any{"status": clients.Active}, "ann", paging)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "ann@example.com", users[0].Email)
+
+	paging = &clients.Paging{Page: 1, Limit: 10}
+	users, err = repo.ListUsers(map[string]any{"role": domain.RoleAdmin}, "", paging)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "carl@example.com", users[0].Email)
+}
+
+func TestAdminAudit(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := postgres.NewAdminRepo(db)
+
+	adminID, userID := uuid.New(), uuid.New()
+	require.NoError(t, repo.SaveAudit(&domain.AuditEntry{ID: uuid.New(), ActorID: adminID, Action: domain.AuditBanUser, TargetID: &userID, Details: "{}"}))
+	require.NoError(t, repo.SaveAudit(&domain.AuditEntry{ID: uuid.New(), ActorID: adminID, Action: domain.AuditListUsers, Details: "{}"}))
+
+	paging := &clients.Paging{Page: 1, Limit: 10}
+	entries, err := repo.ListAudit(map[string]any{"target_id": userID}, paging)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, domain.AuditBanUser, entries[0].Action)
+
+	paging = &clients.Paging{Page: 1, Limit: 10}
+	entries, err = repo.ListAudit(map[string]any{"actor_id": adminID}, paging)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, int64(2), paging.Total)
+}
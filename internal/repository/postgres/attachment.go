@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type attachmentRepo struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepo(db *gorm.DB) *attachmentRepo {
+	return &attachmentRepo{
+		db: db,
+	}
+}
+
+func (r *attachmentRepo) Save(attachment *domain.Attachment) error {
+	if err := r.db.Create(attachment).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *attachmentRepo) GetAttachment(conditions map[string]any) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+
+	if err := r.db.Where(conditions).First(&attachment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &attachment, nil
+}
+
+// ListAttachments lists the matching attachments, oldest first.
+func (r *attachmentRepo) ListAttachments(conditions map[string]any) ([]domain.Attachment, error) {
+	attachments := []domain.Attachment{}
+
+	if err := r.db.Where(conditions).Order("created_at").Order("id").Find(&attachments).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepo) Delete(id uuid.UUID) error {
+	if err := r.db.Where("id = ?", id).Delete(&domain.Attachment{}).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// ListOrphans lists up to limit attachments whose item no longer exists.
+// Purging items leaves their attachments behind, as their blobs have to be
+// deleted outside of the database.
+func (r *attachmentRepo) ListOrphans(limit int) ([]domain.Attachment, error) {
+	attachments := []domain.Attachment{}
+
+	err := r.db.Table(domain.Attachment{}.TableName() + " AS a").Select("a.*").
+		Joins("LEFT JOIN items ON items.id = a.item_id").
+		Where("items.id IS NULL").
+		Order("a.created_at").Limit(limit).
+		Find(&attachments).Error
+	if err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,59 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachments(t *testing.T) {
+	db, itemRepo, err := setupTestDB()
+	require.NoError(t, err)
+
+	repo := postgres.NewAttachmentRepo(db)
+	userID := uuid.New()
+
+	kept := insertMockItem(db, "Kept", "", userID)
+	purged := insertMockItem(db, "Purged", "", userID)
+
+	earlier := time.Now().Add(-time.Minute)
+	first := &domain.Attachment{ID: uuid.New(), ItemID: kept.ID, UserID: userID, FileName: "a.pdf", BlobKey: "a", CreatedAt: &earlier}
+	second := &domain.Attachment{ID: uuid.New(), ItemID: kept.ID, UserID: userID, FileName: "b.png", BlobKey: "b"}
+	orphan := &domain.Attachment{ID: uuid.New(), ItemID: purged.ID, UserID: userID, FileName: "c.txt", BlobKey: "c"}
+	for _, attachment := range []*domain.Attachment{second, first, orphan} {
+		require.NoError(t, repo.Save(attachment))
+	}
+
+	attachments, err := repo.ListAttachments(map[string]any{"item_id": kept.ID})
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "a.pdf", attachments[0].FileName) // oldest first
+	assert.Equal(t, "b.png", attachments[1].FileName)
+
+	_, err = repo.GetAttachment(map[string]any{"id": orphan.ID, "item_id": kept.ID})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	orphans, err := repo.ListOrphans(10)
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+
+	// Purging the item leaves its attachments for the blob cleanup
+	require.NoError(t, itemRepo.Delete(map[string]any{"id": purged.ID}))
+
+	orphans, err = repo.ListOrphans(10)
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, orphan.ID, orphans[0].ID)
+	assert.Equal(t, "c", orphans[0].BlobKey)
+
+	require.NoError(t, repo.Delete(orphan.ID))
+	orphans, err = repo.ListOrphans(10)
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type commentRepo struct {
+	db *gorm.DB
+}
+
+func NewCommentRepo(db *gorm.DB) *commentRepo {
+	return &commentRepo{
+		db: db,
+	}
+}
+
+// Save creates the comment together with its mentions.
+func (r *commentRepo) Save(comment *domain.Comment) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+
+		return insertMentions(tx, comment.ID, comment.Mentions)
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *commentRepo) GetComment(conditions map[string]any) (*domain.Comment, error) {
+	var comment domain.Comment
+
+	if err := r.db.Where(conditions).First(&comment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &comment, nil
+}
+
+// ListComments lists the matching comments with their mentions, oldest
+// first.
+func (r *commentRepo) ListComments(conditions map[string]any, paging *clients.Paging) ([]domain.Comment, error) {
+	comments := []domain.Comment{}
+	query := r.db.Table(domain.Comment{}.TableName()).Where(conditions).Session(&gorm.Session{})
+
+	if err := query.Select("id").Count(&paging.Total).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	query = query.Order("created_at").Order("id").Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+
+	if err := query.Find(&comments).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	if err := r.loadMentions(comments); err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return comments, nil
+}
+
+// Update replaces the body and the mentions of the comment.
+func (r *commentRepo) Update(id uuid.UUID, body string, mentions []domain.Mention, updatedAt time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.Comment{}).Where("id = ?", id).
+			Updates(map[string]any{"body": body, "updated_at": updatedAt}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("comment_id = ?", id).Delete(&domain.Mention{}).Error; err != nil {
+			return err
+		}
+
+		return insertMentions(tx, id, mentions)
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *commentRepo) Delete(id uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", id).Delete(&domain.Mention{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&domain.Comment{}).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// loadMentions fills the mentions of the given comments with a single query.
+func (r *commentRepo) loadMentions(comments []domain.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+
+	var rows []domain.Mention
+	if err := r.db.Where("comment_id IN ?", ids).Order("email").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	mentions := make(map[uuid.UUID][]domain.Mention)
+	for _, row := range rows {
+		mentions[row.CommentID] = append(mentions[row.CommentID], row)
+	}
+
+	for i := range comments {
+		comments[i].Mentions = mentions[comments[i].ID]
+		if comments[i].Mentions == nil {
+			comments[i].Mentions = []domain.Mention{}
+		}
+	}
+
+	return nil
+}
+
+// insertMentions saves the mentions of the comment.
+func insertMentions(tx *gorm.DB, commentID uuid.UUID, mentions []domain.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	for i := range mentions {
+		mentions[i].CommentID = commentID
+	}
+
+	return tx.Create(&mentions).Error
+}
@@ -0,0 +1,91 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComments(t *testing.T) {
+	db, itemRepo, err := setupTestDB()
+	require.NoError(t, err)
+
+	repo := postgres.NewCommentRepo(db)
+	userID, mentionedID := uuid.New(), uuid.New()
+
+	discussed := insertMockItem(db, "Discussed", "", userID)
+	quiet := insertMockItem(db, "Quiet", "", userID)
+
+	earlier := time.Now().Add(-time.Minute)
+	first := &domain.Comment{ID: uuid.New(), ItemID: discussed.ID, UserID: userID, Body: "First", CreatedAt: &earlier,
+		Mentions: []domain.Mention{{UserID: mentionedID, Email: "ann@example.com"}}}
+	second := &domain.Comment{ID: uuid.New(), ItemID: discussed.ID, UserID: userID, Body: "Second"}
+	require.NoError(t, repo.Save(first))
+	require.NoError(t, repo.Save(second))
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	comments, err := repo.ListComments(map[string]any{"item_id": discussed.ID}, paging)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "First", comments[0].Body) // oldest first
+	assert.Equal(t, []domain.Mention{{CommentID: first.ID, UserID: mentionedID, Email: "ann@example.com"}}, comments[0].Mentions)
+	assert.Empty(t, comments[1].Mentions)
+	assert.EqualValues(t, 2, paging.Total)
+
+	// The listing carries the comment counts
+	items, err := itemRepo.GetAll(map[string]any{"user_id": userID}, &clients.Paging{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	counts := map[uuid.UUID]int64{}
+	for _, item := range items {
+		require.NotNil(t, item.CommentCount)
+		counts[item.ID] = *item.CommentCount
+	}
+	assert.Equal(t, map[uuid.UUID]int64{discussed.ID: 2, quiet.ID: 0}, counts)
+
+	require.NoError(t, repo.Update(first.ID, "Edited", nil, time.Now()))
+	edited, err := repo.GetComment(map[string]any{"id": first.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "Edited", edited.Body)
+
+	var mentions int64
+	db.Model(&domain.Mention{}).Count(&mentions)
+	assert.Zero(t, mentions)
+
+	require.NoError(t, repo.Delete(second.ID))
+	_, err = repo.GetComment(map[string]any{"id": second.ID})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	// Comments and their mentions go with their item
+	require.NoError(t, repo.Save(&domain.Comment{ID: uuid.New(), ItemID: discussed.ID, UserID: userID, Body: "Third",
+		Mentions: []domain.Mention{{UserID: mentionedID, Email: "ann@example.com"}}}))
+	require.NoError(t, itemRepo.Delete(map[string]any{"id": discussed.ID}))
+
+	var remaining int64
+	db.Model(&domain.Comment{}).Count(&remaining)
+	assert.Zero(t, remaining)
+	db.Model(&domain.Mention{}).Count(&mentions)
+	assert.Zero(t, mentions)
+}
+
+// TestMentionedUsers checks that the lowercased emails of mentions find users
+// who registered with capitals.
+func TestMentionedUsers(t *testing.T) {
+	db := setupUserTestDB(t)
+	users := postgres.NewUserRepo(db)
+
+	ann := &domain.UserCreate{ID: uuid.New(), Email: "Ann.Lee@Example.com", Status: clients.Active}
+	require.NoError(t, users.Save(ann))
+
+	emails := domain.ParseMentions("@ann.lee@example.com and @ANN.LEE@EXAMPLE.COM, please have a look")
+	require.Equal(t, []string{"ann.lee@example.com"}, emails)
+
+	user, err := users.GetUser(map[string]any{"email": emails[0]})
+	require.NoError(t, err)
+	assert.Equal(t, ann.ID, user.ID)
+}
@@ -2,24 +2,51 @@ package postgres
 
 import (
 	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
 	"todo-app/domain"
 	"todo-app/pkg/clients"
+	"todo-app/pkg/cursor"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// likeEscaper escapes the LIKE wildcards of user supplied search terms.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type itemRepo struct {
-	db *gorm.DB
+	db      *gorm.DB
+	cursors *cursor.Signer
 }
 
-func NewItemRepo(db *gorm.DB) *itemRepo {
+func NewItemRepo(db *gorm.DB, cursors *cursor.Signer) *itemRepo {
 	return &itemRepo{
-		db: db,
+		db:      db,
+		cursors: cursors,
 	}
 }
 
+// itemCursor is the keyset position carried by the paging cursors of the
+// item listing. Desc binds a cursor to the direction it was issued for.
+type itemCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"i"`
+	Desc      bool      `json:"d"`
+}
+
 func (r *itemRepo) Save(item *domain.ItemCreation) error {
-	if err := r.db.Create(&item).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&item).Error; err != nil {
+			return err
+		}
+
+		return insertItemTags(tx, []uuid.UUID{item.ID}, item.TagIDs)
+	})
+	if err != nil {
 		return clients.ErrDB(err)
 	}
 
@@ -28,27 +55,202 @@ func (r *itemRepo) Save(item *domain.ItemCreation) error {
 
 func (r *itemRepo) GetAll(filter map[string]any, paging *clients.Paging) ([]domain.Item, error) {
 	items := []domain.Item{}
-	var query *gorm.DB
+	query := applyItemFilter(r.db.Table(domain.Item{}.TableName()), filter).Session(&gorm.Session{})
+
+	if err := query.Select("id").Count(&paging.Total).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	sort, ok := filter["sort"].(domain.ItemSort)
+	if !ok {
+		sort = domain.ItemSort{Field: "created_at"}
+	}
+
+	// Keyset pagination is only offered for the creation order; other orders
+	// fall back to page/limit.
+	keyset := sort.Field == "created_at"
 
-	if f := filter; f != nil {
-		if v := f["user_id"]; v != "" {
-			query = r.db.Where("user_id = ?", v)
+	if paging.FakeCursor != "" {
+		var after itemCursor
+		if !keyset || r.cursors.Decode(paging.FakeCursor, &after) != nil || after.Desc != sort.Desc {
+			return nil, cursor.ErrInvalidCursor
 		}
+
+		op := ">"
+		if sort.Desc {
+			op = "<"
+		}
+		query = query.Where(fmt.Sprintf("(created_at %[1]s ? OR (created_at = ? AND id %[1]s ?))", op),
+			after.CreatedAt, after.CreatedAt, after.ID)
+	} else {
+		query = query.Offset((paging.Page - 1) * paging.Limit)
 	}
 
-	if err := query.Table(domain.Item{}.TableName()).Select("id").Count(&paging.Total).Error; err != nil {
+	// One extra row tells whether there is a next page.
+	query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: sort.Field}, Desc: sort.Desc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: sort.Desc}).
+		Limit(paging.Limit + 1)
+
+	if err := query.Find(&items).Error; err != nil {
 		return nil, clients.ErrDB(err)
 	}
 
-	query = r.db.Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+	paging.NextCursor = ""
+	if len(items) > paging.Limit {
+		items = items[:paging.Limit]
 
-	if err := query.Find(&items).Error; err != nil {
+		if last := items[len(items)-1]; keyset && last.CreatedAt != nil {
+			next, err := r.cursors.Encode(itemCursor{CreatedAt: *last.CreatedAt, ID: last.ID, Desc: sort.Desc})
+			if err != nil {
+				return nil, clients.ErrInternal(err)
+			}
+			paging.NextCursor = next
+		}
+	}
+
+	if err := r.loadTags(items); err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	if err := r.loadCommentCounts(items); err != nil {
 		return nil, clients.ErrDB(err)
 	}
 
 	return items, nil
 }
 
+// applyItemFilter translates the filter keys understood by the item listing
+// into SQL conditions. Unknown keys are matched by column equality, or IN for
+// slices; "sort" is applied by the caller.
+func applyItemFilter(query *gorm.DB, filter map[string]any) *gorm.DB {
+	for key, value := range filter {
+		switch key {
+		case "sort":
+		case "search":
+			pattern := "%" + likeEscaper.Replace(strings.ToLower(fmt.Sprint(value))) + "%"
+			query = query.Where(`(LOWER(title) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`, pattern, pattern)
+		case "created_from":
+			query = query.Where("created_at >= ?", value)
+		case "created_to":
+			query = query.Where("created_at <= ?", value)
+		case "updated_from":
+			query = query.Where("updated_at >= ?", value)
+		case "updated_to":
+			query = query.Where("updated_at <= ?", value)
+		case "due_from":
+			query = query.Where("due_at >= ?", value)
+		case "due_before":
+			query = query.Where("due_at < ?", value)
+		case "tags_any":
+			query = query.Where("id IN (SELECT item_tags.item_id FROM item_tags JOIN tags ON tags.id = item_tags.tag_id "+
+				"WHERE tags.name IN ?)", value)
+		case "tags_all":
+			names, _ := value.([]string)
+			query = query.Where("id IN (SELECT item_tags.item_id FROM item_tags JOIN tags ON tags.id = item_tags.tag_id "+
+				"WHERE tags.name IN ? GROUP BY item_tags.item_id HAVING COUNT(DISTINCT tags.name) = ?)", names, len(names))
+		case "shared_with":
+			query = query.Where("(id IN (SELECT item_id FROM shares WHERE user_id = ? AND accepted_at IS NOT NULL) OR "+
+				"list_id IN (SELECT list_id FROM shares WHERE user_id = ? AND accepted_at IS NOT NULL))", value, value)
+		default:
+			query = query.Where(clause.Eq{Column: clause.Column{Name: key}, Value: value})
+		}
+	}
+
+	return query
+}
+
+// Search returns the items matching the full-text query, most relevant first.
+// Databases without Postgres full-text search, such as the SQLite used by the
+// tests, fall back to requiring every term as a substring.
+func (r *itemRepo) Search(filter map[string]any, text string, paging *clients.Paging) ([]domain.ItemSearchResult, error) {
+	results := []domain.ItemSearchResult{}
+	query := applyItemFilter(r.db.Table(domain.Item{}.TableName()), filter)
+
+	fullText := r.db.Dialector.Name() == "postgres"
+	terms := strings.Fields(strings.ToLower(text))
+
+	var rank clause.Expr
+	if fullText {
+		query = query.Joins("CROSS JOIN websearch_to_tsquery('english', ?) AS q", text).
+			Where("items.search_vector @@ q")
+		// Markup in the item is escaped so the <mark> tags are the only HTML
+		// in the snippet.
+		rank = clause.Expr{SQL: `ts_rank(items.search_vector, q) AS rank,
+			ts_headline('english',
+				replace(replace(replace(items.title || ' ' || items.description, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+				q, 'StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10') AS snippet`}
+	} else {
+		var weights []string
+		var vars []any
+		for _, term := range terms {
+			pattern := "%" + likeEscaper.Replace(term) + "%"
+			query = query.Where(`(LOWER(title) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`, pattern, pattern)
+			weights = append(weights, `(CASE WHEN LOWER(title) LIKE ? ESCAPE '\' THEN 2 ELSE 1 END)`)
+			vars = append(vars, pattern)
+		}
+		rank = clause.Expr{SQL: "(" + strings.Join(weights, " + ") + ") AS rank", Vars: vars}
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Select("items.id").Count(&paging.Total).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	err := query.Select("items.*, ?", rank).
+		Order("rank DESC").Order("items.id").
+		Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit).
+		Find(&results).Error
+	if err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	items := make([]domain.Item, len(results))
+	for i := range results {
+		if !fullText {
+			results[i].Snippet = highlight(results[i].Title+" "+results[i].Description, terms)
+		}
+		items[i] = results[i].Item
+	}
+
+	if err := r.loadTags(items); err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	for i := range results {
+		results[i].Tags = items[i].Tags
+	}
+
+	return results, nil
+}
+
+// highlight escapes text and wraps the case-insensitive occurrences of terms
+// in <mark>, like the snippets of the full-text search.
+func highlight(text string, terms []string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(text); {
+		match := 0
+		for _, term := range terms {
+			if end := i + len(term); end <= len(text) && len(term) > match && strings.EqualFold(text[i:end], term) {
+				match = len(term)
+			}
+		}
+
+		if match == 0 {
+			b.WriteString(html.EscapeString(text[i : i+1]))
+			i++
+
+			continue
+		}
+
+		b.WriteString("<mark>" + html.EscapeString(text[i:i+match]) + "</mark>")
+		i += match
+	}
+
+	return b.String()
+}
+
 func (r *itemRepo) GetItem(filter map[string]any) (domain.Item, error) {
 	var item domain.Item
 
@@ -60,11 +262,162 @@ func (r *itemRepo) GetItem(filter map[string]any) (domain.Item, error) {
 		return domain.Item{}, clients.ErrDB(err)
 	}
 
-	return item, nil
+	items := []domain.Item{item}
+	if err := r.loadTags(items); err != nil {
+		return domain.Item{}, clients.ErrDB(err)
+	}
+
+	return items[0], nil
+}
+
+// loadTags fills the tags of the given items with a single query.
+func (r *itemRepo) loadTags(items []domain.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	var rows []struct {
+		domain.Tag
+		ItemID uuid.UUID
+	}
+
+	err := r.db.Table(domain.Tag{}.TableName()).
+		Select("tags.*, item_tags.item_id").
+		Joins("JOIN item_tags ON item_tags.tag_id = tags.id").
+		Where("item_tags.item_id IN ?", ids).
+		Order("tags.name").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[uuid.UUID][]domain.Tag)
+	for _, row := range rows {
+		tags[row.ItemID] = append(tags[row.ItemID], row.Tag)
+	}
+
+	for i := range items {
+		items[i].Tags = tags[items[i].ID]
+	}
+
+	return nil
+}
+
+// loadCommentCounts fills the comment counts of the given items with a
+// single query.
+func (r *itemRepo) loadCommentCounts(items []domain.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	var rows []struct {
+		ItemID uuid.UUID
+		Count  int64
+	}
+
+	err := r.db.Table(domain.Comment{}.TableName()).
+		Select("item_id, COUNT(*) AS count").
+		Where("item_id IN ?", ids).
+		Group("item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ItemID] = row.Count
+	}
+
+	for i := range items {
+		count := counts[items[i].ID]
+		items[i].CommentCount = &count
+	}
+
+	return nil
+}
+
+// insertItemTags links every item to every tag.
+func insertItemTags(tx *gorm.DB, itemIDs, tagIDs []uuid.UUID) error {
+	links := make([]domain.ItemTag, 0, len(itemIDs)*len(tagIDs))
+	for _, itemID := range itemIDs {
+		for _, tagID := range tagIDs {
+			links = append(links, domain.ItemTag{ItemID: itemID, TagID: tagID})
+		}
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	return tx.Create(&links).Error
+}
+
+// GetSubtree returns the item identified by rootID followed by all of its
+// descendants.
+func (r *itemRepo) GetSubtree(rootID uuid.UUID) ([]domain.Item, error) {
+	items := []domain.Item{}
+
+	query := `WITH RECURSIVE subtree AS (
+		SELECT * FROM items WHERE id = ?
+		UNION
+		SELECT items.* FROM items JOIN subtree ON items.parent_id = subtree.id
+	)
+	SELECT * FROM subtree`
+
+	if err := r.db.Raw(query, rootID).Scan(&items).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return items, nil
 }
 
 func (r *itemRepo) Update(filter map[string]any, item *domain.ItemUpdate) error {
-	if err := r.db.Where(filter).Updates(&item).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The nil list ID moves the item back to the inbox, which Updates
+		// would otherwise store as a zero UUID.
+		update := *item
+		toInbox := update.ListID != nil && *update.ListID == uuid.Nil
+		if toInbox {
+			update.ListID = nil
+		}
+
+		if err := tx.Where(filter).Updates(&update).Error; err != nil {
+			return err
+		}
+
+		if toInbox {
+			if err := tx.Table(domain.Item{}.TableName()).Where(filter).Update("list_id", nil).Error; err != nil {
+				return err
+			}
+		}
+
+		if item.TagIDs == nil {
+			return nil
+		}
+
+		var itemIDs []uuid.UUID
+		if err := tx.Table(domain.Item{}.TableName()).Where(filter).Pluck("id", &itemIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("item_id IN ?", itemIDs).Delete(&domain.ItemTag{}).Error; err != nil {
+			return err
+		}
+
+		return insertItemTags(tx, itemIDs, item.TagIDs)
+	})
+	if err != nil {
 		return clients.ErrDB(err)
 	}
 
@@ -72,9 +425,147 @@ func (r *itemRepo) Update(filter map[string]any, item *domain.ItemUpdate) error
 }
 
 func (r *itemRepo) Delete(filter map[string]any) error {
-	if err := r.db.Table(domain.Item{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		ids := tx.Table(domain.Item{}.TableName()).Select("id").Where(filter)
+		if err := deleteItemRows(tx, ids); err != nil {
+			return err
+		}
+
+		return tx.Table(domain.Item{}.TableName()).Where(filter).Delete(nil).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Trash moves the matching items that are not trashed yet to the trash,
+// remembering their status for a restore.
+func (r *itemRepo) Trash(filter map[string]any, deletedAt time.Time) error {
+	err := r.db.Table(domain.Item{}.TableName()).
+		Where(filter).
+		Where("status <> ?", domain.Deleted).
+		Updates(map[string]any{
+			"restore_status": gorm.Expr("status"),
+			"status":         domain.Deleted,
+			"deleted_at":     deletedAt,
+			"updated_at":     deletedAt,
+		}).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Restore brings the matching trashed items back with the status they had
+// before they were trashed.
+func (r *itemRepo) Restore(filter map[string]any) error {
+	err := r.db.Table(domain.Item{}.TableName()).
+		Where(filter).
+		Where("status = ?", domain.Deleted).
+		Updates(map[string]any{
+			"status":     gorm.Expr("restore_status"),
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// PurgeTrash permanently deletes the items trashed before the given time and
+// returns how many were removed.
+func (r *itemRepo) PurgeTrash(before time.Time) (int64, error) {
+	var purged int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Table(domain.Item{}.TableName()).
+			Where("status = ? AND deleted_at < ?", domain.Deleted, before).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		if err := deleteItemRows(tx, tx.Table(domain.Item{}.TableName()).Select("id").Where("id IN ?", ids)); err != nil {
+			return err
+		}
+
+		result := tx.Where("id IN ?", ids).Delete(&domain.Item{})
+		purged = result.RowsAffected
+
+		return result.Error
+	})
+	if err != nil {
+		return 0, clients.ErrDB(err)
+	}
+
+	return purged, nil
+}
+
+// deleteItemRows removes the rows belonging to the items selected by the
+// subquery ids, ahead of deleting the items themselves.
+func deleteItemRows(tx *gorm.DB, ids *gorm.DB) error {
+	comments := tx.Table(domain.Comment{}.TableName()).Select("id").Where("item_id IN (?)", ids)
+	if err := tx.Where("comment_id IN (?)", comments).Delete(&domain.Mention{}).Error; err != nil {
+		return err
+	}
+
+	for _, model := range []any{&domain.ItemTag{}, &domain.ItemEvent{}, &domain.Comment{}, &domain.Share{}} {
+		if err := tx.Where("item_id IN (?)", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Assign hands the item to the assignee, or takes it back when assigneeID is
+// nil.
+func (r *itemRepo) Assign(id uuid.UUID, assigneeID *uuid.UUID, updatedAt time.Time) error {
+	err := r.db.Table(domain.Item{}.TableName()).Where("id = ?", id).
+		Updates(map[string]any{"assignee_id": assigneeID, "updated_at": updatedAt}).Error
+	if err != nil {
 		return clients.ErrDB(err)
 	}
 
 	return nil
 }
+
+func (r *itemRepo) SaveEvent(event *domain.ItemEvent) error {
+	if err := r.db.Create(event).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// GetEvents lists the matching item events, newest first.
+func (r *itemRepo) GetEvents(filter map[string]any, paging *clients.Paging) ([]domain.ItemEvent, error) {
+	events := []domain.ItemEvent{}
+	query := r.db.Table(domain.ItemEvent{}.TableName()).Where(filter).Session(&gorm.Session{})
+
+	if err := query.Select("id").Count(&paging.Total).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	query = query.Order("created_at desc").Order("id").Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return events, nil
+}
+
+// Transaction runs fn with a repository bound to a database transaction that
+// is committed when fn returns nil. Nested calls use savepoints.
+func (r *itemRepo) Transaction(fn func(repo *itemRepo) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&itemRepo{db: tx, cursors: r.cursors})
+	})
+}
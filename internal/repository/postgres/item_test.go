@@ -2,12 +2,14 @@ package postgres_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 	"todo-app/domain"
 	"todo-app/internal/repository/postgres"
 	"todo-app/item"
 	"todo-app/pkg/clients"
+	"todo-app/pkg/cursor"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -17,15 +19,17 @@ import (
 )
 
 // Setup function for creating the in-memory SQLite database.
+// Every call gets its own database so tests do not see each other's rows.
 func setupTestDB() (*gorm.DB, item.ItemRepo, error) {
-	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
 	if err != nil {
 		return nil, nil, err
 	}
-	if err := db.AutoMigrate(&domain.Item{}); err != nil {
+	if err := postgres.Migrate(db); err != nil {
 		return nil, nil, err
 	}
-	return db, postgres.NewItemRepo(db), nil
+	return db, item.NewTransactionalRepo(postgres.NewItemRepo(db, cursor.NewSigner("secret"))), nil
 }
 
 // Helper function to insert a mock item into the database.
@@ -81,6 +85,199 @@ func TestGetAllItems(t *testing.T) {
 	assert.Equal(t, "Item 2", result[1].Title)
 }
 
+func TestGetAllItems_DueFilter(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	overdue := insertMockItem(db, "Overdue", "Due yesterday", userID)
+	db.Model(&overdue).Update("due_at", yesterday)
+	upcoming := insertMockItem(db, "Upcoming", "Due tomorrow", userID)
+	db.Model(&upcoming).Update("due_at", tomorrow)
+	insertMockItem(db, "No due date", "Never due", userID)
+	insertMockItem(db, "Other user", "Not mine", uuid.New())
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	result, err := repo.GetAll(map[string]any{"user_id": userID, "due_before": now}, paging)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Overdue", result[0].Title)
+	assert.EqualValues(t, 1, paging.Total)
+
+	paging = &clients.Paging{Limit: 10, Page: 1}
+	result, err = repo.GetAll(map[string]any{"user_id": userID, "due_from": now, "due_before": now.Add(48 * time.Hour)}, paging)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Upcoming", result[0].Title)
+}
+
+func TestGetAllItems_FilterAndSort(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	january := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	report := insertMockItem(db, "Weekly Report", "Send to the team", userID)
+	db.Model(&report).Updates(map[string]any{"created_at": january, "status": domain.Done})
+	invoice := insertMockItem(db, "Invoice", "Monthly REPORT of costs", userID)
+	db.Model(&invoice).Update("created_at", march)
+	discount := insertMockItem(db, "Discount", "100% off_season", userID)
+	db.Model(&discount).Update("created_at", march)
+	insertMockItem(db, "Report", "Not mine", uuid.New())
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	result, err := repo.GetAll(map[string]any{
+		"user_id": userID,
+		"search":  "report",
+		"sort":    domain.ItemSort{Field: "title"},
+	}, paging)
+	assert.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "Invoice", result[0].Title)
+	assert.Equal(t, "Weekly Report", result[1].Title)
+
+	// Wildcards in the search term are matched literally
+	paging = &clients.Paging{Limit: 10, Page: 1}
+	result, err = repo.GetAll(map[string]any{"user_id": userID, "search": "0% off_"}, paging)
+	assert.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Discount", result[0].Title)
+
+	paging = &clients.Paging{Limit: 10, Page: 1}
+	result, err = repo.GetAll(map[string]any{"user_id": userID, "search": "o_f"}, paging)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	paging = &clients.Paging{Limit: 10, Page: 1}
+	result, err = repo.GetAll(map[string]any{
+		"user_id":      userID,
+		"created_from": january.AddDate(0, 1, 0),
+		"status":       []domain.Status{domain.Active},
+		"sort":         domain.ItemSort{Field: "created_at", Desc: true},
+	}, paging)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.EqualValues(t, 2, paging.Total)
+
+	paging = &clients.Paging{Limit: 10, Page: 1}
+	result, err = repo.GetAll(map[string]any{
+		"user_id":    userID,
+		"created_to": january,
+		"sort":       domain.ItemSort{Field: "created_at", Desc: true},
+	}, paging)
+	assert.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Weekly Report", result[0].Title)
+}
+
+func TestGetAllItems_Cursor(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		item := insertMockItem(db, fmt.Sprintf("Item %d", i), "", userID)
+		// Two items share a creation time so the id tie-break is exercised
+		db.Model(&item).Update("created_at", start.Add(time.Duration(i/2)*time.Hour))
+	}
+
+	newestFirst := map[string]any{"user_id": userID, "sort": domain.ItemSort{Field: "created_at", Desc: true}}
+
+	seen := map[uuid.UUID]bool{}
+	paging := &clients.Paging{Limit: 2, Page: 1}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5)
+
+		result, err := repo.GetAll(newestFirst, paging)
+		require.NoError(t, err)
+		for _, item := range result {
+			assert.False(t, seen[item.ID], "item %s returned twice", item.Title)
+			seen[item.ID] = true
+		}
+
+		if pages == 0 {
+			// Items created mid-scroll do not shift the following pages
+			insertMockItem(db, "Newest", "", userID)
+		}
+
+		if paging.NextCursor == "" {
+			break
+		}
+		paging = &clients.Paging{Limit: 2, Page: 1, FakeCursor: paging.NextCursor}
+	}
+	assert.Len(t, seen, 5)
+
+	// Page/limit mode still works and hands out a cursor to continue from
+	paging = &clients.Paging{Limit: 2, Page: 2}
+	result, err := repo.GetAll(map[string]any{"user_id": userID}, paging)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.ElementsMatch(t, []string{"Item 2", "Item 3"}, []string{result[0].Title, result[1].Title})
+	assert.NotEmpty(t, paging.NextCursor)
+
+	paging = &clients.Paging{Limit: 2, Page: 1, FakeCursor: paging.NextCursor}
+	result, err = repo.GetAll(map[string]any{"user_id": userID}, paging)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "Item 4", result[0].Title)
+	assert.Equal(t, "Newest", result[1].Title)
+	assert.Empty(t, paging.NextCursor)
+
+	// Cursors can not be forged or reused with another order
+	cursorPaging := &clients.Paging{Limit: 2, Page: 1}
+	_, err = repo.GetAll(map[string]any{"user_id": userID}, cursorPaging)
+	require.NoError(t, err)
+
+	for _, filter := range []map[string]any{newestFirst, {"user_id": userID, "sort": domain.ItemSort{Field: "title"}}} {
+		_, err = repo.GetAll(filter, &clients.Paging{Limit: 2, Page: 1, FakeCursor: cursorPaging.NextCursor})
+		assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+	}
+
+	_, err = repo.GetAll(map[string]any{"user_id": userID}, &clients.Paging{Limit: 2, Page: 1, FakeCursor: "forged"})
+	assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+}
+
+func TestSearchItems(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	insertMockItem(db, "Monthly invoice", "Send the <b>invoice</b> to accounting", userID)
+	insertMockItem(db, "Weekly report", "Mention the invoice backlog", userID)
+	insertMockItem(db, "Groceries", "Milk and eggs", userID)
+	insertMockItem(db, "Invoice", "Someone else's invoice", uuid.New())
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	results, err := repo.Search(map[string]any{"user_id": userID}, "Invoice", paging)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.EqualValues(t, 2, paging.Total)
+
+	// Title matches rank above description matches
+	assert.Equal(t, "Monthly invoice", results[0].Title)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+	assert.Equal(t, "Monthly <mark>invoice</mark> Send the &lt;b&gt;<mark>invoice</mark>&lt;/b&gt; to accounting", results[0].Snippet)
+
+	// Every term has to match
+	paging = &clients.Paging{Limit: 10, Page: 1}
+	results, err = repo.Search(map[string]any{"user_id": userID}, "invoice backlog", paging)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Weekly report", results[0].Title)
+
+	paging = &clients.Paging{Limit: 10, Page: 1}
+	results, err = repo.Search(map[string]any{"user_id": userID}, "bread", paging)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
 func TestGetItem_Success(t *testing.T) {
 	db, repo, err := setupTestDB()
 	require.NoError(t, err)
@@ -103,6 +300,29 @@ func TestGetItem_NotFound(t *testing.T) {
 	assert.True(t, errors.Is(err, clients.ErrRecordNotFound))
 }
 
+func TestGetSubtree(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	root := insertMockItem(db, "Root", "Root item", userID)
+	child := insertMockItem(db, "Child", "Child item", userID)
+	db.Model(&child).Update("parent_id", root.ID)
+	grandchild := insertMockItem(db, "Grandchild", "Grandchild item", userID)
+	db.Model(&grandchild).Update("parent_id", child.ID)
+	insertMockItem(db, "Unrelated", "Unrelated item", userID)
+
+	result, err := repo.GetSubtree(root.ID)
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+
+	ids := []uuid.UUID{}
+	for _, item := range result {
+		ids = append(ids, item.ID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{root.ID, child.ID, grandchild.ID}, ids)
+}
+
 func TestUpdateItem(t *testing.T) {
 	db, repo, err := setupTestDB()
 	require.NoError(t, err)
@@ -128,6 +348,28 @@ func TestUpdateItem(t *testing.T) {
 	assert.Equal(t, "New Description", updatedItem.Description)
 }
 
+func TestUpdateItem_MoveToInbox(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	item := insertMockItem(db, "Listed", "In a list", userID)
+	db.Model(&item).Update("list_id", uuid.New())
+
+	inbox := uuid.Nil
+	err = repo.Update(map[string]any{"id": item.ID}, &domain.ItemUpdate{ListID: &inbox, UpdatedAt: time.Now()})
+	assert.NoError(t, err)
+
+	var updatedItem domain.Item
+	require.NoError(t, db.First(&updatedItem, "id = ?", item.ID).Error)
+	assert.Nil(t, updatedItem.ListID)
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	result, err := repo.GetAll(map[string]any{"user_id": userID, "list_id": nil}, paging)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+}
+
 func TestDeleteItem(t *testing.T) {
 	db, repo, err := setupTestDB()
 	require.NoError(t, err)
@@ -143,3 +385,176 @@ func TestDeleteItem(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
 }
+
+func TestTrashAndRestoreItem(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	done := insertMockItem(db, "Done", "Finished", userID)
+	db.Model(&done).Update("status", domain.Done)
+	active := insertMockItem(db, "Active", "Still open", userID)
+
+	deletedAt := time.Now()
+	err = repo.Trash(map[string]any{"id": []uuid.UUID{done.ID, active.ID}}, deletedAt)
+	assert.NoError(t, err)
+
+	var trashed domain.Item
+	require.NoError(t, db.First(&trashed, "id = ?", done.ID).Error)
+	assert.Equal(t, domain.Deleted, trashed.Status)
+	assert.Equal(t, domain.Done, trashed.RestoreStatus)
+	require.NotNil(t, trashed.DeletedAt)
+
+	// Trashing again keeps the remembered status
+	err = repo.Trash(map[string]any{"id": done.ID}, time.Now())
+	assert.NoError(t, err)
+
+	err = repo.Restore(map[string]any{"id": done.ID})
+	assert.NoError(t, err)
+
+	var restored domain.Item
+	require.NoError(t, db.First(&restored, "id = ?", done.ID).Error)
+	assert.Equal(t, domain.Done, restored.Status)
+	assert.Nil(t, restored.DeletedAt)
+
+	var stillTrashed domain.Item
+	require.NoError(t, db.First(&stillTrashed, "id = ?", active.ID).Error)
+	assert.Equal(t, domain.Deleted, stillTrashed.Status)
+}
+
+func TestPurgeTrash(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	old := insertMockItem(db, "Old", "Trashed long ago", userID)
+	recent := insertMockItem(db, "Recent", "Trashed just now", userID)
+	live := insertMockItem(db, "Live", "Not trashed", userID)
+	tag := insertMockTag(db, "work", userID)
+	require.NoError(t, db.Create(&domain.ItemTag{ItemID: old.ID, TagID: tag.ID}).Error)
+	require.NoError(t, repo.SaveEvent(&domain.ItemEvent{ID: uuid.New(), ItemID: old.ID, ActorID: userID, Action: domain.ItemEventUnassigned}))
+	require.NoError(t, db.Create(&domain.Share{ID: uuid.New(), OwnerID: userID, ItemID: &old.ID, Email: "ann@example.com", Role: domain.ShareViewer}).Error)
+
+	require.NoError(t, repo.Trash(map[string]any{"id": old.ID}, time.Now().Add(-48*time.Hour)))
+	require.NoError(t, repo.Trash(map[string]any{"id": recent.ID}, time.Now()))
+
+	purged, err := repo.PurgeTrash(time.Now().Add(-24 * time.Hour))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, purged)
+
+	var ids []uuid.UUID
+	db.Model(&domain.Item{}).Order("title").Pluck("id", &ids)
+	assert.Equal(t, []uuid.UUID{live.ID, recent.ID}, ids)
+
+	var links, events, shares int64
+	db.Model(&domain.ItemTag{}).Count(&links)
+	assert.Zero(t, links)
+	db.Model(&domain.ItemEvent{}).Count(&events)
+	assert.Zero(t, events)
+	db.Model(&domain.Share{}).Count(&shares)
+	assert.Zero(t, shares)
+}
+
+// TestPurgeLegacyTrash checks that items deleted before the trash existed are
+// dated by the migration and purged like any other trashed item.
+func TestPurgeLegacyTrash(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	legacy := insertMockItem(db, "Legacy", "Deleted before the trash", uuid.New())
+	updatedAt := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, db.Model(&domain.Item{}).Where("id = ?", legacy.ID).
+		Updates(map[string]any{"status": domain.Deleted, "updated_at": updatedAt}).Error)
+
+	purged, err := repo.PurgeTrash(time.Now().Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	require.NoError(t, postgres.Migrate(db))
+
+	var backfilled domain.Item
+	require.NoError(t, db.First(&backfilled, "id = ?", legacy.ID).Error)
+	require.NotNil(t, backfilled.DeletedAt)
+	assert.WithinDuration(t, updatedAt, *backfilled.DeletedAt, time.Second)
+	assert.Equal(t, domain.Active, backfilled.RestoreStatus)
+
+	purged, err = repo.PurgeTrash(time.Now().Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, purged)
+}
+
+func TestAssignItem(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	ownerID, assigneeID := uuid.New(), uuid.New()
+	assigned := insertMockItem(db, "Assigned", "", ownerID)
+	insertMockItem(db, "Unassigned", "", ownerID)
+
+	require.NoError(t, repo.Assign(assigned.ID, &assigneeID, time.Now()))
+
+	// The assignee finds the item of the owner among the items assigned to them
+	items, err := repo.GetAll(map[string]any{"workspace_id": nil, "assignee_id": assigneeID}, &clients.Paging{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, assigned.ID, items[0].ID)
+	assert.Equal(t, assigneeID, *items[0].AssigneeID)
+
+	first := time.Now().Add(-time.Minute)
+	require.NoError(t, repo.SaveEvent(&domain.ItemEvent{ID: uuid.New(), ItemID: assigned.ID, ActorID: ownerID, Action: domain.ItemEventAssigned, CreatedAt: &first}))
+	require.NoError(t, repo.SaveEvent(&domain.ItemEvent{ID: uuid.New(), ItemID: assigned.ID, ActorID: ownerID, Action: domain.ItemEventUnassigned}))
+	require.NoError(t, repo.Assign(assigned.ID, nil, time.Now()))
+
+	result, err := repo.GetItem(map[string]any{"id": assigned.ID})
+	require.NoError(t, err)
+	assert.Nil(t, result.AssigneeID)
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	events, err := repo.GetEvents(map[string]any{"item_id": assigned.ID}, paging)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.ItemEventUnassigned, events[0].Action) // newest first
+	assert.EqualValues(t, 2, paging.Total)
+
+	require.NoError(t, repo.Delete(map[string]any{"id": assigned.ID}))
+
+	events, err = repo.GetEvents(map[string]any{"item_id": assigned.ID}, &clients.Paging{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestItemTransaction(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	save := func(repo item.ItemRepo, title string) error {
+		return repo.Save(&domain.ItemCreation{ID: uuid.New(), UserID: userID, Title: title, Status: domain.Active})
+	}
+	failure := errors.New("operation failed")
+
+	// A failing nested transaction only rolls back its own work
+	err = repo.Transaction(func(tx item.ItemRepo) error {
+		require.NoError(t, save(tx, "Kept"))
+
+		nestedErr := tx.Transaction(func(nested item.ItemRepo) error {
+			require.NoError(t, save(nested, "Rolled back with savepoint"))
+			return failure
+		})
+		assert.ErrorIs(t, nestedErr, failure)
+
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// A failing outer transaction rolls back everything
+	err = repo.Transaction(func(tx item.ItemRepo) error {
+		require.NoError(t, save(tx, "Rolled back with transaction"))
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	var titles []string
+	db.Model(&domain.Item{}).Pluck("title", &titles)
+	assert.Equal(t, []string{"Kept"}, titles)
+}
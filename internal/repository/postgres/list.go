@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type listRepo struct {
+	db *gorm.DB
+}
+
+func NewListRepo(db *gorm.DB) *listRepo {
+	return &listRepo{
+		db: db,
+	}
+}
+
+func (r *listRepo) Save(list *domain.ListCreation) error {
+	if err := r.db.Create(&list).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *listRepo) GetAll(filter map[string]any, paging *clients.Paging) ([]domain.List, error) {
+	lists := []domain.List{}
+	query := r.db.Table(domain.List{}.TableName()).Where(filter).Session(&gorm.Session{})
+
+	if err := query.Select("id").Count(&paging.Total).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	query = query.Order("created_at").Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+
+	if err := query.Find(&lists).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return lists, nil
+}
+
+func (r *listRepo) GetList(filter map[string]any) (domain.List, error) {
+	var list domain.List
+
+	if err := r.db.Where(filter).First(&list).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.List{}, clients.ErrRecordNotFound
+		}
+
+		return domain.List{}, clients.ErrDB(err)
+	}
+
+	return list, nil
+}
+
+func (r *listRepo) Update(filter map[string]any, list *domain.ListUpdate) error {
+	if err := r.db.Where(filter).Updates(&list).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Archive archives the list together with the items it holds.
+func (r *listRepo) Archive(id uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(domain.List{}.TableName()).Where("id = ?", id).
+			Update("status", domain.Archived).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(domain.Item{}.TableName()).Where("list_id = ? AND status <> ?", id, domain.Deleted).
+			Update("status", domain.Archived).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// DeleteToInbox moves the items of the list back to the inbox and deletes
+// the list together with its shares.
+func (r *listRepo) DeleteToInbox(id uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(domain.Item{}.TableName()).Where("list_id = ?", id).
+			Update("list_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("list_id = ?", id).Delete(&domain.Share{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(domain.List{}.TableName()).Where("id = ?", id).Delete(nil).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/list"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Setup function for creating an in-memory SQLite database holding lists and items.
+func setupListTestDB() (*gorm.DB, list.ListRepo, error) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := postgres.Migrate(db); err != nil {
+		return nil, nil, err
+	}
+	return db, postgres.NewListRepo(db), nil
+}
+
+// Helper function to insert a mock list with items into the database.
+func insertMockList(db *gorm.DB, name string, userID uuid.UUID, items int) domain.List {
+	list := domain.List{
+		ID:     uuid.New(),
+		UserID: userID,
+		Name:   name,
+		Status: domain.Active,
+	}
+
+	db.Create(&list)
+
+	for i := 0; i < items; i++ {
+		item := insertMockItem(db, fmt.Sprintf("%s item %d", name, i), "", userID)
+		db.Model(&item).Update("list_id", list.ID)
+	}
+
+	return list
+}
+
+func TestSaveAndGetList(t *testing.T) {
+	_, repo, err := setupListTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	creation := &domain.ListCreation{ID: uuid.New(), UserID: userID, Name: "Backlog", Status: domain.Active}
+
+	err = repo.Save(creation)
+	assert.NoError(t, err)
+
+	result, err := repo.GetList(map[string]any{"id": creation.ID, "user_id": userID})
+	assert.NoError(t, err)
+	assert.Equal(t, "Backlog", result.Name)
+
+	_, err = repo.GetList(map[string]any{"id": creation.ID, "user_id": uuid.New()})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+}
+
+func TestGetAllLists(t *testing.T) {
+	db, repo, err := setupListTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	insertMockList(db, "Backlog", userID, 0)
+	insertMockList(db, "Sprint 12", userID, 0)
+	insertMockList(db, "Someone else's", uuid.New(), 0)
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	result, err := repo.GetAll(map[string]any{"user_id": userID}, paging)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.EqualValues(t, 2, paging.Total)
+}
+
+func TestArchiveList(t *testing.T) {
+	db, repo, err := setupListTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	list := insertMockList(db, "Sprint 12", userID, 2)
+	other := insertMockItem(db, "Inbox item", "", userID)
+
+	err = repo.Archive(list.ID)
+	assert.NoError(t, err)
+
+	var archived domain.List
+	require.NoError(t, db.First(&archived, "id = ?", list.ID).Error)
+	assert.Equal(t, domain.Archived, archived.Status)
+
+	var items []domain.Item
+	require.NoError(t, db.Where("list_id = ?", list.ID).Find(&items).Error)
+	assert.Len(t, items, 2)
+	for _, item := range items {
+		assert.Equal(t, domain.Archived, item.Status)
+	}
+
+	var untouched domain.Item
+	require.NoError(t, db.First(&untouched, "id = ?", other.ID).Error)
+	assert.Equal(t, domain.Active, untouched.Status)
+}
+
+func TestDeleteListToInbox(t *testing.T) {
+	db, repo, err := setupListTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	list := insertMockList(db, "Sprint 12", userID, 2)
+	require.NoError(t, db.Create(&domain.Share{ID: uuid.New(), OwnerID: userID, ListID: &list.ID, Email: "ann@example.com", Role: domain.ShareViewer}).Error)
+
+	err = repo.DeleteToInbox(list.ID)
+	assert.NoError(t, err)
+
+	var count int64
+	db.Model(&domain.List{}).Where("id = ?", list.ID).Count(&count)
+	assert.EqualValues(t, 0, count)
+
+	db.Model(&domain.Item{}).Where("user_id = ? AND list_id IS NULL", userID).Count(&count)
+	assert.EqualValues(t, 2, count)
+
+	db.Model(&domain.Share{}).Where("list_id = ?", list.ID).Count(&count)
+	assert.EqualValues(t, 0, count)
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"todo-app/domain"
+
+	"gorm.io/gorm"
+)
+
+// Migrate creates or updates the tables backing the postgres repositories.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&domain.User{}, &domain.List{}, &domain.Tag{}, &domain.Item{}, &domain.ItemTag{}, &domain.RefreshToken{}, &domain.UserToken{}, &domain.AuditEntry{}, &domain.APIKey{}, &domain.RecoveryCode{}, &domain.UserIdentity{}, &domain.Session{}, &domain.Share{}, &domain.Workspace{}, &domain.Membership{}, &domain.ItemEvent{}, &domain.Comment{}, &domain.Mention{}, &domain.Attachment{}); err != nil {
+		return err
+	}
+
+	if err := backfillTrash(db); err != nil {
+		return err
+	}
+
+	// Users are looked up by email regardless of case.
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_users_lower_email ON users (LOWER(email))`).Error; err != nil {
+		return err
+	}
+
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	return migrateItemSearch(db)
+}
+
+// backfillTrash dates the items deleted before the trash existed, so that the
+// retention job purges them, and lets them be restored as active items.
+func backfillTrash(db *gorm.DB) error {
+	return db.Model(&domain.Item{}).
+		Where("status = ? AND deleted_at IS NULL", domain.Deleted).
+		Updates(map[string]any{
+			"deleted_at":     gorm.Expr("COALESCE(updated_at, CURRENT_TIMESTAMP)"),
+			"restore_status": domain.Active,
+		}).Error
+}
+
+// migrateItemSearch adds the full-text search vector of items, weighting the
+// title above the description, and its GIN index.
+func migrateItemSearch(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE items ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(description, '')), 'B')
+			) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_items_search_vector ON items USING GIN (search_vector)`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type shareRepo struct {
+	db *gorm.DB
+}
+
+func NewShareRepo(db *gorm.DB) *shareRepo {
+	return &shareRepo{
+		db: db,
+	}
+}
+
+func (r *shareRepo) Save(share *domain.Share) error {
+	if err := r.db.Create(share).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *shareRepo) GetShare(conditions map[string]any) (*domain.Share, error) {
+	var share domain.Share
+
+	if err := r.db.Where(conditions).First(&share).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &share, nil
+}
+
+// ListShares lists the matching shares, oldest first.
+func (r *shareRepo) ListShares(conditions map[string]any) ([]domain.Share, error) {
+	shares := []domain.Share{}
+
+	if err := r.db.Where(conditions).Order("created_at").Find(&shares).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return shares, nil
+}
+
+// Accept binds the pending share to the user. A share accepted already is
+// reported as not found.
+func (r *shareRepo) Accept(id, userID uuid.UUID, acceptedAt time.Time) error {
+	result := r.db.Model(&domain.Share{}).Where("id = ? AND accepted_at IS NULL", id).
+		Updates(map[string]any{"user_id": userID, "accepted_at": acceptedAt})
+	if result.Error != nil {
+		return clients.ErrDB(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return clients.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *shareRepo) Delete(id uuid.UUID) error {
+	if err := r.db.Where("id = ?", id).Delete(&domain.Share{}).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *shareRepo) GetGrants(ownerID, userID uuid.UUID, itemIDs, listIDs []uuid.UUID) ([]domain.Share, error) {
+	shares := []domain.Share{}
+
+	query := r.db.Where("owner_id = ? AND user_id = ? AND accepted_at IS NOT NULL", ownerID, userID)
+	if len(listIDs) > 0 {
+		query = query.Where("(item_id IN ? OR list_id IN ?)", itemIDs, listIDs)
+	} else {
+		query = query.Where("item_id IN ?", itemIDs)
+	}
+
+	if err := query.Find(&shares).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return shares, nil
+}
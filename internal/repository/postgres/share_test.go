@@ -0,0 +1,72 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShares(t *testing.T) {
+	db, itemRepo, err := setupTestDB()
+	require.NoError(t, err)
+
+	repo := postgres.NewShareRepo(db)
+	ownerID, userID := uuid.New(), uuid.New()
+	listID := uuid.New()
+
+	shared := insertMockItem(db, "Shared", "", ownerID)
+	listed := insertMockItem(db, "Listed", "", ownerID)
+	require.NoError(t, db.Model(&listed).Update("list_id", listID).Error)
+	insertMockItem(db, "Private", "", ownerID)
+
+	itemShare := &domain.Share{ID: uuid.New(), OwnerID: ownerID, ItemID: &shared.ID, Email: "ann@example.com", Role: domain.ShareEditor}
+	listShare := &domain.Share{ID: uuid.New(), OwnerID: ownerID, ListID: &listID, Email: "ann@example.com", Role: domain.ShareViewer}
+	require.NoError(t, repo.Save(itemShare))
+	require.NoError(t, repo.Save(listShare))
+
+	// Pending invitations grant nothing
+	grants, err := repo.GetGrants(ownerID, userID, []uuid.UUID{shared.ID}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, grants)
+
+	now := time.Now()
+	require.NoError(t, repo.Accept(itemShare.ID, userID, now))
+	require.NoError(t, repo.Accept(listShare.ID, userID, now))
+
+	// An invitation can be accepted once only
+	assert.ErrorIs(t, repo.Accept(itemShare.ID, uuid.New(), now), clients.ErrRecordNotFound)
+
+	grants, err = repo.GetGrants(ownerID, userID, []uuid.UUID{shared.ID}, nil)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, domain.AccessEdit, grants[0].Access())
+
+	grants, err = repo.GetGrants(ownerID, userID, []uuid.UUID{listed.ID}, []uuid.UUID{listID})
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, domain.AccessView, grants[0].Access())
+
+	items, err := itemRepo.GetAll(map[string]any{"shared_with": userID}, &clients.Paging{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	titles := []string{}
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	assert.ElementsMatch(t, []string{"Shared", "Listed"}, titles)
+
+	require.NoError(t, repo.Delete(listShare.ID))
+
+	_, err = repo.GetShare(map[string]any{"id": listShare.ID})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	items, err = itemRepo.GetAll(map[string]any{"shared_with": userID}, &clients.Paging{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Shared", items[0].Title)
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"gorm.io/gorm"
+)
+
+type tagRepo struct {
+	db *gorm.DB
+}
+
+func NewTagRepo(db *gorm.DB) *tagRepo {
+	return &tagRepo{
+		db: db,
+	}
+}
+
+func (r *tagRepo) Save(tag *domain.TagCreation) error {
+	if err := r.db.Create(&tag).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *tagRepo) GetTags(filter map[string]any) ([]domain.Tag, error) {
+	tags := []domain.Tag{}
+
+	if err := r.db.Where(filter).Order("name").Find(&tags).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return tags, nil
+}
+
+func (r *tagRepo) GetTag(filter map[string]any) (domain.Tag, error) {
+	var tag domain.Tag
+
+	if err := r.db.Where(filter).First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Tag{}, clients.ErrRecordNotFound
+		}
+
+		return domain.Tag{}, clients.ErrDB(err)
+	}
+
+	return tag, nil
+}
+
+func (r *tagRepo) Update(filter map[string]any, tag *domain.TagUpdate) error {
+	if err := r.db.Where(filter).Updates(&tag).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Delete removes the matching tags along with their item links.
+func (r *tagRepo) Delete(filter map[string]any) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		ids := tx.Table(domain.Tag{}.TableName()).Select("id").Where(filter)
+		if err := tx.Where("tag_id IN (?)", ids).Delete(&domain.ItemTag{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(domain.Tag{}.TableName()).Where(filter).Delete(nil).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Helper function to insert a mock tag into the database.
+func insertMockTag(db *gorm.DB, name string, userID uuid.UUID) domain.Tag {
+	tag := domain.Tag{
+		ID:     uuid.New(),
+		UserID: userID,
+		Name:   name,
+		Color:  domain.DefaultTagColor,
+	}
+
+	db.Create(&tag)
+
+	return tag
+}
+
+func TestSaveItemWithTags(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	urgent := insertMockTag(db, "urgent", userID)
+	home := insertMockTag(db, "home", userID)
+
+	item := &domain.ItemCreation{
+		ID:     uuid.New(),
+		UserID: userID,
+		Title:  "Tagged",
+		TagIDs: []uuid.UUID{urgent.ID, home.ID},
+	}
+	require.NoError(t, repo.Save(item))
+
+	result, err := repo.GetItem(map[string]any{"id": item.ID})
+	assert.NoError(t, err)
+	assert.Len(t, result.Tags, 2)
+	assert.Equal(t, "home", result.Tags[0].Name)
+	assert.Equal(t, "urgent", result.Tags[1].Name)
+
+	// A non-nil tag list replaces the tags of the item
+	err = repo.Update(map[string]any{"id": item.ID}, &domain.ItemUpdate{TagIDs: []uuid.UUID{home.ID}, UpdatedAt: time.Now()})
+	assert.NoError(t, err)
+
+	result, err = repo.GetItem(map[string]any{"id": item.ID})
+	assert.NoError(t, err)
+	assert.Len(t, result.Tags, 1)
+	assert.Equal(t, "home", result.Tags[0].Name)
+}
+
+func TestGetAllItems_TagFilter(t *testing.T) {
+	db, repo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	urgent := insertMockTag(db, "urgent", userID)
+	home := insertMockTag(db, "home", userID)
+
+	both := insertMockItem(db, "Both", "", userID)
+	onlyUrgent := insertMockItem(db, "Only urgent", "", userID)
+	insertMockItem(db, "Untagged", "", userID)
+	db.Create(&[]domain.ItemTag{
+		{ItemID: both.ID, TagID: urgent.ID},
+		{ItemID: both.ID, TagID: home.ID},
+		{ItemID: onlyUrgent.ID, TagID: urgent.ID},
+	})
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	result, err := repo.GetAll(map[string]any{"user_id": userID, "tags_any": []string{"urgent", "home"}}, paging)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	paging = &clients.Paging{Limit: 10, Page: 1}
+	result, err = repo.GetAll(map[string]any{"user_id": userID, "tags_all": []string{"urgent", "home"}}, paging)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Both", result[0].Title)
+	assert.Len(t, result[0].Tags, 2)
+}
+
+func TestDeleteTag(t *testing.T) {
+	db, itemRepo, err := setupTestDB()
+	require.NoError(t, err)
+	repo := postgres.NewTagRepo(db)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	urgent := insertMockTag(db, "urgent", userID)
+	item := insertMockItem(db, "Tagged", "", userID)
+	db.Create(&domain.ItemTag{ItemID: item.ID, TagID: urgent.ID})
+
+	err = repo.Delete(map[string]any{"id": urgent.ID, "user_id": userID})
+	assert.NoError(t, err)
+
+	_, err = repo.GetTag(map[string]any{"id": urgent.ID})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	result, err := itemRepo.GetItem(map[string]any{"id": item.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Tags)
+}
@@ -0,0 +1,295 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type tokenRepo struct {
+	db *gorm.DB
+}
+
+func NewTokenRepo(db *gorm.DB) *tokenRepo {
+	return &tokenRepo{
+		db: db,
+	}
+}
+
+func (r *tokenRepo) SaveRefreshToken(token *domain.RefreshToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *tokenRepo) GetRefreshToken(conditions map[string]any) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+
+	if err := r.db.Where(conditions).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken revokes the token with the given ID in favour of next.
+// The revocation only applies to a token that is not revoked yet, so of two
+// concurrent rotations of the same token one fails with ErrRefreshTokenReused.
+func (r *tokenRepo) RotateRefreshToken(id uuid.UUID, next *domain.RefreshToken) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.RefreshToken{}).Where("id = ? AND revoked_at IS NULL", id).
+			Updates(map[string]any{"revoked_at": time.Now(), "replaced_by": next.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domain.ErrRefreshTokenReused
+		}
+
+		return tx.Create(next).Error
+	})
+	if errors.Is(err, domain.ErrRefreshTokenReused) {
+		return domain.ErrRefreshTokenReused
+	}
+
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// RevokeRefreshTokens revokes the matching tokens that are not revoked yet,
+// and ends the sessions they belong to.
+func (r *tokenRepo) RevokeRefreshTokens(conditions map[string]any, revokedAt time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		sessionIDs := tx.Model(&domain.RefreshToken{}).Select("session_id").Where(conditions)
+		err := tx.Model(&domain.Session{}).Where("id IN (?) AND revoked_at IS NULL", sessionIDs).
+			Update("revoked_at", revokedAt).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&domain.RefreshToken{}).Where(conditions).Where("revoked_at IS NULL").
+			Update("revoked_at", revokedAt).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *tokenRepo) SaveSession(session *domain.Session) error {
+	if err := r.db.Create(session).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *tokenRepo) GetSession(conditions map[string]any) (*domain.Session, error) {
+	var session domain.Session
+
+	if err := r.db.Where(conditions).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &session, nil
+}
+
+// ListSessions lists the matching sessions, most recently seen first.
+func (r *tokenRepo) ListSessions(conditions map[string]any) ([]domain.Session, error) {
+	sessions := []domain.Session{}
+
+	if err := r.db.Where(conditions).Order("last_seen_at desc").Find(&sessions).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends the session with the given ID of the user and revokes
+// its refresh tokens. Sessions of other users and sessions already ended are
+// reported as not found.
+func (r *tokenRepo) RevokeSession(userID, id uuid.UUID, revokedAt time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Session{}).Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+			Update("revoked_at", revokedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return clients.ErrRecordNotFound
+		}
+
+		return tx.Model(&domain.RefreshToken{}).Where("session_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", revokedAt).Error
+	})
+	if errors.Is(err, clients.ErrRecordNotFound) {
+		return clients.ErrRecordNotFound
+	}
+
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *tokenRepo) TouchSession(id uuid.UUID, seenAt time.Time) error {
+	if err := r.db.Model(&domain.Session{}).Where("id = ?", id).Update("last_seen_at", seenAt).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *tokenRepo) SaveUserToken(token *domain.UserToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// ConsumeUserToken marks the unused, unexpired token with the given purpose
+// and hash as used and returns it. Consuming happens in a single update, so a
+// token can not be used twice by concurrent requests.
+func (r *tokenRepo) ConsumeUserToken(purpose, tokenHash string, usedAt time.Time) (*domain.UserToken, error) {
+	var token domain.UserToken
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.UserToken{}).
+			Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, tokenHash, usedAt).
+			Update("used_at", usedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return clients.ErrRecordNotFound
+		}
+
+		return tx.Where("token_hash = ?", tokenHash).First(&token).Error
+	})
+	if errors.Is(err, clients.ErrRecordNotFound) {
+		return nil, clients.ErrRecordNotFound
+	}
+
+	if err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return &token, nil
+}
+
+// ReplaceRecoveryCodes drops the recovery codes of the user in favour of
+// codes.
+func (r *tokenRepo) ReplaceRecoveryCodes(userID uuid.UUID, codes []domain.RecoveryCode) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		if len(codes) == 0 {
+			return nil
+		}
+
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCode marks an unused recovery code of the user as used, or
+// fails with ErrRecordNotFound.
+func (r *tokenRepo) ConsumeRecoveryCode(userID uuid.UUID, codeHash string, usedAt time.Time) error {
+	result := r.db.Model(&domain.RecoveryCode{}).Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return clients.ErrDB(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return clients.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *tokenRepo) SaveAPIKey(key *domain.APIKey) error {
+	if err := r.db.Create(key).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *tokenRepo) GetAPIKey(conditions map[string]any) (*domain.APIKey, error) {
+	var key domain.APIKey
+
+	if err := r.db.Where(conditions).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &key, nil
+}
+
+// ListAPIKeys lists the matching keys, newest first.
+func (r *tokenRepo) ListAPIKeys(conditions map[string]any) ([]domain.APIKey, error) {
+	keys := []domain.APIKey{}
+
+	if err := r.db.Where(conditions).Order("created_at desc").Find(&keys).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes the key with the given ID of the user. Keys of other
+// users and keys already revoked are reported as not found.
+func (r *tokenRepo) RevokeAPIKey(userID, id uuid.UUID, revokedAt time.Time) error {
+	result := r.db.Model(&domain.APIKey{}).Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return clients.ErrDB(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return clients.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *tokenRepo) TouchAPIKey(id uuid.UUID, usedAt time.Time) error {
+	if err := r.db.Model(&domain.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRotateRefreshToken(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.Migrate(db))
+
+	repo := postgres.NewTokenRepo(db)
+	userID := uuid.New()
+	newToken := func(hash string) *domain.RefreshToken {
+		return &domain.RefreshToken{ID: uuid.New(), UserID: userID, TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	first := newToken("first")
+	require.NoError(t, repo.SaveRefreshToken(first))
+
+	second := newToken("second")
+	require.NoError(t, repo.RotateRefreshToken(first.ID, second))
+
+	rotated, err := repo.GetRefreshToken(map[string]any{"token_hash": "first"})
+	require.NoError(t, err)
+	assert.NotNil(t, rotated.RevokedAt)
+	assert.Equal(t, second.ID, *rotated.ReplacedBy)
+
+	// A used token can not be rotated a second time
+	err = repo.RotateRefreshToken(first.ID, newToken("third"))
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+
+	_, err = repo.GetRefreshToken(map[string]any{"token_hash": "third"})
+	assert.Error(t, err)
+
+	require.NoError(t, repo.RevokeRefreshTokens(map[string]any{"user_id": userID}, time.Now()))
+
+	current, err := repo.GetRefreshToken(map[string]any{"token_hash": "second"})
+	require.NoError(t, err)
+	assert.NotNil(t, current.RevokedAt)
+}
+
+func TestConsumeUserToken(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.Migrate(db))
+
+	repo := postgres.NewTokenRepo(db)
+	userID := uuid.New()
+
+	require.NoError(t, repo.SaveUserToken(&domain.UserToken{
+		ID: uuid.New(), UserID: userID, Purpose: domain.TokenPurposePasswordReset, TokenHash: "reset", ExpiresAt: time.Now().Add(time.Hour),
+	}))
+	require.NoError(t, repo.SaveUserToken(&domain.UserToken{
+		ID: uuid.New(), UserID: userID, Purpose: domain.TokenPurposeEmailVerification, TokenHash: "expired", ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+
+	// A token only works for its own purpose
+	_, err = repo.ConsumeUserToken(domain.TokenPurposeEmailVerification, "reset", time.Now())
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	token, err := repo.ConsumeUserToken(domain.TokenPurposePasswordReset, "reset", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, userID, token.UserID)
+	assert.NotNil(t, token.UsedAt)
+
+	// Tokens are single-use
+	_, err = repo.ConsumeUserToken(domain.TokenPurposePasswordReset, "reset", time.Now())
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	_, err = repo.ConsumeUserToken(domain.TokenPurposeEmailVerification, "expired", time.Now())
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+}
+
+func TestAPIKeys(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.Migrate(db))
+
+	repo := postgres.NewTokenRepo(db)
+	userID := uuid.New()
+
+	key := &domain.APIKey{ID: uuid.New(), UserID: userID, Name: "CI", KeyHash: "hash", Scopes: domain.ScopeRead}
+	require.NoError(t, repo.SaveAPIKey(key))
+	require.NoError(t, repo.SaveAPIKey(&domain.APIKey{ID: uuid.New(), UserID: userID, Name: "Old", KeyHash: "old", Scopes: domain.ScopeRead}))
+
+	usedAt := time.Now()
+	require.NoError(t, repo.TouchAPIKey(key.ID, usedAt))
+
+	result, err := repo.GetAPIKey(map[string]any{"key_hash": "hash"})
+	require.NoError(t, err)
+	require.NotNil(t, result.LastUsedAt)
+	assert.WithinDuration(t, usedAt, *result.LastUsedAt, time.Second)
+
+	// Keys of other users can not be revoked
+	assert.ErrorIs(t, repo.RevokeAPIKey(uuid.New(), key.ID, time.Now()), clients.ErrRecordNotFound)
+
+	require.NoError(t, repo.RevokeAPIKey(userID, key.ID, time.Now()))
+	assert.ErrorIs(t, repo.RevokeAPIKey(userID, key.ID, time.Now()), clients.ErrRecordNotFound)
+
+	keys, err := repo.ListAPIKeys(map[string]any{"user_id": userID, "revoked_at": nil})
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "Old", keys[0].Name)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.Migrate(db))
+
+	repo := postgres.NewTokenRepo(db)
+	userID := uuid.New()
+
+	require.NoError(t, repo.ReplaceRecoveryCodes(userID, []domain.RecoveryCode{
+		{ID: uuid.New(), UserID: userID, CodeHash: "old"},
+	}))
+	require.NoError(t, repo.ReplaceRecoveryCodes(userID, []domain.RecoveryCode{
+		{ID: uuid.New(), UserID: userID, CodeHash: "one"},
+		{ID: uuid.New(), UserID: userID, CodeHash: "two"},
+	}))
+
+	// Replaced codes are gone and codes only work for their user, once
+	assert.ErrorIs(t, repo.ConsumeRecoveryCode(userID, "old", time.Now()), clients.ErrRecordNotFound)
+	assert.ErrorIs(t, repo.ConsumeRecoveryCode(uuid.New(), "one", time.Now()), clients.ErrRecordNotFound)
+	assert.NoError(t, repo.ConsumeRecoveryCode(userID, "one", time.Now()))
+	assert.ErrorIs(t, repo.ConsumeRecoveryCode(userID, "one", time.Now()), clients.ErrRecordNotFound)
+
+	require.NoError(t, repo.ReplaceRecoveryCodes(userID, nil))
+	assert.ErrorIs(t, repo.ConsumeRecoveryCode(userID, "two", time.Now()), clients.ErrRecordNotFound)
+}
+
+func TestSessions(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.Migrate(db))
+
+	repo := postgres.NewTokenRepo(db)
+	userID := uuid.New()
+	newSession := func(userAgent string, lastSeenAt time.Time) (*domain.Session, *domain.RefreshToken) {
+		session := &domain.Session{ID: uuid.New(), UserID: userID, UserAgent: userAgent, LastSeenAt: lastSeenAt}
+		require.NoError(t, repo.SaveSession(session))
+
+		token := &domain.RefreshToken{ID: uuid.New(), UserID: userID, SessionID: &session.ID, TokenHash: userAgent, ExpiresAt: time.Now().Add(time.Hour)}
+		require.NoError(t, repo.SaveRefreshToken(token))
+
+		return session, token
+	}
+
+	laptop, laptopToken := newSession("laptop", time.Now().Add(-time.Hour))
+	phone, phoneToken := newSession("phone", time.Now().Add(-2*time.Hour))
+	tablet, _ := newSession("tablet", time.Now().Add(-3*time.Hour))
+
+	seenAt := time.Now()
+	require.NoError(t, repo.TouchSession(phone.ID, seenAt))
+
+	sessions, err := repo.ListSessions(map[string]any{"user_id": userID, "revoked_at": nil})
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	assert.Equal(t, "phone", sessions[0].UserAgent)
+	assert.WithinDuration(t, seenAt, sessions[0].LastSeenAt, time.Second)
+
+	// Test case 1: Revoking a session revokes its refresh tokens
+	assert.ErrorIs(t, repo.RevokeSession(uuid.New(), laptop.ID, time.Now()), clients.ErrRecordNotFound)
+	require.NoError(t, repo.RevokeSession(userID, laptop.ID, time.Now()))
+	assert.ErrorIs(t, repo.RevokeSession(userID, laptop.ID, time.Now()), clients.ErrRecordNotFound)
+
+	token, err := repo.GetRefreshToken(map[string]any{"id": laptopToken.ID})
+	require.NoError(t, err)
+	assert.NotNil(t, token.RevokedAt)
+
+	token, err = repo.GetRefreshToken(map[string]any{"id": phoneToken.ID})
+	require.NoError(t, err)
+	assert.Nil(t, token.RevokedAt)
+
+	// Test case 2: Revoking refresh tokens ends their sessions
+	require.NoError(t, repo.RevokeRefreshTokens(map[string]any{"user_id": userID, "token_hash": "phone"}, time.Now()))
+
+	session, err := repo.GetSession(map[string]any{"id": phone.ID})
+	require.NoError(t, err)
+	assert.NotNil(t, session.RevokedAt)
+
+	sessions, err = repo.ListSessions(map[string]any{"user_id": userID, "revoked_at": nil})
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, tablet.ID, sessions[0].ID)
+}
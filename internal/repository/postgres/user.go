@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"errors"
+	"strings"
+	"time"
 	"todo-app/domain"
 	"todo-app/pkg/clients"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -26,10 +29,25 @@ func (r *userRepo) Save(user *domain.UserCreate) error {
 	return nil
 }
 
+// GetUser returns the first user matching the conditions. Emails are kept as
+// typed at registration but matched regardless of case.
 func (r *userRepo) GetUser(conditions map[string]any) (*domain.User, error) {
 	var user domain.User
 
-	if err := r.db.Where(conditions).First(&user).Error; err != nil {
+	query := r.db
+	if email, ok := conditions["email"].(string); ok {
+		rest := make(map[string]any, len(conditions))
+		for key, value := range conditions {
+			if key != "email" {
+				rest[key] = value
+			}
+		}
+
+		conditions = rest
+		query = query.Where("LOWER(email) = ?", strings.ToLower(email))
+	}
+
+	if err := query.Where(conditions).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, clients.ErrRecordNotFound
 		}
@@ -39,3 +57,169 @@ func (r *userRepo) GetUser(conditions map[string]any) (*domain.User, error) {
 
 	return &user, nil
 }
+
+// UpdatePassword stores a new password hash. The hash carries its own salt,
+// so the legacy salt column is cleared.
+func (r *userRepo) UpdatePassword(id uuid.UUID, password string) error {
+	err := r.db.Table(domain.User{}.TableName()).Where("id = ?", id).
+		Updates(map[string]any{"password": password, "salt": "", "updated_at": time.Now()}).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *userRepo) MarkEmailVerified(id uuid.UUID, verifiedAt time.Time) error {
+	err := r.db.Table(domain.User{}.TableName()).Where("id = ?", id).
+		Updates(map[string]any{"email_verified_at": verifiedAt, "updated_at": verifiedAt}).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// SetTOTPSecret stores the secret of a pending two-factor enrollment, which
+// turns two-factor authentication off until it is enabled again. An empty
+// secret removes it.
+func (r *userRepo) SetTOTPSecret(id uuid.UUID, secret string) error {
+	err := r.db.Table(domain.User{}.TableName()).Where("id = ?", id).
+		Updates(map[string]any{"totp_secret": secret, "totp_enabled_at": nil, "totp_last_step": 0}).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *userRepo) EnableTOTP(id uuid.UUID, enabledAt time.Time) error {
+	err := r.db.Table(domain.User{}.TableName()).Where("id = ?", id).
+		Updates(map[string]any{"totp_enabled_at": enabledAt, "updated_at": enabledAt}).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the time step of an accepted TOTP code. Only steps
+// after the last recorded one are accepted, so of two logins with the same
+// code one fails with ErrRecordNotFound.
+func (r *userRepo) UseTOTPStep(id uuid.UUID, step int64) error {
+	result := r.db.Table(domain.User{}.TableName()).Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return clients.ErrDB(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return clients.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepo) Update(id uuid.UUID, data *domain.UserUpdate) error {
+	if err := r.db.Where("id = ?", id).Updates(data).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Delete soft-deletes the user and moves their personal items to the trash,
+// where they are purged once the trash retention passes. Workspace items they
+// created belong to the team and stay. The email is replaced by
+// a placeholder so that it can be registered again, and the memberships,
+// shares and linked identities of the user are removed.
+func (r *userRepo) Delete(id uuid.UUID, deletedAt time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := tx.Where("id = ?", id).First(&user).Error; err != nil {
+			return err
+		}
+
+		err := tx.Table(domain.User{}.TableName()).Where("id = ?", id).
+			Updates(map[string]any{
+				"status":     clients.Deleted,
+				"email":      deletedEmail(id),
+				"updated_at": deletedAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", id).Delete(&domain.Membership{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("owner_id = ? OR user_id = ? OR email = ?", id, id, user.Email).Delete(&domain.Share{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", id).Delete(&domain.UserIdentity{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(domain.Item{}.TableName()).
+			Where("user_id = ? AND workspace_id IS NULL AND status <> ?", id, domain.Deleted).
+			Updates(map[string]any{
+				"restore_status": gorm.Expr("status"),
+				"status":         domain.Deleted,
+				"deleted_at":     deletedAt,
+				"updated_at":     deletedAt,
+			}).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// deletedEmail is the placeholder email of a deleted user. The invalid
+// top-level domain keeps it from ever reaching a mailbox.
+func deletedEmail(id uuid.UUID) string {
+	return "deleted-" + id.String() + "@example.invalid"
+}
+
+// CountSoleOwnedWorkspaces counts the workspaces the user is the only owner
+// of.
+func (r *userRepo) CountSoleOwnedWorkspaces(userID uuid.UUID) (int64, error) {
+	var count int64
+
+	owners := r.db.Table(domain.Membership{}.TableName()+" AS o").Select("COUNT(*)").
+		Where("o.workspace_id = m.workspace_id AND o.role = ?", domain.WorkspaceOwner)
+	err := r.db.Table(domain.Membership{}.TableName()+" AS m").
+		Where("m.user_id = ? AND m.role = ?", userID, domain.WorkspaceOwner).
+		Where("(?) = 1", owners).
+		Count(&count).Error
+	if err != nil {
+		return 0, clients.ErrDB(err)
+	}
+
+	return count, nil
+}
+
+func (r *userRepo) GetIdentity(conditions map[string]any) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+
+	if err := r.db.Where(conditions).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &identity, nil
+}
+
+func (r *userRepo) SaveIdentity(identity *domain.UserIdentity) error {
+	if err := r.db.Create(identity).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
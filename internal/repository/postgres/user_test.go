@@ -0,0 +1,206 @@
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupUserTestDB(t *testing.T) *gorm.DB {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.Migrate(db))
+
+	return db
+}
+
+func TestUpdateUser(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := postgres.NewUserRepo(db)
+
+	user := &domain.UserCreate{ID: uuid.New(), Email: "a@example.com", FirstName: "Ann", LastName: "Lee", Status: clients.Active}
+	require.NoError(t, repo.Save(user))
+
+	phone := "+84 912 345 678"
+	require.NoError(t, repo.Update(user.ID, &domain.UserUpdate{Phone: &phone, UpdatedAt: time.Now()}))
+
+	result, err := repo.GetUser(map[string]any{"id": user.ID})
+	require.NoError(t, err)
+	assert.Equal(t, phone, result.Phone)
+	assert.Equal(t, "Ann", result.FirstName) // untouched fields stay
+}
+
+// TestGetUserByEmail checks that emails registered with capitals are found
+// by the lowercased emails of member invitations and mentions.
+func TestGetUserByEmail(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := postgres.NewUserRepo(db)
+
+	user := &domain.UserCreate{ID: uuid.New(), Email: "Ann.Lee@Example.com", Status: clients.Active}
+	require.NoError(t, repo.Save(user))
+
+	for _, email := range []string{"ann.lee@example.com", "ANN.LEE@EXAMPLE.COM", "Ann.Lee@Example.com"} {
+		result, err := repo.GetUser(map[string]any{"email": email})
+		require.NoError(t, err, email)
+		assert.Equal(t, user.ID, result.ID)
+		assert.Equal(t, "Ann.Lee@Example.com", result.Email) // kept as typed
+	}
+
+	_, err := repo.GetUser(map[string]any{"email": "ann.lee@example.com", "status": clients.Deleted})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+}
+
+func TestDeleteUser(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := postgres.NewUserRepo(db)
+
+	user := &domain.UserCreate{ID: uuid.New(), Email: "a@example.com", Status: clients.Active}
+	require.NoError(t, repo.Save(user))
+
+	own := insertMockItem(db, "Own", "", user.ID)
+	done := insertMockItem(db, "Done", "", user.ID)
+	db.Model(&done).Update("status", domain.Done)
+	otherID := uuid.New()
+	other := insertMockItem(db, "Other", "", otherID)
+
+	workspaceID := uuid.New()
+	require.NoError(t, db.Create(&domain.Membership{WorkspaceID: workspaceID, UserID: user.ID, Role: domain.WorkspaceMember}).Error)
+	team := insertMockItem(db, "Team", "", user.ID)
+	db.Model(&team).Update("workspace_id", workspaceID)
+	require.NoError(t, db.Create(&domain.Membership{WorkspaceID: workspaceID, UserID: otherID, Role: domain.WorkspaceOwner}).Error)
+	shares := []domain.Share{
+		{ID: uuid.New(), OwnerID: user.ID, ItemID: &own.ID, Email: "b@example.com", UserID: &otherID},
+		{ID: uuid.New(), OwnerID: otherID, ItemID: &other.ID, Email: user.Email, UserID: &user.ID},
+		{ID: uuid.New(), OwnerID: otherID, ItemID: &other.ID, Email: user.Email}, // pending invitation
+		{ID: uuid.New(), OwnerID: otherID, ItemID: &other.ID, Email: "c@example.com"},
+	}
+	require.NoError(t, db.Create(&shares).Error)
+	require.NoError(t, db.Create(&domain.UserIdentity{ID: uuid.New(), UserID: user.ID, Issuer: "https://idp", Subject: "1"}).Error)
+
+	require.NoError(t, repo.Delete(user.ID, time.Now()))
+
+	result, err := repo.GetUser(map[string]any{"id": user.ID})
+	require.NoError(t, err)
+	assert.Equal(t, clients.Deleted, result.Status)
+
+	// The email is free for a new account
+	assert.NotEqual(t, user.Email, result.Email)
+	_, err = repo.GetUser(map[string]any{"email": user.Email})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	var members, identities int64
+	db.Model(&domain.Membership{}).Count(&members)
+	assert.EqualValues(t, 1, members)
+	db.Model(&domain.UserIdentity{}).Count(&identities)
+	assert.Zero(t, identities)
+
+	var remaining []domain.Share
+	require.NoError(t, db.Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "c@example.com", remaining[0].Email)
+
+	var trashed domain.Item
+	require.NoError(t, db.First(&trashed, "id = ?", own.ID).Error)
+	assert.Equal(t, domain.Deleted, trashed.Status)
+	assert.NotNil(t, trashed.DeletedAt)
+
+	// The previous status is kept for a restore
+	var trashedDone domain.Item
+	require.NoError(t, db.First(&trashedDone, "id = ?", done.ID).Error)
+	assert.Equal(t, domain.Done, trashedDone.RestoreStatus)
+
+	var untouched domain.Item
+	require.NoError(t, db.First(&untouched, "id = ?", other.ID).Error)
+	assert.Equal(t, domain.Active, untouched.Status)
+
+	// Workspace items belong to the team and stay live
+	var teamItem domain.Item
+	require.NoError(t, db.First(&teamItem, "id = ?", team.ID).Error)
+	assert.Equal(t, domain.Active, teamItem.Status)
+	assert.Nil(t, teamItem.DeletedAt)
+}
+
+func TestCountSoleOwnedWorkspaces(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := postgres.NewUserRepo(db)
+
+	userID, otherID := uuid.New(), uuid.New()
+	alone, shared, member := uuid.New(), uuid.New(), uuid.New()
+	memberships := []domain.Membership{
+		{WorkspaceID: alone, UserID: userID, Role: domain.WorkspaceOwner},
+		{WorkspaceID: alone, UserID: otherID, Role: domain.WorkspaceAdmin},
+		{WorkspaceID: shared, UserID: userID, Role: domain.WorkspaceOwner},
+		{WorkspaceID: shared, UserID: otherID, Role: domain.WorkspaceOwner},
+		{WorkspaceID: member, UserID: userID, Role: domain.WorkspaceMember},
+	}
+	require.NoError(t, db.Create(&memberships).Error)
+
+	count, err := repo.CountSoleOwnedWorkspaces(userID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	count, err = repo.CountSoleOwnedWorkspaces(otherID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestTOTP(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := postgres.NewUserRepo(db)
+
+	user := &domain.UserCreate{ID: uuid.New(), Email: "a@example.com", Status: clients.Active}
+	require.NoError(t, repo.Save(user))
+
+	require.NoError(t, repo.SetTOTPSecret(user.ID, "SECRET"))
+	require.NoError(t, repo.UseTOTPStep(user.ID, 100))
+	require.NoError(t, repo.EnableTOTP(user.ID, time.Now()))
+
+	result, err := repo.GetUser(map[string]any{"id": user.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "SECRET", result.TOTPSecret)
+	assert.Equal(t, int64(100), result.TOTPLastStep)
+	assert.NotNil(t, result.TOTPEnabledAt)
+
+	// A step is only accepted once, and never an earlier one
+	assert.ErrorIs(t, repo.UseTOTPStep(user.ID, 100), clients.ErrRecordNotFound)
+	assert.ErrorIs(t, repo.UseTOTPStep(user.ID, 99), clients.ErrRecordNotFound)
+	assert.NoError(t, repo.UseTOTPStep(user.ID, 101))
+
+	require.NoError(t, repo.SetTOTPSecret(user.ID, ""))
+
+	result, err = repo.GetUser(map[string]any{"id": user.ID})
+	require.NoError(t, err)
+	assert.Empty(t, result.TOTPSecret)
+	assert.Nil(t, result.TOTPEnabledAt)
+	assert.Zero(t, result.TOTPLastStep)
+}
+
+func TestUserIdentity(t *testing.T) {
+	db := setupUserTestDB(t)
+	repo := postgres.NewUserRepo(db)
+
+	userID := uuid.New()
+	identity := &domain.UserIdentity{ID: uuid.New(), UserID: userID, Issuer: "https://idp.example.com", Subject: "42", Email: "a@example.com"}
+	require.NoError(t, repo.SaveIdentity(identity))
+
+	result, err := repo.GetIdentity(map[string]any{"issuer": identity.Issuer, "subject": "42"})
+	require.NoError(t, err)
+	assert.Equal(t, userID, result.UserID)
+
+	// A subject of an issuer links one user only
+	duplicate := &domain.UserIdentity{ID: uuid.New(), UserID: uuid.New(), Issuer: identity.Issuer, Subject: "42"}
+	assert.Error(t, repo.SaveIdentity(duplicate))
+
+	_, err = repo.GetIdentity(map[string]any{"issuer": "https://other.example.com", "subject": "42"})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type workspaceRepo struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepo(db *gorm.DB) *workspaceRepo {
+	return &workspaceRepo{
+		db: db,
+	}
+}
+
+func (r *workspaceRepo) Create(workspace *domain.Workspace, owner *domain.Membership) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(owner).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *workspaceRepo) GetWorkspace(conditions map[string]any) (*domain.Workspace, error) {
+	var workspace domain.Workspace
+
+	if err := r.db.Where(conditions).First(&workspace).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &workspace, nil
+}
+
+// ListWorkspaces returns the workspaces with the given IDs, by name.
+func (r *workspaceRepo) ListWorkspaces(ids []uuid.UUID) ([]domain.Workspace, error) {
+	workspaces := []domain.Workspace{}
+	if len(ids) == 0 {
+		return workspaces, nil
+	}
+
+	if err := r.db.Where("id IN ?", ids).Order("name").Find(&workspaces).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return workspaces, nil
+}
+
+func (r *workspaceRepo) Update(id uuid.UUID, name string, updatedAt time.Time) error {
+	err := r.db.Model(&domain.Workspace{}).Where("id = ?", id).
+		Updates(map[string]any{"name": name, "updated_at": updatedAt}).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Delete removes the workspace together with its members, lists and items,
+// and the shares of its lists and items.
+func (r *workspaceRepo) Delete(id uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		items := tx.Table(domain.Item{}.TableName()).Select("id").Where("workspace_id = ?", id)
+		if err := deleteItemRows(tx, items); err != nil {
+			return err
+		}
+
+		if err := tx.Where("workspace_id = ?", id).Delete(&domain.Item{}).Error; err != nil {
+			return err
+		}
+
+		lists := tx.Table(domain.List{}.TableName()).Select("id").Where("workspace_id = ?", id)
+		if err := tx.Where("list_id IN (?)", lists).Delete(&domain.Share{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("workspace_id = ?", id).Delete(&domain.List{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("workspace_id = ?", id).Delete(&domain.Membership{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&domain.Workspace{}).Error
+	})
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *workspaceRepo) GetMember(conditions map[string]any) (*domain.Membership, error) {
+	var member domain.Membership
+
+	if err := r.db.Where(conditions).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, clients.ErrRecordNotFound
+		}
+
+		return nil, clients.ErrDB(err)
+	}
+
+	return &member, nil
+}
+
+// ListMembers returns the matching memberships with the emails of the
+// members, oldest first.
+func (r *workspaceRepo) ListMembers(conditions map[string]any) ([]domain.Membership, error) {
+	members := []domain.Membership{}
+	table := domain.Membership{}.TableName()
+
+	query := r.db.Table(table).
+		Select(table + ".*, users.email").
+		Joins("JOIN users ON users.id = " + table + ".user_id")
+	for key, value := range conditions {
+		query = query.Where(clause.Eq{Column: clause.Column{Table: table, Name: key}, Value: value})
+	}
+
+	if err := query.Order(table + ".created_at").Find(&members).Error; err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	return members, nil
+}
+
+func (r *workspaceRepo) CountMembers(conditions map[string]any) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&domain.Membership{}).Where(conditions).Count(&count).Error; err != nil {
+		return 0, clients.ErrDB(err)
+	}
+
+	return count, nil
+}
+
+func (r *workspaceRepo) SaveMember(member *domain.Membership) error {
+	if err := r.db.Create(member).Error; err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *workspaceRepo) UpdateMember(workspaceID, userID uuid.UUID, role domain.WorkspaceRole) error {
+	err := r.db.Model(&domain.Membership{}).Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *workspaceRepo) DeleteMember(workspaceID, userID uuid.UUID) error {
+	err := r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&domain.Membership{}).Error
+	if err != nil {
+		return clients.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package postgres_test
+
+import (
+	"testing"
+	"todo-app/domain"
+	"todo-app/internal/repository/postgres"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// insertWorkspaceItem inserts an item created by the user in the workspace.
+func insertWorkspaceItem(db *gorm.DB, title string, userID, workspaceID uuid.UUID) domain.Item {
+	item := insertMockItem(db, title, "", userID)
+	db.Model(&item).Update("workspace_id", workspaceID)
+
+	return item
+}
+
+func itemTitles(items []domain.Item) []string {
+	titles := []string{}
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+
+	return titles
+}
+
+func TestTenantIsolation(t *testing.T) {
+	db, itemRepo, err := setupTestDB()
+	require.NoError(t, err)
+
+	userID, teammateID := uuid.New(), uuid.New()
+	teamA, teamB := uuid.New(), uuid.New()
+
+	insertMockItem(db, "Personal", "", userID)
+	insertWorkspaceItem(db, "Team A by me", userID, teamA)
+	insertWorkspaceItem(db, "Team A by teammate", teammateID, teamA)
+	teamBItem := insertWorkspaceItem(db, "Team B", teammateID, teamB)
+
+	paging := &clients.Paging{Limit: 10, Page: 1}
+	tenants := []struct {
+		name   string
+		tenant domain.Tenant
+		want   []string
+	}{
+		{"personal", domain.Tenant{UserID: userID}, []string{"Personal"}},
+		{"team A", domain.Tenant{UserID: userID, WorkspaceID: &teamA}, []string{"Team A by me", "Team A by teammate"}},
+		{"team B", domain.Tenant{UserID: userID, WorkspaceID: &teamB}, []string{"Team B"}},
+		{"teammate personal", domain.Tenant{UserID: teammateID}, []string{}},
+	}
+
+	for _, c := range tenants {
+		t.Run(c.name, func(t *testing.T) {
+			items, err := itemRepo.GetAll(c.tenant.Conditions(), paging)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, c.want, itemTitles(items))
+		})
+	}
+
+	// An item of team B can not be reached through the conditions of team A
+	conditions := domain.Tenant{UserID: teammateID, WorkspaceID: &teamA}.Conditions()
+	conditions["id"] = teamBItem.ID
+	_, err = itemRepo.GetItem(conditions)
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	// Nor through the personal space of its creator
+	conditions = domain.Tenant{UserID: teammateID}.Conditions()
+	conditions["id"] = teamBItem.ID
+	_, err = itemRepo.GetItem(conditions)
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	listRepo := postgres.NewListRepo(db)
+	require.NoError(t, listRepo.Save(&domain.ListCreation{ID: uuid.New(), Name: "Mine", UserID: userID}))
+	require.NoError(t, listRepo.Save(&domain.ListCreation{ID: uuid.New(), Name: "Team A", UserID: userID, WorkspaceID: &teamA}))
+
+	lists, err := listRepo.GetAll(domain.Tenant{UserID: userID}.Conditions(), paging)
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, "Mine", lists[0].Name)
+
+	lists, err = listRepo.GetAll(domain.Tenant{UserID: teammateID, WorkspaceID: &teamA}.Conditions(), paging)
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, "Team A", lists[0].Name)
+}
+
+func TestWorkspaces(t *testing.T) {
+	db, itemRepo, err := setupTestDB()
+	require.NoError(t, err)
+
+	repo := postgres.NewWorkspaceRepo(db)
+	users := postgres.NewUserRepo(db)
+
+	owner := &domain.UserCreate{ID: uuid.New(), Email: "owner@example.com", Status: clients.Active}
+	viewer := &domain.UserCreate{ID: uuid.New(), Email: "viewer@example.com", Status: clients.Active}
+	require.NoError(t, users.Save(owner))
+	require.NoError(t, users.Save(viewer))
+
+	workspace := &domain.Workspace{ID: uuid.New(), Name: "Platform", CreatedBy: owner.ID}
+	require.NoError(t, repo.Create(workspace, &domain.Membership{WorkspaceID: workspace.ID, UserID: owner.ID, Role: domain.WorkspaceOwner}))
+	require.NoError(t, repo.SaveMember(&domain.Membership{WorkspaceID: workspace.ID, UserID: viewer.ID, Role: domain.WorkspaceViewer}))
+
+	members, err := repo.ListMembers(map[string]any{"workspace_id": workspace.ID})
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.ElementsMatch(t, []string{"owner@example.com", "viewer@example.com"}, []string{members[0].Email, members[1].Email})
+
+	owners, err := repo.CountMembers(map[string]any{"workspace_id": workspace.ID, "role": domain.WorkspaceOwner})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), owners)
+
+	require.NoError(t, repo.UpdateMember(workspace.ID, viewer.ID, domain.WorkspaceOwner))
+	owners, err = repo.CountMembers(map[string]any{"workspace_id": workspace.ID, "role": domain.WorkspaceOwner})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), owners)
+
+	personal := insertMockItem(db, "Personal", "", owner.ID)
+	insertWorkspaceItem(db, "Team", owner.ID, workspace.ID)
+
+	require.NoError(t, repo.Delete(workspace.ID))
+
+	_, err = repo.GetWorkspace(map[string]any{"id": workspace.ID})
+	assert.ErrorIs(t, err, clients.ErrRecordNotFound)
+
+	members, err = repo.ListMembers(map[string]any{"user_id": owner.ID})
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	// Only the items of the workspace go with it
+	items, err := itemRepo.GetAll(map[string]any{"user_id": owner.ID}, &clients.Paging{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, personal.ID, items[0].ID)
+}
@@ -0,0 +1,134 @@
+package item
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// AssignItem hands an item the user can edit to the assignee, or takes it
+// back when no assignee is given. The assignee needs to be able to view the
+// item. Every change of the assignee is recorded in the item's history.
+func (s *itemService) AssignItem(id, userID uuid.UUID, assignment *domain.ItemAssignment) error {
+	if err := assignment.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	item, err := s.itemRepo.GetItem(map[string]any{"id": id})
+	if err != nil {
+		return clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	if err := s.authorize(item, userID, domain.AccessEdit); err != nil {
+		return err
+	}
+
+	if item.Status == domain.Deleted {
+		return clients.ErrEntityDeleted(item.TableName(), nil)
+	}
+
+	if sameAssignee(item.AssigneeID, assignment.AssigneeID) {
+		return nil
+	}
+
+	action := domain.ItemEventUnassigned
+	if assignment.AssigneeID != nil {
+		access, err := s.permissions.ItemAccess(item, *assignment.AssigneeID)
+		if err != nil {
+			return clients.ErrInternal(err)
+		}
+
+		if access < domain.AccessView {
+			return domain.ErrAssigneeNoAccess
+		}
+
+		action = domain.ItemEventAssigned
+	}
+
+	event, err := newItemEvent(id, userID, action, map[string]*uuid.UUID{
+		"from": item.AssigneeID,
+		"to":   assignment.AssigneeID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.itemRepo.Transaction(func(repo ItemRepo) error {
+		if err := repo.Assign(id, assignment.AssigneeID, time.Now()); err != nil {
+			return err
+		}
+
+		return repo.SaveEvent(event)
+	})
+	if err != nil {
+		return clients.ErrCannotUpdateEntity(item.TableName(), err)
+	}
+
+	return nil
+}
+
+// GetItemHistory lists the recorded changes of an item the user can view,
+// newest first.
+func (s *itemService) GetItemHistory(id, userID uuid.UUID, paging *clients.Paging) ([]domain.ItemEvent, error) {
+	item, err := s.itemRepo.GetItem(map[string]any{"id": id})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	if err := s.authorize(item, userID, domain.AccessView); err != nil {
+		return nil, err
+	}
+
+	events, err := s.itemRepo.GetEvents(map[string]any{"item_id": id}, paging)
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.ItemEvent{}.TableName(), err)
+	}
+
+	return events, nil
+}
+
+// authorizeUpdate refuses the update unless the user can edit the item. The
+// assignee can also change its status alone, as long as they can still view
+// it.
+func (s *itemService) authorizeUpdate(item domain.Item, userID uuid.UUID, itemUpdate *domain.ItemUpdate) error {
+	access, err := s.permissions.ItemAccess(item, userID)
+	if err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	assignee := item.AssigneeID != nil && *item.AssigneeID == userID
+	if access >= domain.AccessEdit || (assignee && access >= domain.AccessView && itemUpdate.StatusOnly()) {
+		return nil
+	}
+
+	return clients.ErrNoPermission(errors.New("no access to the item"))
+}
+
+// newItemEvent builds an event of the item's history with details encoded as
+// JSON.
+func newItemEvent(itemID, actorID uuid.UUID, action string, details any) (*domain.ItemEvent, error) {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return nil, clients.ErrInternal(err)
+	}
+
+	return &domain.ItemEvent{
+		ID:      uuid.New(),
+		ItemID:  itemID,
+		ActorID: actorID,
+		Action:  action,
+		Details: string(data),
+	}, nil
+}
+
+func sameAssignee(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
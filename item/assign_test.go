@@ -0,0 +1,197 @@
+package item_test
+
+import (
+	"encoding/json"
+	"testing"
+	"todo-app/domain"
+	service "todo-app/item"
+	"todo-app/item/mocks"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignItem(t *testing.T) {
+	ownerID, assigneeID, strangerID := uuid.New(), uuid.New(), uuid.New()
+	itemID := uuid.New()
+	item := domain.Item{ID: itemID, UserID: ownerID, Status: domain.Active}
+
+	setup := func() (*mocks.ItemRepo, interface {
+		AssignItem(id, userID uuid.UUID, assignment *domain.ItemAssignment) error
+	}) {
+		mockItemRepo := new(mocks.ItemRepo)
+		permissions := new(mocks.PermissionResolver)
+		permissions.On("ItemAccess", mock.Anything, ownerID).Return(domain.AccessOwner, nil)
+		permissions.On("ItemAccess", mock.Anything, assigneeID).Return(domain.AccessView, nil)
+		permissions.On("ItemAccess", mock.Anything, strangerID).Return(domain.AccessNone, nil)
+
+		return mockItemRepo, service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), permissions, false)
+	}
+
+	t.Run("records the assignment", func(t *testing.T) {
+		mockItemRepo, itemService := setup()
+		inTransaction(mockItemRepo)
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(item, nil).Once()
+		mockItemRepo.On("Assign", itemID, &assigneeID, mock.Anything).Return(nil).Once()
+		mockItemRepo.On("SaveEvent", mock.MatchedBy(func(event *domain.ItemEvent) bool {
+			var details map[string]*uuid.UUID
+			require.NoError(t, json.Unmarshal([]byte(event.Details), &details))
+
+			return event.ItemID == itemID && event.ActorID == ownerID && event.Action == domain.ItemEventAssigned &&
+				details["from"] == nil && *details["to"] == assigneeID
+		})).Return(nil).Once()
+
+		err := itemService.AssignItem(itemID, ownerID, &domain.ItemAssignment{AssigneeID: &assigneeID})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("records the unassignment", func(t *testing.T) {
+		mockItemRepo, itemService := setup()
+		inTransaction(mockItemRepo)
+
+		assigned := item
+		assigned.AssigneeID = &assigneeID
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(assigned, nil).Once()
+		mockItemRepo.On("Assign", itemID, (*uuid.UUID)(nil), mock.Anything).Return(nil).Once()
+		mockItemRepo.On("SaveEvent", mock.MatchedBy(func(event *domain.ItemEvent) bool {
+			return event.Action == domain.ItemEventUnassigned
+		})).Return(nil).Once()
+
+		err := itemService.AssignItem(itemID, ownerID, &domain.ItemAssignment{})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("same assignee", func(t *testing.T) {
+		mockItemRepo, itemService := setup()
+
+		assigned := item
+		assigned.AssigneeID = &assigneeID
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(assigned, nil).Once()
+
+		again := assigneeID
+		err := itemService.AssignItem(itemID, ownerID, &domain.ItemAssignment{AssigneeID: &again})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertNotCalled(t, "Assign", mock.Anything, mock.Anything, mock.Anything)
+		mockItemRepo.AssertNotCalled(t, "SaveEvent", mock.Anything)
+	})
+
+	t.Run("assignee without access", func(t *testing.T) {
+		mockItemRepo, itemService := setup()
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(item, nil).Once()
+
+		err := itemService.AssignItem(itemID, ownerID, &domain.ItemAssignment{AssigneeID: &strangerID})
+
+		assert.ErrorIs(t, err, domain.ErrAssigneeNoAccess)
+		mockItemRepo.AssertNotCalled(t, "Assign", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("viewers can not assign", func(t *testing.T) {
+		mockItemRepo, itemService := setup()
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(item, nil).Once()
+
+		err := itemService.AssignItem(itemID, assigneeID, &domain.ItemAssignment{AssigneeID: &assigneeID})
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "Assign", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAssigneePermissions(t *testing.T) {
+	ownerID, assigneeID := uuid.New(), uuid.New()
+	itemID := uuid.New()
+	assigned := domain.Item{ID: itemID, UserID: ownerID, AssigneeID: &assigneeID, Status: domain.Active}
+
+	setup := func() (*mocks.ItemRepo, interface {
+		UpdateItem(id, userID uuid.UUID, item *domain.ItemUpdate) error
+		DeleteItem(id, userID uuid.UUID) error
+	}) {
+		mockItemRepo := new(mocks.ItemRepo)
+		permissions := new(mocks.PermissionResolver)
+		permissions.On("ItemAccess", assigned, assigneeID).Return(domain.AccessView, nil)
+
+		return mockItemRepo, service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), permissions, false)
+	}
+
+	t.Run("assignee changes the status", func(t *testing.T) {
+		mockItemRepo, itemService := setup()
+
+		done := domain.Done
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(assigned, nil).Once()
+		mockItemRepo.On("GetSubtree", itemID).Return([]domain.Item{assigned}, nil).Once()
+		mockItemRepo.On("Update", map[string]any{"id": itemID}, mock.Anything).Return(nil).Once()
+
+		err := itemService.UpdateItem(itemID, assigneeID, &domain.ItemUpdate{Status: &done})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("assignee can not edit", func(t *testing.T) {
+		mockItemRepo, itemService := setup()
+
+		done, title := domain.Done, "Changed"
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(assigned, nil).Once()
+
+		err := itemService.UpdateItem(itemID, assigneeID, &domain.ItemUpdate{Status: &done, Title: &title})
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("assignee can not delete", func(t *testing.T) {
+		mockItemRepo, itemService := setup()
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(assigned, nil).Once()
+
+		err := itemService.DeleteItem(itemID, assigneeID)
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "Trash", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetAllItem_AssignedToMe(t *testing.T) {
+	userID, ownerID, workspaceID := uuid.New(), uuid.New(), uuid.New()
+	paging := &clients.Paging{Limit: 10, Page: 1}
+
+	t.Run("Personal space lists shared items assigned to the user", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+		shared := domain.Item{ID: uuid.New(), UserID: ownerID, AssigneeID: &userID, Status: domain.Active}
+
+		mockItemRepo.On("GetAll", map[string]any{"workspace_id": nil, "assignee_id": userID, "status": domain.LiveStatuses, "sort": newestFirst}, paging).
+			Return([]domain.Item{shared}, nil).Once()
+
+		items, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{AssignedToMe: true}, paging)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Item{shared}, items)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("Workspace keeps its scope", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		permissions := new(mocks.PermissionResolver)
+		permissions.On("WorkspaceAccess", workspaceID, userID).Return(domain.AccessView, nil)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), permissions, false)
+
+		mockItemRepo.On("GetAll", map[string]any{"workspace_id": workspaceID, "assignee_id": userID, "status": domain.LiveStatuses, "sort": newestFirst}, paging).
+			Return([]domain.Item{}, nil).Once()
+
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID, WorkspaceID: &workspaceID}, &domain.ItemFilter{AssignedToMe: true}, paging)
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,109 @@
+package item
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+)
+
+// errBatchAborted rolls back an atomic batch after one of its operations failed.
+var errBatchAborted = errors.New("batch aborted")
+
+// BatchItems applies the operations of the batch in a single transaction, each
+// one with the same checks as its single-item endpoint. Every operation runs
+// in its own savepoint so a best-effort batch only loses the failing ones.
+func (s *itemService) BatchItems(tenant domain.Tenant, batch *domain.ItemBatch) (*domain.ItemBatchReport, error) {
+	if err := batch.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	report := &domain.ItemBatchReport{
+		Mode:    batch.Mode,
+		Results: make([]domain.ItemBatchResult, len(batch.Operations)),
+	}
+
+	for i, op := range batch.Operations {
+		report.Results[i] = domain.ItemBatchResult{Index: i, Op: op.Op, ID: op.ID}
+	}
+
+	err := s.itemRepo.Transaction(func(repo ItemRepo) error {
+		for i, op := range batch.Operations {
+			result := &report.Results[i]
+
+			err := repo.Transaction(func(repo ItemRepo) error {
+				return s.withRepo(repo).applyBatchOperation(tenant, op, result)
+			})
+			if err != nil {
+				result.Error = toAppError(err)
+
+				if batch.Mode == domain.BatchAtomic {
+					return errBatchAborted
+				}
+
+				continue
+			}
+
+			result.Applied = true
+		}
+
+		return nil
+	})
+
+	// Nothing of an aborted batch is committed, so no item was created either.
+	if errors.Is(err, errBatchAborted) {
+		for i := range report.Results {
+			report.Results[i].Applied = false
+			if batch.Operations[i].Op == domain.BatchCreate {
+				report.Results[i].ID = nil
+			}
+		}
+
+		return report, nil
+	}
+
+	if err != nil {
+		return nil, clients.ErrDB(err)
+	}
+
+	report.Committed = true
+
+	return report, nil
+}
+
+// withRepo returns a copy of the service working on repo, typically one
+// bound to a transaction.
+func (s *itemService) withRepo(repo ItemRepo) *itemService {
+	copied := *s
+	copied.itemRepo = repo
+
+	return &copied
+}
+
+// applyBatchOperation applies one operation of a batch. Items are created in
+// the tenant; updates and deletes go by the access of the user to the item.
+func (s *itemService) applyBatchOperation(tenant domain.Tenant, op domain.ItemBatchOperation, result *domain.ItemBatchResult) error {
+	switch op.Op {
+	case domain.BatchCreate:
+		op.Create.UserID = tenant.UserID
+		op.Create.WorkspaceID = tenant.WorkspaceID
+		if err := s.CreateItem(op.Create); err != nil {
+			return err
+		}
+		result.ID = &op.Create.ID
+
+		return nil
+	case domain.BatchUpdate:
+		return s.UpdateItem(*op.ID, tenant.UserID, op.Update)
+	default:
+		return s.DeleteItem(*op.ID, tenant.UserID)
+	}
+}
+
+func toAppError(err error) *clients.AppError {
+	var appErr *clients.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return clients.ErrInternal(err)
+}
@@ -0,0 +1,128 @@
+package item_test
+
+import (
+	"errors"
+	"testing"
+	"todo-app/domain"
+	service "todo-app/item"
+	"todo-app/item/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// inTransaction makes the mock run transactional callbacks against itself
+// and return their error, like the real repository does.
+func inTransaction(repo *mocks.ItemRepo) {
+	repo.On("Transaction", mock.Anything).Return(func(fn func(repo service.ItemRepo) error) error {
+		return fn(repo)
+	})
+}
+
+func TestBatchItems(t *testing.T) {
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	ownID, foreignID := uuid.New(), uuid.New()
+	done := domain.Done
+
+	operations := func() []domain.ItemBatchOperation {
+		return []domain.ItemBatchOperation{
+			{Op: domain.BatchCreate, Create: &domain.ItemCreation{Title: "New"}},
+			{Op: domain.BatchUpdate, ID: &foreignID, Update: &domain.ItemUpdate{Status: &done}},
+			{Op: domain.BatchUpdate, ID: &ownID, Update: &domain.ItemUpdate{Status: &done}},
+		}
+	}
+
+	setup := func() (*mocks.ItemRepo, service.ItemRepo) {
+		mockItemRepo := new(mocks.ItemRepo)
+		inTransaction(mockItemRepo)
+
+		mockItemRepo.On("Save", mock.MatchedBy(func(item *domain.ItemCreation) bool {
+			return item.UserID == userID && item.Title == "New"
+		})).Return(nil).Once()
+		mockItemRepo.On("GetItem", map[string]any{"id": foreignID}).
+			Return(domain.Item{ID: foreignID, UserID: uuid.New(), Status: domain.Active}, nil).Once()
+
+		return mockItemRepo, mockItemRepo
+	}
+
+	t.Run("best effort", func(t *testing.T) {
+		mockItemRepo, repo := setup()
+		itemService := service.NewItemService(repo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", map[string]any{"id": ownID}).
+			Return(domain.Item{ID: ownID, UserID: userID, Status: domain.Active}, nil).Once()
+		mockItemRepo.On("GetSubtree", ownID).Return([]domain.Item{{ID: ownID}}, nil).Once()
+		mockItemRepo.On("Update", map[string]any{"id": ownID}, mock.Anything).Return(nil).Once()
+
+		report, err := itemService.BatchItems(domain.Tenant{UserID: userID}, &domain.ItemBatch{Mode: domain.BatchBestEffort, Operations: operations()})
+
+		require.NoError(t, err)
+		assert.True(t, report.Committed)
+		require.Len(t, report.Results, 3)
+
+		assert.True(t, report.Results[0].Applied)
+		assert.NotNil(t, report.Results[0].ID)
+
+		// The ownership check of UpdateItem applies to every operation
+		assert.False(t, report.Results[1].Applied)
+		require.NotNil(t, report.Results[1].Error)
+		assert.Equal(t, "ErrNoPermission", report.Results[1].Error.Key)
+
+		assert.True(t, report.Results[2].Applied)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("atomic", func(t *testing.T) {
+		mockItemRepo, repo := setup()
+		itemService := service.NewItemService(repo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		report, err := itemService.BatchItems(domain.Tenant{UserID: userID}, &domain.ItemBatch{Operations: operations()})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.BatchAtomic, report.Mode)
+		assert.False(t, report.Committed)
+
+		// Nothing is applied and the operations after the failure are skipped
+		for _, result := range report.Results {
+			assert.False(t, result.Applied)
+		}
+		assert.Nil(t, report.Results[0].ID) // the created item was rolled back
+		assert.NotNil(t, report.Results[1].Error)
+		assert.Nil(t, report.Results[2].Error)
+		mockItemRepo.AssertNotCalled(t, "GetItem", map[string]any{"id": ownID})
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("transaction error", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("Transaction", mock.Anything).Return(errors.New("connection lost")).Once()
+
+		_, err := itemService.BatchItems(domain.Tenant{UserID: userID}, &domain.ItemBatch{Operations: operations()})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid batches", func(t *testing.T) {
+		itemService := service.NewItemService(new(mocks.ItemRepo), new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		invalid := []domain.ItemBatch{
+			{},
+			{Mode: "sometimes", Operations: operations()},
+			{Operations: []domain.ItemBatchOperation{{Op: "archive", ID: &ownID}}},
+			{Operations: []domain.ItemBatchOperation{{Op: domain.BatchCreate}}},
+			{Operations: []domain.ItemBatchOperation{{Op: domain.BatchDelete}}},
+			{Operations: []domain.ItemBatchOperation{{Op: domain.BatchUpdate, ID: &ownID}}},
+			{Operations: make([]domain.ItemBatchOperation, domain.MaxBatchOperations+1)},
+		}
+
+		for _, batch := range invalid {
+			_, err := itemService.BatchItems(domain.Tenant{UserID: userID}, &batch)
+
+			assert.Error(t, err)
+		}
+	})
+}
@@ -3,10 +3,14 @@
 package mocks
 
 import (
+	time "time"
 	domain "todo-app/domain"
+	item "todo-app/item"
 	clients "todo-app/pkg/clients"
 
 	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
 )
 
 // ItemRepo is an autogenerated mock type for the ItemRepo type
@@ -14,6 +18,24 @@ type ItemRepo struct {
 	mock.Mock
 }
 
+// Assign provides a mock function with given fields: id, assigneeID, updatedAt
+func (_m *ItemRepo) Assign(id uuid.UUID, assigneeID *uuid.UUID, updatedAt time.Time) error {
+	ret := _m.Called(id, assigneeID, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Assign")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID, time.Time) error); ok {
+		r0 = rf(id, assigneeID, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: filter
 func (_m *ItemRepo) Delete(filter map[string]interface{}) error {
 	ret := _m.Called(filter)
@@ -62,6 +84,36 @@ func (_m *ItemRepo) GetAll(filter map[string]interface{}, paging *clients.Paging
 	return r0, r1
 }
 
+// GetEvents provides a mock function with given fields: filter, paging
+func (_m *ItemRepo) GetEvents(filter map[string]interface{}, paging *clients.Paging) ([]domain.ItemEvent, error) {
+	ret := _m.Called(filter, paging)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvents")
+	}
+
+	var r0 []domain.ItemEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *clients.Paging) ([]domain.ItemEvent, error)); ok {
+		return rf(filter, paging)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *clients.Paging) []domain.ItemEvent); ok {
+		r0 = rf(filter, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ItemEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *clients.Paging) error); ok {
+		r1 = rf(filter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetItem provides a mock function with given fields: filter
 func (_m *ItemRepo) GetItem(filter map[string]interface{}) (domain.Item, error) {
 	ret := _m.Called(filter)
@@ -90,6 +142,82 @@ func (_m *ItemRepo) GetItem(filter map[string]interface{}) (domain.Item, error)
 	return r0, r1
 }
 
+// GetSubtree provides a mock function with given fields: rootID
+func (_m *ItemRepo) GetSubtree(rootID uuid.UUID) ([]domain.Item, error) {
+	ret := _m.Called(rootID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubtree")
+	}
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]domain.Item, error)); ok {
+		return rf(rootID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []domain.Item); ok {
+		r0 = rf(rootID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(rootID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: before
+func (_m *ItemRepo) PurgeTrash(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: filter
+func (_m *ItemRepo) Restore(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: _a0
 func (_m *ItemRepo) Save(_a0 *domain.ItemCreation) error {
 	ret := _m.Called(_a0)
@@ -108,6 +236,90 @@ func (_m *ItemRepo) Save(_a0 *domain.ItemCreation) error {
 	return r0
 }
 
+// SaveEvent provides a mock function with given fields: event
+func (_m *ItemRepo) SaveEvent(event *domain.ItemEvent) error {
+	ret := _m.Called(event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.ItemEvent) error); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: filter, text, paging
+func (_m *ItemRepo) Search(filter map[string]interface{}, text string, paging *clients.Paging) ([]domain.ItemSearchResult, error) {
+	ret := _m.Called(filter, text, paging)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.ItemSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, string, *clients.Paging) ([]domain.ItemSearchResult, error)); ok {
+		return rf(filter, text, paging)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, string, *clients.Paging) []domain.ItemSearchResult); ok {
+		r0 = rf(filter, text, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ItemSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, string, *clients.Paging) error); ok {
+		r1 = rf(filter, text, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transaction provides a mock function with given fields: fn
+func (_m *ItemRepo) Transaction(fn func(repo item.ItemRepo) error) error {
+	ret := _m.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(repo item.ItemRepo) error) error); ok {
+		r0 = rf(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Trash provides a mock function with given fields: filter, deletedAt
+func (_m *ItemRepo) Trash(filter map[string]interface{}, deletedAt time.Time) error {
+	ret := _m.Called(filter, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, time.Time) error); ok {
+		r0 = rf(filter, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: filter, _a1
 func (_m *ItemRepo) Update(filter map[string]interface{}, _a1 *domain.ItemUpdate) error {
 	ret := _m.Called(filter, _a1)
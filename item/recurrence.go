@@ -0,0 +1,166 @@
+package item
+
+import (
+	"fmt"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// maxPreviewOccurrences bounds how many occurrences can be previewed at once.
+const maxPreviewOccurrences = 50
+
+// PreviewOccurrences returns the next count occurrences of a recurring item
+// after its current due date.
+func (s *itemService) PreviewOccurrences(id, userID uuid.UUID, count int) ([]time.Time, error) {
+	if count < 1 || count > maxPreviewOccurrences {
+		return nil, clients.ErrInvalidRequest(fmt.Errorf("count must be between 1 and %d", maxPreviewOccurrences))
+	}
+
+	item, err := s.getRecurringItem(id, userID, domain.AccessView)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := domain.ParseRRule(item.Recurrence)
+	if err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	occurrences := rule.Occurrences(*seriesStart(item), item.Location(), *item.DueAt, count)
+	if occurrences == nil {
+		occurrences = []time.Time{}
+	}
+
+	return occurrences, nil
+}
+
+// SkipOccurrence moves a recurring item on to its next occurrence without
+// completing it and returns the new due date.
+func (s *itemService) SkipOccurrence(id, userID uuid.UUID) (time.Time, error) {
+	item, err := s.getRecurringItem(id, userID, domain.AccessEdit)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next, err := nextOccurrence(item)
+	if err != nil {
+		return time.Time{}, clients.ErrInvalidRequest(err)
+	}
+
+	if next == nil {
+		return time.Time{}, domain.ErrRecurrenceEnded
+	}
+
+	update := &domain.ItemUpdate{DueAt: next.DueAt, RemindAt: next.RemindAt, UpdatedAt: time.Now()}
+	if err := s.itemRepo.Update(map[string]any{"id": id}, update); err != nil {
+		return time.Time{}, clients.ErrCannotUpdateEntity(item.TableName(), err)
+	}
+
+	return *next.DueAt, nil
+}
+
+// getRecurringItem returns a live recurring item the user has the needed
+// access to.
+func (s *itemService) getRecurringItem(id, userID uuid.UUID, need domain.Access) (domain.Item, error) {
+	item, err := s.itemRepo.GetItem(map[string]any{"id": id})
+	if err != nil {
+		return domain.Item{}, clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	if err := s.authorize(item, userID, need); err != nil {
+		return domain.Item{}, err
+	}
+
+	if item.Status == domain.Deleted {
+		return domain.Item{}, clients.ErrEntityDeleted(item.TableName(), nil)
+	}
+
+	if item.Recurrence == "" || item.DueAt == nil {
+		return domain.Item{}, domain.ErrItemNotRecurring
+	}
+
+	return item, nil
+}
+
+// nextOccurrence returns the item following a recurring item in its series,
+// or nil when the item does not recur or its series has ended. The reminder
+// keeps its distance to the due date; the tags and the assignee are carried
+// over.
+func nextOccurrence(item domain.Item) (*domain.ItemCreation, error) {
+	if item.Recurrence == "" || item.DueAt == nil {
+		return nil, nil
+	}
+
+	rule, err := domain.ParseRRule(item.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	start := seriesStart(item)
+	occurrences := rule.Occurrences(*start, item.Location(), *item.DueAt, 1)
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	dueAt := occurrences[0]
+	next := &domain.ItemCreation{
+		ID:              uuid.New(),
+		UserID:          item.UserID,
+		WorkspaceID:     item.WorkspaceID,
+		AssigneeID:      item.AssigneeID,
+		ParentID:        item.ParentID,
+		ListID:          item.ListID,
+		Title:           item.Title,
+		Description:     item.Description,
+		Status:          domain.Active,
+		DueAt:           &dueAt,
+		DueTimezone:     item.DueTimezone,
+		Recurrence:      item.Recurrence,
+		RecurrenceStart: start,
+	}
+
+	if item.RemindAt != nil {
+		remindAt := dueAt.Add(item.RemindAt.Sub(*item.DueAt))
+		next.RemindAt = &remindAt
+	}
+
+	for _, tag := range item.Tags {
+		next.TagIDs = append(next.TagIDs, tag.ID)
+	}
+
+	return next, nil
+}
+
+func seriesStart(item domain.Item) *time.Time {
+	if item.RecurrenceStart != nil {
+		return item.RecurrenceStart
+	}
+
+	return item.DueAt
+}
+
+// applyUpdate returns the item as it will be once the scheduling fields of
+// update are applied.
+func applyUpdate(item domain.Item, update *domain.ItemUpdate) domain.Item {
+	if update.Recurrence != nil {
+		item.Recurrence = *update.Recurrence
+		item.RecurrenceStart = update.RecurrenceStart
+	}
+
+	if update.DueAt != nil {
+		item.DueAt = update.DueAt
+	}
+
+	if update.DueTimezone != nil {
+		item.DueTimezone = *update.DueTimezone
+	}
+
+	if update.RemindAt != nil {
+		item.RemindAt = update.RemindAt
+	}
+
+	return item
+}
@@ -0,0 +1,188 @@
+package item_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	service "todo-app/item"
+	"todo-app/item/mocks"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateItem_CompleteRecurring(t *testing.T) {
+	mockID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	tagID := uuid.New()
+	dueAt := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	remindAt := dueAt.Add(-time.Hour)
+	done := domain.Done
+
+	recurring := domain.Item{
+		ID:         mockID,
+		UserID:     userID,
+		Title:      "Standup",
+		Status:     domain.Active,
+		DueAt:      &dueAt,
+		RemindAt:   &remindAt,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO,TH",
+		Tags:       []domain.Tag{{ID: tagID}},
+	}
+
+	t.Run("spawns the next occurrence", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+		inTransaction(mockItemRepo)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(recurring, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return([]domain.Item{recurring}, nil).Once()
+		mockItemRepo.On("Update", map[string]any{"id": mockID}, mock.MatchedBy(func(update *domain.ItemUpdate) bool {
+			return update.Recurrence != nil && *update.Recurrence == ""
+		})).Return(nil).Once()
+
+		next := dueAt.AddDate(0, 0, 3)
+		mockItemRepo.On("Save", mock.MatchedBy(func(item *domain.ItemCreation) bool {
+			return item.DueAt.Equal(next) && item.RemindAt.Equal(next.Add(-time.Hour)) &&
+				item.Status == domain.Active && item.Title == "Standup" &&
+				item.Recurrence == recurring.Recurrence && item.RecurrenceStart.Equal(dueAt) &&
+				assert.ObjectsAreEqual([]uuid.UUID{tagID}, item.TagIDs)
+		})).Return(nil).Once()
+
+		// Call the service method
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{Status: &done})
+
+		// Assertions
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("ends with the series", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		last := recurring
+		last.Recurrence = "FREQ=DAILY;COUNT=2"
+		last.RecurrenceStart = ptrToTime(dueAt.AddDate(0, 0, -1))
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(last, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return([]domain.Item{last}, nil).Once()
+		mockItemRepo.On("Update", map[string]any{"id": mockID}, mock.Anything).Return(nil).Once()
+
+		// Call the service method
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{Status: &done})
+
+		// Assertions
+		assert.NoError(t, err)
+		mockItemRepo.AssertNotCalled(t, "Save", mock.Anything)
+		mockItemRepo.AssertNotCalled(t, "Transaction", mock.Anything)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("recurrence needs a due date", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(domain.Item{ID: mockID, UserID: userID, Status: domain.Active}, nil).Once()
+
+		// Call the service method
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{Recurrence: ptrToString("FREQ=DAILY")})
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "recurrence needs a due_at")
+	})
+}
+
+func TestPreviewOccurrences(t *testing.T) {
+	mockItemRepo := new(mocks.ItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+	mockID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	start := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mockItemRepo.On("GetItem", map[string]any{"id": mockID}).Return(domain.Item{
+			ID: mockID, UserID: userID, Status: domain.Active, DueAt: &dueAt,
+			Recurrence: "FREQ=MONTHLY", RecurrenceStart: &start,
+		}, nil).Once()
+
+		// Call the service method
+		occurrences, err := itemService.PreviewOccurrences(mockID, userID, 2)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 5, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2026, 7, 31, 9, 0, 0, 0, time.UTC),
+		}, occurrences)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - not recurring", func(t *testing.T) {
+		mockItemRepo.On("GetItem", mock.Anything).Return(domain.Item{ID: mockID, UserID: userID, Status: domain.Active}, nil).Once()
+
+		// Call the service method
+		_, err := itemService.PreviewOccurrences(mockID, userID, 5)
+
+		// Assertions
+		assert.ErrorIs(t, err, domain.ErrItemNotRecurring)
+	})
+
+	t.Run("error - invalid count", func(t *testing.T) {
+		// Call the service method
+		_, err := itemService.PreviewOccurrences(mockID, userID, 51)
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Equal(t, "ErrInvalidRequest", err.(*clients.AppError).Key)
+	})
+}
+
+func TestSkipOccurrence(t *testing.T) {
+	mockID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	dueAt := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		next := dueAt.AddDate(0, 0, 2)
+		mockItemRepo.On("GetItem", mock.Anything).Return(domain.Item{
+			ID: mockID, UserID: userID, Status: domain.Active, DueAt: &dueAt, Recurrence: "FREQ=DAILY;INTERVAL=2",
+		}, nil).Once()
+		mockItemRepo.On("Update", map[string]any{"id": mockID}, mock.MatchedBy(func(update *domain.ItemUpdate) bool {
+			return update.DueAt.Equal(next) && update.Status == nil
+		})).Return(nil).Once()
+
+		// Call the service method
+		got, err := itemService.SkipOccurrence(mockID, userID)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, next, got)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - series ended", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(domain.Item{
+			ID: mockID, UserID: userID, Status: domain.Active, DueAt: &dueAt, Recurrence: "FREQ=DAILY;UNTIL=20260105",
+		}, nil).Once()
+
+		// Call the service method
+		_, err := itemService.SkipOccurrence(mockID, userID)
+
+		// Assertions
+		assert.ErrorIs(t, err, domain.ErrRecurrenceEnded)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
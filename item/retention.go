@@ -0,0 +1,34 @@
+package item
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TrashPurger removes items that have been in the trash for too long.
+type TrashPurger interface {
+	PurgeExpiredTrash(retention time.Duration) (int64, error)
+}
+
+// RunTrashRetention purges the trash older than retention once at start and
+// then every interval, until ctx is done.
+func RunTrashRetention(ctx context.Context, purger TrashPurger, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := purger.PurgeExpiredTrash(retention)
+		if err != nil {
+			log.Println("trash retention:", err)
+		} else if purged > 0 {
+			log.Printf("trash retention: purged %d items", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package item_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	service "todo-app/item"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type purgerFunc func(retention time.Duration) (int64, error)
+
+func (f purgerFunc) PurgeExpiredTrash(retention time.Duration) (int64, error) {
+	return f(retention)
+}
+
+// TestRunTrashRetention checks that the job purges with the configured
+// retention and stops once its context is done.
+func TestRunTrashRetention(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := make(chan time.Duration, 10)
+
+	purger := purgerFunc(func(retention time.Duration) (int64, error) {
+		calls <- retention
+		if len(calls) == 2 {
+			cancel()
+		}
+
+		return 0, nil
+	})
+
+	finished := make(chan struct{})
+	go func() {
+		service.RunTrashRetention(ctx, purger, 48*time.Hour, time.Millisecond)
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("retention job did not stop")
+	}
+
+	assert.Equal(t, 48*time.Hour, <-calls)
+}
@@ -1,38 +1,122 @@
 package item
 
 import (
+	"errors"
+	"fmt"
 	"time"
 	"todo-app/domain"
 	"todo-app/pkg/clients"
+	"todo-app/pkg/cursor"
 
 	"github.com/google/uuid"
 )
 
-//go:generate mockery --name ItemRepo
-type ItemRepo interface {
+// ItemStore is the storage of items, as implemented by the repositories.
+type ItemStore interface {
 	Save(item *domain.ItemCreation) error
 	GetAll(filter map[string]any, paging *clients.Paging) ([]domain.Item, error)
+	Search(filter map[string]any, text string, paging *clients.Paging) ([]domain.ItemSearchResult, error)
 	GetItem(filter map[string]any) (domain.Item, error)
+	GetSubtree(rootID uuid.UUID) ([]domain.Item, error)
 	Update(filter map[string]any, item *domain.ItemUpdate) error
+	Trash(filter map[string]any, deletedAt time.Time) error
+	Restore(filter map[string]any) error
 	Delete(filter map[string]any) error
+	PurgeTrash(before time.Time) (int64, error)
+	// Assign hands the item to the assignee, or takes it back when
+	// assigneeID is nil.
+	Assign(id uuid.UUID, assigneeID *uuid.UUID, updatedAt time.Time) error
+	SaveEvent(event *domain.ItemEvent) error
+	// GetEvents lists the matching item events, newest first.
+	GetEvents(filter map[string]any, paging *clients.Paging) ([]domain.ItemEvent, error)
+}
+
+//go:generate mockery --name ItemRepo
+type ItemRepo interface {
+	ItemStore
+	Transaction(fn func(repo ItemRepo) error) error
+}
+
+//go:generate mockery --name ListRepo
+type ListRepo interface {
+	GetList(filter map[string]any) (domain.List, error)
+}
+
+//go:generate mockery --name TagRepo
+type TagRepo interface {
+	GetTags(filter map[string]any) ([]domain.Tag, error)
+}
+
+// PermissionResolver tells what a user may do with an item: everything as
+// its owner, otherwise what the item was shared with them for. The items of a
+// workspace are governed by the role of the user in it.
+//
+//go:generate mockery --name PermissionResolver
+type PermissionResolver interface {
+	ItemAccess(item domain.Item, userID uuid.UUID) (domain.Access, error)
+	WorkspaceAccess(workspaceID, userID uuid.UUID) (domain.Access, error)
 }
 
 type itemService struct {
-	itemRepo ItemRepo
+	itemRepo    ItemRepo
+	listRepo    ListRepo
+	tagRepo     TagRepo
+	permissions PermissionResolver
+	cascadeDone bool
 }
 
-func NewItemService(repo ItemRepo) *itemService {
+// NewItemService creates the item service. When cascadeDone is true, marking
+// an item Done also completes its active subtasks; otherwise the update is
+// refused while any subtask is still active.
+func NewItemService(repo ItemRepo, listRepo ListRepo, tagRepo TagRepo, permissions PermissionResolver, cascadeDone bool) *itemService {
 	return &itemService{
-		itemRepo: repo,
+		itemRepo:    repo,
+		listRepo:    listRepo,
+		tagRepo:     tagRepo,
+		permissions: permissions,
+		cascadeDone: cascadeDone,
 	}
 }
 
+// CreateItem creates an item in the personal space of its user, or in its
+// workspace when it has one. A subtask has to be in the space of its parent.
 func (s *itemService) CreateItem(item *domain.ItemCreation) error {
 	if err := item.Validate(); err != nil {
 		return clients.ErrInvalidRequest(err)
 	}
 
+	tenant := domain.Tenant{UserID: item.UserID, WorkspaceID: item.WorkspaceID}
+	if err := s.authorizeTenant(tenant, domain.AccessEdit); err != nil {
+		return err
+	}
+
+	if item.ParentID != nil {
+		parent, err := s.itemRepo.GetItem(scoped(tenant, map[string]any{"id": *item.ParentID}))
+		if err != nil {
+			return clients.ErrCannotGetEntity(item.TableName(), err)
+		}
+
+		if parent.Status == domain.Deleted {
+			return domain.ErrParentTrashed
+		}
+	}
+
+	if item.ListID != nil {
+		if err := s.checkList(*item.ListID, tenant); err != nil {
+			return err
+		}
+	}
+
+	item.TagIDs = unique(item.TagIDs)
+	if err := s.checkTags(item.TagIDs, item.UserID); err != nil {
+		return err
+	}
+
 	item.ID = uuid.New()
+	item.Status = domain.Active
+	if item.Recurrence != "" {
+		item.RecurrenceStart = item.DueAt
+	}
 	if err := s.itemRepo.Save(item); err != nil {
 		return clients.ErrCannotCreateEntity(item.TableName(), err)
 	}
@@ -40,9 +124,39 @@ func (s *itemService) CreateItem(item *domain.ItemCreation) error {
 	return nil
 }
 
-func (s *itemService) GetAllItem(userID uuid.UUID, paging *clients.Paging) ([]domain.Item, error) {
-	filter := map[string]any{"user_id": userID}
+// upcomingWindow is how far ahead the upcoming view looks for due items.
+const upcomingWindow = 7 * 24 * time.Hour
+
+// GetAllItem lists the items of the tenant matching itemFilter. The filter is
+// validated and normalized in place so it can be echoed back to the client.
+func (s *itemService) GetAllItem(tenant domain.Tenant, itemFilter *domain.ItemFilter, paging *clients.Paging) ([]domain.Item, error) {
+	if itemFilter == nil {
+		itemFilter = &domain.ItemFilter{}
+	}
+
+	if err := itemFilter.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	if err := s.authorizeTenant(tenant, domain.AccessView); err != nil {
+		return nil, err
+	}
+
+	filter, err := itemConditions(tenant, itemFilter)
+	if err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	return s.listItems(filter, paging)
+}
+
+// listItems runs an item listing, reporting a forged or stale paging cursor
+// as an invalid request.
+func (s *itemService) listItems(filter map[string]any, paging *clients.Paging) ([]domain.Item, error) {
 	items, err := s.itemRepo.GetAll(filter, paging)
+	if errors.Is(err, cursor.ErrInvalidCursor) {
+		return nil, clients.ErrInvalidRequest(err)
+	}
 	if err != nil {
 		return nil, clients.ErrCannotListEntity(domain.Item{}.TableName(), err)
 	}
@@ -50,16 +164,226 @@ func (s *itemService) GetAllItem(userID uuid.UUID, paging *clients.Paging) ([]do
 	return items, nil
 }
 
+// itemConditions translates a validated item filter into the conditions
+// understood by ItemRepo.GetAll.
+func itemConditions(tenant domain.Tenant, itemFilter *domain.ItemFilter) (map[string]any, error) {
+	filter := scoped(tenant, map[string]any{
+		"sort": domain.ItemSort{Field: itemFilter.SortBy, Desc: itemFilter.SortDir == domain.SortDesc},
+	})
+
+	filter["status"] = domain.LiveStatuses
+	if len(itemFilter.Status) > 0 {
+		filter["status"] = unique(itemFilter.Status)
+	}
+
+	if itemFilter.Search != "" {
+		filter["search"] = itemFilter.Search
+	}
+
+	// Outside a workspace, items assigned to the user may be owned by whoever
+	// shared them, so only the space is kept from the tenant conditions.
+	if itemFilter.AssignedToMe {
+		if tenant.WorkspaceID == nil {
+			delete(filter, "user_id")
+		}
+		filter["assignee_id"] = tenant.UserID
+	}
+
+	ranges := map[string]*time.Time{
+		"created_from": itemFilter.CreatedFrom,
+		"created_to":   itemFilter.CreatedTo,
+		"updated_from": itemFilter.UpdatedFrom,
+		"updated_to":   itemFilter.UpdatedTo,
+		"due_before":   itemFilter.DueBefore,
+	}
+	for key, value := range ranges {
+		if value != nil {
+			filter[key] = *value
+		}
+	}
+
+	if itemFilter.Overdue {
+		now := time.Now()
+		if due, ok := filter["due_before"].(time.Time); !ok || now.Before(due) {
+			filter["due_before"] = now
+		}
+		filter["status"] = domain.Active
+	}
+
+	switch itemFilter.ListID {
+	case "":
+	case domain.InboxListID:
+		filter["list_id"] = nil
+	default:
+		listID, err := uuid.Parse(itemFilter.ListID)
+		if err != nil {
+			return nil, err
+		}
+		filter["list_id"] = listID
+	}
+
+	if len(itemFilter.Tags) > 0 {
+		if itemFilter.TagMode == domain.TagMatchAll {
+			filter["tags_all"] = unique(itemFilter.Tags)
+		} else {
+			filter["tags_any"] = unique(itemFilter.Tags)
+		}
+	}
+
+	return filter, nil
+}
+
+// SearchItems runs a full-text search over the titles and descriptions of the
+// tenant's items, most relevant first.
+func (s *itemService) SearchItems(tenant domain.Tenant, search *domain.ItemSearch, paging *clients.Paging) ([]domain.ItemSearchResult, error) {
+	if err := search.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	if err := s.authorizeTenant(tenant, domain.AccessView); err != nil {
+		return nil, err
+	}
+
+	filter := scoped(tenant, map[string]any{"status": domain.LiveStatuses})
+	results, err := s.itemRepo.Search(filter, search.Query, paging)
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Item{}.TableName(), err)
+	}
+
+	return results, nil
+}
+
+// GetSharedItems lists the live items other users shared with the user,
+// directly or through their list, newest first. Workspace items are never
+// shared this way.
+func (s *itemService) GetSharedItems(userID uuid.UUID, paging *clients.Paging) ([]domain.Item, error) {
+	filter := map[string]any{
+		"shared_with":  userID,
+		"workspace_id": nil,
+		"status":       domain.LiveStatuses,
+		"sort":         domain.ItemSort{Field: "created_at", Desc: true},
+	}
+
+	return s.listItems(filter, paging)
+}
+
+// GetDueItems lists the active items of a due view. The "today" view is
+// computed against the calendar day in loc.
+func (s *itemService) GetDueItems(tenant domain.Tenant, view string, loc *time.Location, paging *clients.Paging) ([]domain.Item, error) {
+	if err := s.authorizeTenant(tenant, domain.AccessView); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(loc)
+	filter := scoped(tenant, map[string]any{
+		"status": domain.Active,
+		"sort":   domain.ItemSort{Field: "due_at"},
+	})
+
+	switch view {
+	case domain.DueViewToday:
+		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+		filter["due_from"] = startOfDay
+		filter["due_before"] = startOfDay.AddDate(0, 0, 1)
+	case domain.DueViewUpcoming:
+		filter["due_from"] = now
+		filter["due_before"] = now.Add(upcomingWindow)
+	case domain.DueViewOverdue:
+		filter["due_before"] = now
+	default:
+		return nil, clients.ErrInvalidRequest(fmt.Errorf("unknown due view %q", view))
+	}
+
+	return s.listItems(filter, paging)
+}
+
+// GetSubtasks lists the subtasks of an item the user can view.
+func (s *itemService) GetSubtasks(id, userID uuid.UUID, paging *clients.Paging) ([]domain.Item, error) {
+	item, err := s.itemRepo.GetItem(map[string]any{"id": id})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	if err := s.authorize(item, userID, domain.AccessView); err != nil {
+		return nil, err
+	}
+
+	filter := map[string]any{
+		"parent_id": id,
+		"status":    domain.LiveStatuses,
+		"sort":      domain.ItemSort{Field: "created_at"},
+	}
+	return s.listItems(filter, paging)
+}
+
+// GetItemByID returns the item with its subtasks nested below it and the
+// completion percentage computed at every level.
 func (s *itemService) GetItemByID(id, userID uuid.UUID) (domain.Item, error) {
-	item, err := s.itemRepo.GetItem(map[string]any{"id": id, "user_id": userID})
+	item, err := s.itemRepo.GetItem(map[string]any{"id": id})
+	if err != nil {
+		return domain.Item{}, clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	if err := s.authorize(item, userID, domain.AccessView); err != nil {
+		return domain.Item{}, err
+	}
+
+	if item.Status == domain.Deleted {
+		return domain.Item{}, clients.ErrEntityDeleted(item.TableName(), nil)
+	}
+
+	subtree, err := s.itemRepo.GetSubtree(id)
 	if err != nil {
 		return domain.Item{}, clients.ErrCannotGetEntity(item.TableName(), err)
 	}
 
+	live := subtree[:0]
+	for _, subtask := range subtree {
+		if subtask.Status != domain.Deleted {
+			live = append(live, subtask)
+		}
+	}
+
+	item = buildTree(item, live)
+	item.ComputeProgress()
+
 	return item, nil
 }
 
+// buildTree nests the descendants returned by GetSubtree below root.
+func buildTree(root domain.Item, subtree []domain.Item) domain.Item {
+	children := make(map[uuid.UUID][]domain.Item)
+	for _, item := range subtree {
+		if item.ParentID != nil && item.ID != root.ID {
+			children[*item.ParentID] = append(children[*item.ParentID], item)
+		}
+	}
+
+	visited := map[uuid.UUID]bool{}
+
+	var attach func(item domain.Item) domain.Item
+	attach = func(item domain.Item) domain.Item {
+		visited[item.ID] = true
+		for _, child := range children[item.ID] {
+			if !visited[child.ID] {
+				item.Subtasks = append(item.Subtasks, attach(child))
+			}
+		}
+
+		return item
+	}
+
+	return attach(root)
+}
+
+// UpdateItem changes an item the user can edit, or the status of an item
+// assigned to the user. The list it is put in has to be in the space of the
+// item and its tags have to belong to its owner.
 func (s *itemService) UpdateItem(id, userID uuid.UUID, itemUpdate *domain.ItemUpdate) error {
+	if err := itemUpdate.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
 	itemUpdate.UpdatedAt = time.Now()
 
 	item, err := s.itemRepo.GetItem(map[string]any{"id": id})
@@ -67,11 +391,87 @@ func (s *itemService) UpdateItem(id, userID uuid.UUID, itemUpdate *domain.ItemUp
 		return clients.ErrCannotGetEntity(itemUpdate.TableName(), err)
 	}
 
-	if item.UserID != userID {
-		return clients.ErrNoPermission(err)
+	if err := s.authorizeUpdate(item, userID, itemUpdate); err != nil {
+		return err
+	}
+
+	if item.Status == domain.Deleted {
+		return clients.ErrEntityDeleted(item.TableName(), nil)
+	}
+
+	if itemUpdate.ListID != nil && *itemUpdate.ListID != uuid.Nil {
+		if err := s.checkList(*itemUpdate.ListID, item.Tenant()); err != nil {
+			return err
+		}
 	}
 
-	err = s.itemRepo.Update(map[string]any{"id": id}, itemUpdate)
+	itemUpdate.TagIDs = unique(itemUpdate.TagIDs)
+	if err := s.checkTags(itemUpdate.TagIDs, item.UserID); err != nil {
+		return err
+	}
+
+	if itemUpdate.Recurrence != nil && *itemUpdate.Recurrence != "" {
+		dueAt := item.DueAt
+		if itemUpdate.DueAt != nil {
+			dueAt = itemUpdate.DueAt
+		}
+
+		if dueAt == nil {
+			return clients.ErrInvalidRequest(errors.New("recurrence needs a due_at"))
+		}
+
+		itemUpdate.RecurrenceStart = dueAt
+	}
+
+	var (
+		subtaskIDs []uuid.UUID
+		next       *domain.ItemCreation
+	)
+	if itemUpdate.Status != nil && *itemUpdate.Status == domain.Done && item.Status != domain.Done {
+		if subtaskIDs, err = s.activeSubtasks(id); err != nil {
+			return err
+		}
+
+		if next, err = nextOccurrence(applyUpdate(item, itemUpdate)); err != nil {
+			return clients.ErrInvalidRequest(err)
+		}
+
+		// The recurrence moves on to the next occurrence, so completing the
+		// item again can not spawn a second one.
+		if next != nil {
+			stop := ""
+			itemUpdate.Recurrence = &stop
+		}
+	}
+
+	if len(subtaskIDs) == 0 && next == nil {
+		if err := s.itemRepo.Update(map[string]any{"id": id}, itemUpdate); err != nil {
+			return clients.ErrCannotUpdateEntity(itemUpdate.TableName(), err)
+		}
+
+		return nil
+	}
+
+	// Completed subtasks and the next occurrence only stick together with
+	// the update of the item itself.
+	err = s.itemRepo.Transaction(func(repo ItemRepo) error {
+		if len(subtaskIDs) > 0 {
+			done := domain.Done
+			if err := repo.Update(map[string]any{"id": subtaskIDs}, &domain.ItemUpdate{Status: &done, UpdatedAt: itemUpdate.UpdatedAt}); err != nil {
+				return err
+			}
+		}
+
+		if err := repo.Update(map[string]any{"id": id}, itemUpdate); err != nil {
+			return err
+		}
+
+		if next == nil {
+			return nil
+		}
+
+		return repo.Save(next)
+	})
 	if err != nil {
 		return clients.ErrCannotUpdateEntity(itemUpdate.TableName(), err)
 	}
@@ -79,11 +479,286 @@ func (s *itemService) UpdateItem(id, userID uuid.UUID, itemUpdate *domain.ItemUp
 	return nil
 }
 
+// authorize refuses the request unless the user has at least the needed
+// access to the item.
+func (s *itemService) authorize(item domain.Item, userID uuid.UUID, need domain.Access) error {
+	access, err := s.permissions.ItemAccess(item, userID)
+	if err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	if access < need {
+		return clients.ErrNoPermission(errors.New("no access to the item"))
+	}
+
+	return nil
+}
+
+// authorizeTenant refuses the request unless the user has at least the
+// needed access to the items of the tenant's workspace. Personal data is
+// confined to the user by the tenant conditions.
+func (s *itemService) authorizeTenant(tenant domain.Tenant, need domain.Access) error {
+	_, err := s.tenantAccess(tenant, need)
+
+	return err
+}
+
+// tenantAccess is authorizeTenant returning the access of the user, which is
+// full in their personal space.
+func (s *itemService) tenantAccess(tenant domain.Tenant, need domain.Access) (domain.Access, error) {
+	if tenant.WorkspaceID == nil {
+		return domain.AccessOwner, nil
+	}
+
+	access, err := s.permissions.WorkspaceAccess(*tenant.WorkspaceID, tenant.UserID)
+	if err != nil {
+		return domain.AccessNone, clients.ErrInternal(err)
+	}
+
+	if access == domain.AccessNone {
+		return domain.AccessNone, domain.ErrNotWorkspaceMember
+	}
+
+	if access < need {
+		return domain.AccessNone, clients.ErrNoPermission(errors.New("your role in the workspace does not allow this"))
+	}
+
+	return access, nil
+}
+
+// scoped adds the conditions confining a query to the tenant to conditions.
+func scoped(tenant domain.Tenant, conditions map[string]any) map[string]any {
+	for key, value := range tenant.Conditions() {
+		conditions[key] = value
+	}
+
+	return conditions
+}
+
+// checkList makes sure items can be put in the list: it has to be in the
+// space of the tenant and must not be archived.
+func (s *itemService) checkList(listID uuid.UUID, tenant domain.Tenant) error {
+	list, err := s.listRepo.GetList(scoped(tenant, map[string]any{"id": listID}))
+	if err != nil {
+		return clients.ErrCannotGetEntity(list.TableName(), err)
+	}
+
+	if list.Status == domain.Archived {
+		return domain.ErrListArchived
+	}
+
+	return nil
+}
+
+// checkTags makes sure every tag exists and belongs to the user. tagIDs must
+// not hold duplicates.
+func (s *itemService) checkTags(tagIDs []uuid.UUID, userID uuid.UUID) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	tags, err := s.tagRepo.GetTags(map[string]any{"id": tagIDs, "user_id": userID})
+	if err != nil {
+		return clients.ErrCannotGetEntity(domain.Tag{}.TableName(), err)
+	}
+
+	if len(tags) != len(tagIDs) {
+		return clients.ErrEntityNotFound(domain.Tag{}.TableName(), nil)
+	}
+
+	return nil
+}
+
+// unique drops repeated values while keeping their order. A nil slice stays
+// nil so that "not provided" can still be told apart from "empty".
+func unique[T comparable](values []T) []T {
+	if values == nil {
+		return nil
+	}
+
+	seen := map[T]bool{}
+	result := make([]T, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+// activeSubtasks returns the active descendants of an item about to be
+// completed, to be marked Done with it, or refuses when cascading is
+// disabled.
+func (s *itemService) activeSubtasks(id uuid.UUID) ([]uuid.UUID, error) {
+	subtree, err := s.itemRepo.GetSubtree(id)
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	var activeIDs []uuid.UUID
+	for _, subtask := range subtree {
+		if subtask.ID != id && subtask.Status == domain.Active {
+			activeIDs = append(activeIDs, subtask.ID)
+		}
+	}
+
+	if len(activeIDs) > 0 && !s.cascadeDone {
+		return nil, domain.ErrItemHasActiveSubtasks
+	}
+
+	return activeIDs, nil
+}
+
+// DeleteItem moves the item together with all of its subtasks to the trash.
+// Subtasks trashed earlier keep their own deletion time. Only the owner can
+// delete an item, as it goes to their trash.
 func (s *itemService) DeleteItem(id, userID uuid.UUID) error {
-	err := s.itemRepo.Delete(map[string]any{"id": id, "user_id": userID})
+	item, err := s.itemRepo.GetItem(map[string]any{"id": id})
+	if err != nil {
+		return clients.ErrCannotDeleteEntity(item.TableName(), err)
+	}
+
+	if err := s.authorize(item, userID, domain.AccessOwner); err != nil {
+		return err
+	}
+
+	if item.Status == domain.Deleted {
+		return clients.ErrEntityDeleted(item.TableName(), nil)
+	}
+
+	subtree, err := s.itemRepo.GetSubtree(id)
+	if err != nil {
+		return clients.ErrCannotDeleteEntity(item.TableName(), err)
+	}
+
+	ids := []uuid.UUID{id}
+	for _, subtask := range subtree {
+		if subtask.ID != id && subtask.Status != domain.Deleted {
+			ids = append(ids, subtask.ID)
+		}
+	}
+
+	if err := s.itemRepo.Trash(map[string]any{"id": ids}, time.Now()); err != nil {
+		return clients.ErrCannotDeleteEntity(item.TableName(), err)
+	}
+
+	return nil
+}
+
+// GetTrash lists the trashed items of the tenant, most recently deleted
+// first. Users only see the items they could have deleted: in a workspace,
+// members see the items they created and admins all of them.
+func (s *itemService) GetTrash(tenant domain.Tenant, paging *clients.Paging) ([]domain.Item, error) {
+	access, err := s.tenantAccess(tenant, domain.AccessEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := scoped(tenant, map[string]any{
+		"status": domain.Deleted,
+		"sort":   domain.ItemSort{Field: "deleted_at", Desc: true},
+	})
+
+	if access < domain.AccessOwner {
+		filter["user_id"] = tenant.UserID
+	}
+
+	return s.listItems(filter, paging)
+}
+
+// RestoreItem brings a trashed item back with the status it had, together
+// with the subtasks that were trashed along with it.
+func (s *itemService) RestoreItem(id, userID uuid.UUID) error {
+	item, err := s.getTrashedItem(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if item.ParentID != nil {
+		parent, err := s.itemRepo.GetItem(map[string]any{"id": *item.ParentID})
+		if err != nil {
+			return clients.ErrCannotGetEntity(item.TableName(), err)
+		}
+
+		if parent.Status == domain.Deleted {
+			return domain.ErrParentTrashed
+		}
+	}
+
+	subtree, err := s.itemRepo.GetSubtree(id)
 	if err != nil {
-		return clients.ErrCannotDeleteEntity(domain.Item{}.TableName(), err)
+		return clients.ErrCannotUpdateEntity(item.TableName(), err)
+	}
+
+	ids := []uuid.UUID{id}
+	for _, subtask := range subtree {
+		if subtask.ID != id && subtask.Status == domain.Deleted &&
+			subtask.DeletedAt != nil && item.DeletedAt != nil && subtask.DeletedAt.Equal(*item.DeletedAt) {
+			ids = append(ids, subtask.ID)
+		}
+	}
+
+	if err := s.itemRepo.Restore(map[string]any{"id": ids}); err != nil {
+		return clients.ErrCannotUpdateEntity(item.TableName(), err)
 	}
 
 	return nil
 }
+
+// PurgeItem permanently deletes a trashed item and all of its subtasks.
+func (s *itemService) PurgeItem(id, userID uuid.UUID) error {
+	item, err := s.getTrashedItem(id, userID)
+	if err != nil {
+		return err
+	}
+
+	subtree, err := s.itemRepo.GetSubtree(id)
+	if err != nil {
+		return clients.ErrCannotDeleteEntity(item.TableName(), err)
+	}
+
+	ids := []uuid.UUID{id}
+	for _, subtask := range subtree {
+		if subtask.ID != id {
+			ids = append(ids, subtask.ID)
+		}
+	}
+
+	err = s.itemRepo.Delete(map[string]any{"id": ids})
+	if err != nil {
+		return clients.ErrCannotDeleteEntity(item.TableName(), err)
+	}
+
+	return nil
+}
+
+// PurgeExpiredTrash permanently deletes the items that have been in the trash
+// for longer than retention and returns how many were removed.
+func (s *itemService) PurgeExpiredTrash(retention time.Duration) (int64, error) {
+	purged, err := s.itemRepo.PurgeTrash(time.Now().Add(-retention))
+	if err != nil {
+		return 0, clients.ErrCannotDeleteEntity(domain.Item{}.TableName(), err)
+	}
+
+	return purged, nil
+}
+
+// getTrashedItem returns a trashed item the user could have deleted.
+func (s *itemService) getTrashedItem(id, userID uuid.UUID) (domain.Item, error) {
+	item, err := s.itemRepo.GetItem(map[string]any{"id": id})
+	if err != nil {
+		return domain.Item{}, clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	if err := s.authorize(item, userID, domain.AccessOwner); err != nil {
+		return domain.Item{}, err
+	}
+
+	if item.Status != domain.Deleted {
+		return domain.Item{}, domain.ErrItemNotTrashed
+	}
+
+	return item, nil
+}
@@ -2,21 +2,39 @@ package item_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 	"todo-app/domain"
 	service "todo-app/item"
 	"todo-app/item/mocks"
 	"todo-app/pkg/clients"
+	"todo-app/pkg/cursor"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// ownerAccess resolves the owner of an item to owner access and everyone
+// else to none, as the permission resolver does for items nobody shared.
+func ownerAccess() *mocks.PermissionResolver {
+	permissions := new(mocks.PermissionResolver)
+	permissions.On("ItemAccess", mock.Anything, mock.Anything).Return(func(item domain.Item, userID uuid.UUID) (domain.Access, error) {
+		if item.UserID == userID {
+			return domain.AccessOwner, nil
+		}
+
+		return domain.AccessNone, nil
+	})
+
+	return permissions
+}
+
 func TestCreateItem(t *testing.T) {
 	// Create a mock item repository
 	mockItemRepo := new(mocks.ItemRepo)
-	itemService := service.NewItemService(mockItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
 
 	t.Run("success", func(t *testing.T) {
 		// Define valid item creation input
@@ -55,6 +73,76 @@ func TestCreateItem(t *testing.T) {
 		assert.Contains(t, err.Error(), "title can not be null")
 	})
 
+	t.Run("validation error - invalid due date", func(t *testing.T) {
+		// Define an item due in the past with an unknown timezone
+		past := time.Now().Add(-time.Hour)
+		item := &domain.ItemCreation{
+			Title:       "Late Item",
+			UserID:      uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			DueAt:       &past,
+			DueTimezone: "Mars/Olympus_Mons",
+		}
+
+		// Call the service method
+		err := itemService.CreateItem(item)
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "due_at can not be in the past")
+		assert.Contains(t, err.Error(), "due_timezone is not a valid IANA timezone")
+	})
+
+	t.Run("success - past due date allowed", func(t *testing.T) {
+		// Define an item explicitly allowed to be due in the past
+		past := time.Now().Add(-time.Hour)
+		item := &domain.ItemCreation{
+			Title:        "Backfilled Item",
+			UserID:       uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			DueAt:        &past,
+			DueTimezone:  "Asia/Ho_Chi_Minh",
+			AllowPastDue: true,
+		}
+
+		// Setup mock expectation
+		mockItemRepo.On("Save", mock.Anything).Return(nil).Once()
+
+		// Call the service method
+		err := itemService.CreateItem(item)
+
+		// Assertions
+		assert.NoError(t, err)
+
+		// Verify that all expectations were met
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - foreign tag", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		mockTagRepo := new(mocks.TagRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), mockTagRepo, ownerAccess(), false)
+
+		userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+		ownTag, foreignTag := uuid.New(), uuid.New()
+		item := &domain.ItemCreation{
+			Title:  "Tagged Item",
+			UserID: userID,
+			TagIDs: []uuid.UUID{ownTag, foreignTag, ownTag},
+		}
+
+		// Only one of the two distinct tags belongs to the user
+		mockTagRepo.On("GetTags", map[string]any{"id": []uuid.UUID{ownTag, foreignTag}, "user_id": userID}).
+			Return([]domain.Tag{{ID: ownTag, UserID: userID}}, nil).Once()
+
+		// Call the service method
+		err := itemService.CreateItem(item)
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Equal(t, "ErrtagsNotFound", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "Save", mock.Anything)
+		mockTagRepo.AssertExpectations(t)
+	})
+
 	t.Run("repository error", func(t *testing.T) {
 		// Define valid item input
 		item := &domain.ItemCreation{
@@ -98,7 +186,7 @@ func TestGetAllItem(t *testing.T) {
 
 	// Create a mock item repository
 	mockItemRepo := new(mocks.ItemRepo)
-	itemService := service.NewItemService(mockItemRepo) // Create the service with the mock repo
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false) // Create the service with the mock repo
 
 	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
 	paging := &clients.Paging{Limit: 2, Page: 1}
@@ -109,7 +197,7 @@ func TestGetAllItem(t *testing.T) {
 			Return(mockItems, nil).Once()
 
 		// Call the service method
-		result, err := itemService.GetAllItem(userID, paging)
+		result, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{}, paging)
 
 		// Assertions
 		assert.NoError(t, err)
@@ -128,7 +216,7 @@ func TestGetAllItem(t *testing.T) {
 			Return(nil, mockErr).Once()
 
 		// Call the service method
-		result, err := itemService.GetAllItem(userID, paging)
+		result, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{}, paging)
 
 		// Assertions
 		assert.Error(t, err)
@@ -137,12 +225,263 @@ func TestGetAllItem(t *testing.T) {
 		// Verify that all expectations were met
 		mockItemRepo.AssertExpectations(t)
 	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		// Simulate a forged cursor
+		mockItemRepo.On("GetAll", mock.Anything, mock.AnythingOfType("*clients.Paging")).
+			Return(nil, cursor.ErrInvalidCursor).Once()
+
+		// Call the service method
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{}, paging)
+
+		// Assertions
+		var appErr *clients.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "ErrInvalidRequest", appErr.Key)
+
+		// Verify that all expectations were met
+		mockItemRepo.AssertExpectations(t)
+	})
+}
+
+// newestFirst is the default order of the item listing.
+var newestFirst = domain.ItemSort{Field: "created_at", Desc: true}
+
+// lastDeletedFirst is the order of the trash.
+var lastDeletedFirst = domain.ItemSort{Field: "deleted_at", Desc: true}
+
+func TestGetAllItem_Filter(t *testing.T) {
+	// Create a mock item repository
+	mockItemRepo := new(mocks.ItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	paging := &clients.Paging{Limit: 2, Page: 1}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("status, search, ranges and sort", func(t *testing.T) {
+		mockItemRepo.On("GetAll", map[string]any{
+			"user_id":      userID,
+			"workspace_id": nil,
+			"status":       []domain.Status{domain.Active, domain.Done},
+			"search":       "report",
+			"created_from": from,
+			"created_to":   to,
+			"updated_from": from,
+			"sort":         domain.ItemSort{Field: "title"},
+		}, paging).Return([]domain.Item{}, nil).Once()
+
+		filter := &domain.ItemFilter{
+			Status:      []domain.Status{domain.Active, domain.Done, domain.Active},
+			Search:      "  report ",
+			CreatedFrom: &from,
+			CreatedTo:   &to,
+			UpdatedFrom: &from,
+			SortBy:      "title",
+			SortDir:     domain.SortAsc,
+		}
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, filter, paging)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "report", filter.Search)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("defaults are echoed", func(t *testing.T) {
+		mockItemRepo.On("GetAll", map[string]any{"user_id": userID, "workspace_id": nil, "status": domain.LiveStatuses, "sort": newestFirst}, paging).
+			Return([]domain.Item{}, nil).Once()
+
+		filter := &domain.ItemFilter{}
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, filter, paging)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "created_at", filter.SortBy)
+		assert.Equal(t, domain.SortDesc, filter.SortDir)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		invalid := []domain.ItemFilter{
+			{Status: []domain.Status{7}},
+			{CreatedFrom: &to, CreatedTo: &from},
+			{UpdatedFrom: &to, UpdatedTo: &from},
+			{SortBy: "password"},
+			{SortDir: "up"},
+			{Search: strings.Repeat("a", 101)},
+		}
+
+		for _, filter := range invalid {
+			_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &filter, paging)
+
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestSearchItems(t *testing.T) {
+	// Create a mock item repository
+	mockItemRepo := new(mocks.ItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	paging := &clients.Paging{Limit: 2, Page: 1}
+
+	t.Run("success", func(t *testing.T) {
+		// Expect the search to be scoped to the user
+		mockItemRepo.On("Search", map[string]any{"user_id": userID, "workspace_id": nil, "status": domain.LiveStatuses}, "invoice", paging).
+			Return([]domain.ItemSearchResult{{Item: domain.Item{Title: "Invoice"}, Snippet: "<mark>Invoice</mark>"}}, nil).Once()
+
+		// Call the service method
+		results, err := itemService.SearchItems(domain.Tenant{UserID: userID}, &domain.ItemSearch{Query: " invoice "}, paging)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+
+		// Verify that all expectations were met
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		for _, query := range []string{"", "   ", strings.Repeat("a", 101)} {
+			_, err := itemService.SearchItems(domain.Tenant{UserID: userID}, &domain.ItemSearch{Query: query}, paging)
+
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestGetAllItem_ListFilter(t *testing.T) {
+	// Create a mock item repository
+	mockItemRepo := new(mocks.ItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	listID := uuid.New()
+	paging := &clients.Paging{Limit: 2, Page: 1}
+
+	t.Run("list", func(t *testing.T) {
+		mockItemRepo.On("GetAll", map[string]any{"user_id": userID, "workspace_id": nil, "list_id": listID, "status": domain.LiveStatuses, "sort": newestFirst}, paging).
+			Return([]domain.Item{}, nil).Once()
+
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{ListID: listID.String()}, paging)
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("inbox", func(t *testing.T) {
+		mockItemRepo.On("GetAll", map[string]any{"user_id": userID, "workspace_id": nil, "list_id": nil, "status": domain.LiveStatuses, "sort": newestFirst}, paging).
+			Return([]domain.Item{}, nil).Once()
+
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{ListID: domain.InboxListID}, paging)
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("malformed list id", func(t *testing.T) {
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{ListID: "sprint-12"}, paging)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetAllItem_TagFilter(t *testing.T) {
+	// Create a mock item repository
+	mockItemRepo := new(mocks.ItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	paging := &clients.Paging{Limit: 2, Page: 1}
+
+	t.Run("any", func(t *testing.T) {
+		mockItemRepo.On("GetAll", map[string]any{"user_id": userID, "workspace_id": nil, "tags_any": []string{"a", "b"}, "status": domain.LiveStatuses, "sort": newestFirst}, paging).
+			Return([]domain.Item{}, nil).Once()
+
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{Tags: []string{"a", "b", "a"}}, paging)
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		mockItemRepo.On("GetAll", map[string]any{"user_id": userID, "workspace_id": nil, "tags_all": []string{"a", "b"}, "status": domain.LiveStatuses, "sort": newestFirst}, paging).
+			Return([]domain.Item{}, nil).Once()
+
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{Tags: []string{"a", "b"}, TagMode: domain.TagMatchAll}, paging)
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: userID}, &domain.ItemFilter{Tags: []string{"a"}, TagMode: "some"}, paging)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetDueItems(t *testing.T) {
+	// Create a mock item repository
+	mockItemRepo := new(mocks.ItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	paging := &clients.Paging{Limit: 2, Page: 1}
+	loc, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+
+	t.Run("today", func(t *testing.T) {
+		// Expect a filter covering the current day in the requested timezone
+		mockItemRepo.On("GetAll", mock.MatchedBy(func(filter map[string]any) bool {
+			from, _ := filter["due_from"].(time.Time)
+			before, _ := filter["due_before"].(time.Time)
+			return from.Location() == loc && from.Hour() == 0 && before.Sub(from) == 24*time.Hour &&
+				filter["status"] == domain.Active
+		}), paging).Return([]domain.Item{}, nil).Once()
+
+		// Call the service method
+		_, err := itemService.GetDueItems(domain.Tenant{UserID: userID}, domain.DueViewToday, loc, paging)
+
+		// Assertions
+		assert.NoError(t, err)
+
+		// Verify that all expectations were met
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("overdue", func(t *testing.T) {
+		// Expect a filter without a lower bound
+		mockItemRepo.On("GetAll", mock.MatchedBy(func(filter map[string]any) bool {
+			_, hasFrom := filter["due_from"]
+			_, hasBefore := filter["due_before"]
+			return !hasFrom && hasBefore
+		}), paging).Return([]domain.Item{}, nil).Once()
+
+		// Call the service method
+		_, err := itemService.GetDueItems(domain.Tenant{UserID: userID}, domain.DueViewOverdue, time.UTC, paging)
+
+		// Assertions
+		assert.NoError(t, err)
+
+		// Verify that all expectations were met
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		// Call the service method
+		_, err := itemService.GetDueItems(domain.Tenant{UserID: userID}, "someday", time.UTC, paging)
+
+		// Assertions
+		assert.Error(t, err)
+	})
 }
 
 func TestGetItemByID(t *testing.T) {
 	// Create a mock item repository
 	mockItemRepo := new(mocks.ItemRepo)
-	itemService := service.NewItemService(mockItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
 
 	// Define mock data
 	mockID := uuid.New()
@@ -152,19 +491,57 @@ func TestGetItemByID(t *testing.T) {
 		Title:       "Test Item",
 		Description: "Test Description",
 		UserID:      userID,
+		Status:      domain.Active,
 	}
 
 	t.Run("success", func(t *testing.T) {
 		// Setup mock expectation for a successful fetch
 		mockItemRepo.On("GetItem", mock.Anything).
 			Return(mockItem, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).
+			Return([]domain.Item{mockItem}, nil).Once()
 
 		// Call the service method
 		result, err := itemService.GetItemByID(mockID, userID)
 
 		// Assertions
 		assert.NoError(t, err)
-		assert.Equal(t, mockItem, result)
+		assert.Equal(t, mockItem.ID, result.ID)
+		assert.Equal(t, mockItem.Title, result.Title)
+		assert.Empty(t, result.Subtasks)
+
+		// Verify expectations
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - with subtasks", func(t *testing.T) {
+		// Define a parent with one done and one active subtask, the active
+		// one having two subtasks of its own
+		childID, grandchildID := uuid.New(), uuid.New()
+		parent := domain.Item{ID: mockID, UserID: userID, Status: domain.Active}
+		subtree := []domain.Item{
+			parent,
+			{ID: uuid.New(), ParentID: &mockID, UserID: userID, Status: domain.Done},
+			{ID: childID, ParentID: &mockID, UserID: userID, Status: domain.Active},
+			{ID: grandchildID, ParentID: &childID, UserID: userID, Status: domain.Done},
+			{ID: uuid.New(), ParentID: &childID, UserID: userID, Status: domain.Active},
+			{ID: uuid.New(), ParentID: &childID, UserID: userID, Status: domain.Deleted},
+		}
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(parent, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return(subtree, nil).Once()
+
+		// Call the service method
+		result, err := itemService.GetItemByID(mockID, userID)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Len(t, result.Subtasks, 2)
+		assert.Equal(t, 75.0, *result.Progress)
+		assert.Equal(t, 100.0, *result.Subtasks[0].Progress)
+		assert.Equal(t, 50.0, *result.Subtasks[1].Progress)
+		// Trashed subtasks are left out
+		assert.Len(t, result.Subtasks[1].Subtasks, 2)
 
 		// Verify expectations
 		mockItemRepo.AssertExpectations(t)
@@ -206,7 +583,7 @@ func TestGetItemByID(t *testing.T) {
 func TestUpdateItem(t *testing.T) {
 	// Create a mock item repository
 	mockItemRepo := new(mocks.ItemRepo)
-	itemService := service.NewItemService(mockItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
 
 	// Define mock data
 	mockID := uuid.New()
@@ -216,8 +593,11 @@ func TestUpdateItem(t *testing.T) {
 		Description: ptrToString("Updated Description"),
 	}
 
+	mockItem := domain.Item{ID: mockID, UserID: userID, Status: domain.Active}
+
 	t.Run("success", func(t *testing.T) {
 		// Setup mock expectation for successful update
+		mockItemRepo.On("GetItem", mock.Anything).Return(mockItem, nil).Once()
 		mockItemRepo.On("Update", mock.Anything, updateData).Return(nil).Once()
 
 		// Call the service method
@@ -232,6 +612,7 @@ func TestUpdateItem(t *testing.T) {
 
 	t.Run("error - repository error", func(t *testing.T) {
 		// Simulate repository error
+		mockItemRepo.On("GetItem", mock.Anything).Return(mockItem, nil).Once()
 		mockItemRepo.On("Update", mock.Anything, updateData).
 			Return(errors.New("cannot update entity")).Once()
 
@@ -245,20 +626,409 @@ func TestUpdateItem(t *testing.T) {
 		// Verify expectations
 		mockItemRepo.AssertExpectations(t)
 	})
+
+	t.Run("error - no permission", func(t *testing.T) {
+		// Simulate an item owned by another user
+		otherItem := domain.Item{ID: mockID, UserID: uuid.New()}
+		mockItemRepo.On("GetItem", mock.Anything).Return(otherItem, nil).Once()
+
+		// Call the service method
+		err := itemService.UpdateItem(mockID, userID, updateData)
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Contains(t, err.(*clients.AppError).Key, "ErrNoPermission")
+
+		// Verify expectations
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error - past due date", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+
+		// Call the service method
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{DueAt: &past})
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "due_at can not be in the past")
+	})
+}
+
+func TestUpdateItem_CompleteWithSubtasks(t *testing.T) {
+	mockID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	parent := domain.Item{ID: mockID, UserID: userID, Status: domain.Active}
+	activeChildID := uuid.New()
+	subtree := []domain.Item{
+		parent,
+		{ID: activeChildID, ParentID: &mockID, UserID: userID, Status: domain.Active},
+		{ID: uuid.New(), ParentID: &mockID, UserID: userID, Status: domain.Done},
+	}
+	done := domain.Done
+
+	t.Run("refused while subtasks are active", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(parent, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return(subtree, nil).Once()
+
+		// Call the service method
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{Status: &done})
+
+		// Assertions
+		assert.ErrorIs(t, err, domain.ErrItemHasActiveSubtasks)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("cascades to active subtasks", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), true)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(parent, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return(subtree, nil).Once()
+		inTransaction(mockItemRepo)
+		mockItemRepo.On("Update", map[string]any{"id": []uuid.UUID{activeChildID}}, mock.Anything).Return(nil).Once()
+		mockItemRepo.On("Update", map[string]any{"id": mockID}, mock.Anything).Return(nil).Once()
+
+		// Call the service method
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{Status: &done})
+
+		// Assertions
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("a failed update rolls back the cascade", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), true)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(parent, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return(subtree, nil).Once()
+		inTransaction(mockItemRepo)
+		mockItemRepo.On("Update", map[string]any{"id": []uuid.UUID{activeChildID}}, mock.Anything).Return(nil).Once()
+		mockItemRepo.On("Update", map[string]any{"id": mockID}, mock.Anything).Return(errors.New("connection lost")).Once()
+
+		// Call the service method
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{Status: &done})
+
+		// Assertions: both updates ran in the transaction, which failed as a whole
+		assert.Error(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateItem_MoveToList(t *testing.T) {
+	mockID := uuid.New()
+	listID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	mockItem := domain.Item{ID: mockID, UserID: userID, Status: domain.Active}
+
+	t.Run("success", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		mockListRepo := new(mocks.ListRepo)
+		itemService := service.NewItemService(mockItemRepo, mockListRepo, new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(mockItem, nil).Once()
+		mockListRepo.On("GetList", map[string]any{"id": listID, "user_id": userID, "workspace_id": nil}).
+			Return(domain.List{ID: listID, UserID: userID, Status: domain.Active}, nil).Once()
+		mockItemRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{ListID: &listID})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+		mockListRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - archived list", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		mockListRepo := new(mocks.ListRepo)
+		itemService := service.NewItemService(mockItemRepo, mockListRepo, new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(mockItem, nil).Once()
+		mockListRepo.On("GetList", mock.Anything).
+			Return(domain.List{ID: listID, UserID: userID, Status: domain.Archived}, nil).Once()
+
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{ListID: &listID})
+
+		assert.ErrorIs(t, err, domain.ErrListArchived)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - back to inbox", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		mockListRepo := new(mocks.ListRepo)
+		itemService := service.NewItemService(mockItemRepo, mockListRepo, new(mocks.TagRepo), ownerAccess(), false)
+		inbox := uuid.Nil
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(mockItem, nil).Once()
+		mockItemRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := itemService.UpdateItem(mockID, userID, &domain.ItemUpdate{ListID: &inbox})
+
+		assert.NoError(t, err)
+		mockListRepo.AssertNotCalled(t, "GetList", mock.Anything)
+	})
+}
+
+func TestItemPermissions(t *testing.T) {
+	ownerID, collaboratorID := uuid.New(), uuid.New()
+	itemID, listID := uuid.New(), uuid.New()
+	sharedItem := domain.Item{ID: itemID, UserID: ownerID, Status: domain.Active}
+
+	setup := func(access domain.Access) (*mocks.ItemRepo, *mocks.ListRepo, *mocks.PermissionResolver, interface {
+		GetItemByID(id, userID uuid.UUID) (domain.Item, error)
+		GetSubtasks(id, userID uuid.UUID, paging *clients.Paging) ([]domain.Item, error)
+		UpdateItem(id, userID uuid.UUID, item *domain.ItemUpdate) error
+		DeleteItem(id, userID uuid.UUID) error
+	}) {
+		mockItemRepo := new(mocks.ItemRepo)
+		mockListRepo := new(mocks.ListRepo)
+		permissions := new(mocks.PermissionResolver)
+		permissions.On("ItemAccess", sharedItem, collaboratorID).Return(access, nil)
+
+		return mockItemRepo, mockListRepo, permissions, service.NewItemService(mockItemRepo, mockListRepo, new(mocks.TagRepo), permissions, false)
+	}
+
+	t.Run("viewer can read", func(t *testing.T) {
+		mockItemRepo, _, permissions, itemService := setup(domain.AccessView)
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(sharedItem, nil)
+		mockItemRepo.On("GetSubtree", itemID).Return([]domain.Item{sharedItem}, nil).Once()
+		mockItemRepo.On("GetAll", map[string]any{"parent_id": itemID, "status": domain.LiveStatuses, "sort": domain.ItemSort{Field: "created_at"}}, mock.Anything).
+			Return([]domain.Item{}, nil).Once()
+
+		item, err := itemService.GetItemByID(itemID, collaboratorID)
+		assert.NoError(t, err)
+		assert.Equal(t, itemID, item.ID)
+
+		_, err = itemService.GetSubtasks(itemID, collaboratorID, &clients.Paging{})
+		assert.NoError(t, err)
+		permissions.AssertExpectations(t)
+	})
+
+	t.Run("viewer can not edit", func(t *testing.T) {
+		mockItemRepo, _, _, itemService := setup(domain.AccessView)
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(sharedItem, nil).Once()
+		title := "Changed"
+
+		err := itemService.UpdateItem(itemID, collaboratorID, &domain.ItemUpdate{Title: &title})
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("editor edits within the lists of the owner", func(t *testing.T) {
+		mockItemRepo, mockListRepo, _, itemService := setup(domain.AccessEdit)
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(sharedItem, nil).Once()
+		mockListRepo.On("GetList", map[string]any{"id": listID, "user_id": ownerID, "workspace_id": nil}).
+			Return(domain.List{ID: listID, UserID: ownerID, Status: domain.Active}, nil).Once()
+		mockItemRepo.On("Update", map[string]any{"id": itemID}, mock.Anything).Return(nil).Once()
+
+		err := itemService.UpdateItem(itemID, collaboratorID, &domain.ItemUpdate{ListID: &listID})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+		mockListRepo.AssertExpectations(t)
+	})
+
+	t.Run("editor can not delete", func(t *testing.T) {
+		mockItemRepo, _, _, itemService := setup(domain.AccessEdit)
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(sharedItem, nil).Once()
+
+		err := itemService.DeleteItem(itemID, collaboratorID)
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "Trash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("stranger can not read", func(t *testing.T) {
+		mockItemRepo, _, _, itemService := setup(domain.AccessNone)
+
+		mockItemRepo.On("GetItem", map[string]any{"id": itemID}).Return(sharedItem, nil).Once()
+
+		_, err := itemService.GetItemByID(itemID, collaboratorID)
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "GetSubtree", mock.Anything)
+	})
+}
+
+func TestWorkspaceItems(t *testing.T) {
+	workspaceID, otherWorkspaceID := uuid.New(), uuid.New()
+	adminID, memberID, viewerID, outsiderID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	teamItem := domain.Item{ID: uuid.New(), UserID: memberID, WorkspaceID: &workspaceID, Status: domain.Active}
+
+	// The roles in the workspace, as the workspace resolver reports them
+	roles := map[uuid.UUID]domain.Access{adminID: domain.AccessOwner, memberID: domain.AccessEdit, viewerID: domain.AccessView}
+	setup := func() (*mocks.ItemRepo, *mocks.PermissionResolver, interface {
+		CreateItem(item *domain.ItemCreation) error
+		GetAllItem(tenant domain.Tenant, itemFilter *domain.ItemFilter, paging *clients.Paging) ([]domain.Item, error)
+		GetItemByID(id, userID uuid.UUID) (domain.Item, error)
+		GetTrash(tenant domain.Tenant, paging *clients.Paging) ([]domain.Item, error)
+	}) {
+		mockItemRepo := new(mocks.ItemRepo)
+		permissions := new(mocks.PermissionResolver)
+		permissions.On("WorkspaceAccess", workspaceID, mock.Anything).Return(func(_, userID uuid.UUID) (domain.Access, error) {
+			return roles[userID], nil
+		})
+		permissions.On("WorkspaceAccess", otherWorkspaceID, mock.Anything).Return(domain.AccessNone, nil)
+		permissions.On("ItemAccess", teamItem, mock.Anything).Return(func(_ domain.Item, userID uuid.UUID) (domain.Access, error) {
+			return roles[userID], nil
+		})
+
+		return mockItemRepo, permissions, service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), permissions, false)
+	}
+
+	t.Run("members list the items of the workspace", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		mockItemRepo.On("GetAll", map[string]any{"workspace_id": workspaceID, "status": domain.LiveStatuses, "sort": newestFirst}, mock.Anything).
+			Return([]domain.Item{teamItem}, nil).Once()
+
+		items, err := itemService.GetAllItem(domain.Tenant{UserID: viewerID, WorkspaceID: &workspaceID}, nil, &clients.Paging{})
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("outsiders can not list", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: outsiderID, WorkspaceID: &workspaceID}, nil, &clients.Paging{})
+
+		assert.ErrorIs(t, err, domain.ErrNotWorkspaceMember)
+		mockItemRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("members of one workspace can not list another", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		_, err := itemService.GetAllItem(domain.Tenant{UserID: memberID, WorkspaceID: &otherWorkspaceID}, nil, &clients.Paging{})
+
+		assert.ErrorIs(t, err, domain.ErrNotWorkspaceMember)
+		mockItemRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("outsiders can not read an item", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		mockItemRepo.On("GetItem", map[string]any{"id": teamItem.ID}).Return(teamItem, nil).Once()
+
+		_, err := itemService.GetItemByID(teamItem.ID, outsiderID)
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "GetSubtree", mock.Anything)
+	})
+
+	t.Run("members create", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		mockItemRepo.On("Save", mock.MatchedBy(func(item *domain.ItemCreation) bool {
+			return *item.WorkspaceID == workspaceID
+		})).Return(nil).Once()
+
+		err := itemService.CreateItem(&domain.ItemCreation{Title: "Team task", UserID: memberID, WorkspaceID: &workspaceID})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("viewers can not create", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		err := itemService.CreateItem(&domain.ItemCreation{Title: "Team task", UserID: viewerID, WorkspaceID: &workspaceID})
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("outsiders can not create", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		err := itemService.CreateItem(&domain.ItemCreation{Title: "Team task", UserID: outsiderID, WorkspaceID: &workspaceID})
+
+		assert.ErrorIs(t, err, domain.ErrNotWorkspaceMember)
+		mockItemRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("admins see the whole trash", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		mockItemRepo.On("GetAll", map[string]any{"workspace_id": workspaceID, "status": domain.Deleted, "sort": lastDeletedFirst}, mock.Anything).
+			Return([]domain.Item{}, nil).Once()
+
+		_, err := itemService.GetTrash(domain.Tenant{UserID: adminID, WorkspaceID: &workspaceID}, &clients.Paging{})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("members see the items they trashed", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		mockItemRepo.On("GetAll", map[string]any{"workspace_id": workspaceID, "user_id": memberID, "status": domain.Deleted, "sort": lastDeletedFirst}, mock.Anything).
+			Return([]domain.Item{}, nil).Once()
+
+		_, err := itemService.GetTrash(domain.Tenant{UserID: memberID, WorkspaceID: &workspaceID}, &clients.Paging{})
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("viewers have no trash", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		_, err := itemService.GetTrash(domain.Tenant{UserID: viewerID, WorkspaceID: &workspaceID}, &clients.Paging{})
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockItemRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("subtasks stay within the workspace", func(t *testing.T) {
+		mockItemRepo, _, itemService := setup()
+
+		parentID := uuid.New()
+		mockItemRepo.On("GetItem", map[string]any{"id": parentID, "workspace_id": workspaceID}).
+			Return(domain.Item{}, clients.ErrRecordNotFound).Once()
+
+		err := itemService.CreateItem(&domain.ItemCreation{Title: "Team task", UserID: memberID, WorkspaceID: &workspaceID, ParentID: &parentID})
+
+		assert.Error(t, err)
+		mockItemRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
 }
 
 func TestDeleteItem(t *testing.T) {
 	// Create a mock item repository
 	mockItemRepo := new(mocks.ItemRepo)
-	itemService := service.NewItemService(mockItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
 
 	// Define mock data
 	mockID := uuid.New()
 	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	mockItem := domain.Item{ID: mockID, UserID: userID, Status: domain.Active}
 
 	t.Run("success", func(t *testing.T) {
-		// Setup mock expectation for successful deletion
-		mockItemRepo.On("Delete", mock.Anything).Return(nil).Once()
+		// Expect the item and its live subtask to be trashed, but not the
+		// subtask that already is in the trash
+		childID := uuid.New()
+		subtree := []domain.Item{
+			mockItem,
+			{ID: childID, ParentID: &mockID, Status: domain.Done},
+			{ID: uuid.New(), ParentID: &mockID, Status: domain.Deleted},
+		}
+		mockItemRepo.On("GetItem", map[string]any{"id": mockID}).Return(mockItem, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return(subtree, nil).Once()
+		mockItemRepo.On("Trash", map[string]any{"id": []uuid.UUID{mockID, childID}}, mock.AnythingOfType("time.Time")).
+			Return(nil).Once()
 
 		// Call the service method
 		err := itemService.DeleteItem(mockID, userID)
@@ -270,9 +1040,24 @@ func TestDeleteItem(t *testing.T) {
 		mockItemRepo.AssertExpectations(t)
 	})
 
+	t.Run("error - already trashed", func(t *testing.T) {
+		mockItemRepo.On("GetItem", mock.Anything).Return(domain.Item{ID: mockID, UserID: userID, Status: domain.Deleted}, nil).Once()
+
+		// Call the service method
+		err := itemService.DeleteItem(mockID, userID)
+
+		// Assertions
+		assert.Error(t, err)
+
+		// Verify expectations
+		mockItemRepo.AssertExpectations(t)
+	})
+
 	t.Run("error - repository error", func(t *testing.T) {
 		// Simulate a repository error
-		mockItemRepo.On("Delete", mock.Anything).
+		mockItemRepo.On("GetItem", mock.Anything).Return(mockItem, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return([]domain.Item{mockItem}, nil).Once()
+		mockItemRepo.On("Trash", mock.Anything, mock.Anything).
 			Return(errors.New("cannot delete entity")).Once()
 
 		// Call the service method
@@ -287,7 +1072,116 @@ func TestDeleteItem(t *testing.T) {
 	})
 }
 
+func TestRestoreItem(t *testing.T) {
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	parentID := uuid.New()
+	mockID := uuid.New()
+	deletedAt := time.Now().Add(-time.Hour)
+	mockItem := domain.Item{ID: mockID, ParentID: &parentID, UserID: userID, Status: domain.Deleted, DeletedAt: &deletedAt}
+
+	t.Run("success", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		// Only the subtask trashed together with the item comes back
+		earlier := deletedAt.Add(-time.Hour)
+		childID := uuid.New()
+		subtree := []domain.Item{
+			mockItem,
+			{ID: childID, ParentID: &mockID, Status: domain.Deleted, DeletedAt: &deletedAt},
+			{ID: uuid.New(), ParentID: &mockID, Status: domain.Deleted, DeletedAt: &earlier},
+		}
+		mockItemRepo.On("GetItem", map[string]any{"id": mockID}).Return(mockItem, nil).Once()
+		mockItemRepo.On("GetItem", map[string]any{"id": parentID}).Return(domain.Item{ID: parentID, Status: domain.Active}, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return(subtree, nil).Once()
+		mockItemRepo.On("Restore", map[string]any{"id": []uuid.UUID{mockID, childID}}).Return(nil).Once()
+
+		err := itemService.RestoreItem(mockID, userID)
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - parent trashed", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", map[string]any{"id": mockID}).Return(mockItem, nil).Once()
+		mockItemRepo.On("GetItem", map[string]any{"id": parentID}).Return(domain.Item{ID: parentID, Status: domain.Deleted}, nil).Once()
+
+		err := itemService.RestoreItem(mockID, userID)
+
+		assert.Equal(t, domain.ErrParentTrashed, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - not trashed", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(domain.Item{ID: mockID, UserID: userID, Status: domain.Done}, nil).Once()
+
+		err := itemService.RestoreItem(mockID, userID)
+
+		assert.Equal(t, domain.ErrItemNotTrashed, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+}
+
+func TestPurgeItem(t *testing.T) {
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	mockID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		childID := uuid.New()
+		mockItemRepo.On("GetItem", mock.Anything).Return(domain.Item{ID: mockID, UserID: userID, Status: domain.Deleted}, nil).Once()
+		mockItemRepo.On("GetSubtree", mockID).Return([]domain.Item{{ID: mockID}, {ID: childID, ParentID: &mockID}}, nil).Once()
+		mockItemRepo.On("Delete", map[string]any{"id": []uuid.UUID{mockID, childID}}).Return(nil).Once()
+
+		err := itemService.PurgeItem(mockID, userID)
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - not trashed", func(t *testing.T) {
+		mockItemRepo := new(mocks.ItemRepo)
+		itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+		mockItemRepo.On("GetItem", mock.Anything).Return(domain.Item{ID: mockID, UserID: userID, Status: domain.Active}, nil).Once()
+
+		err := itemService.PurgeItem(mockID, userID)
+
+		assert.Equal(t, domain.ErrItemNotTrashed, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+}
+
+func TestPurgeExpiredTrash(t *testing.T) {
+	mockItemRepo := new(mocks.ItemRepo)
+	itemService := service.NewItemService(mockItemRepo, new(mocks.ListRepo), new(mocks.TagRepo), ownerAccess(), false)
+
+	// Expect the cutoff to be the retention before now
+	mockItemRepo.On("PurgeTrash", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 24*time.Hour && time.Since(before) < 25*time.Hour
+	})).Return(int64(3), nil).Once()
+
+	purged, err := itemService.PurgeExpiredTrash(24 * time.Hour)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, purged)
+	mockItemRepo.AssertExpectations(t)
+}
+
 // Helper function to return a pointer to a string
 func ptrToString(s string) *string {
 	return &s
 }
+
+// Helper function to return a pointer to a time
+func ptrToTime(t time.Time) *time.Time {
+	return &t
+}
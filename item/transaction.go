@@ -0,0 +1,24 @@
+package item
+
+// TransactionalStore is an ItemStore whose transactions hand out stores of
+// its own type S, so that repositories need not depend on this package.
+type TransactionalStore[S any] interface {
+	ItemStore
+	Transaction(fn func(store S) error) error
+}
+
+// NewTransactionalRepo adapts a transactional store to ItemRepo.
+func NewTransactionalRepo[S TransactionalStore[S]](store S) ItemRepo {
+	return transactionalRepo[S]{ItemStore: store, store: store}
+}
+
+type transactionalRepo[S TransactionalStore[S]] struct {
+	ItemStore
+	store S
+}
+
+func (r transactionalRepo[S]) Transaction(fn func(repo ItemRepo) error) error {
+	return r.store.Transaction(func(store S) error {
+		return fn(NewTransactionalRepo(store))
+	})
+}
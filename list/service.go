@@ -0,0 +1,173 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name ListRepo
+type ListRepo interface {
+	Save(list *domain.ListCreation) error
+	GetAll(filter map[string]any, paging *clients.Paging) ([]domain.List, error)
+	GetList(filter map[string]any) (domain.List, error)
+	Update(filter map[string]any, list *domain.ListUpdate) error
+	Archive(id uuid.UUID) error
+	DeleteToInbox(id uuid.UUID) error
+}
+
+// PermissionResolver tells what a user may do with the data of a workspace.
+//
+//go:generate mockery --name PermissionResolver
+type PermissionResolver interface {
+	WorkspaceAccess(workspaceID, userID uuid.UUID) (domain.Access, error)
+}
+
+type listService struct {
+	listRepo    ListRepo
+	permissions PermissionResolver
+}
+
+// NewListService creates the list service. Members of a workspace can view
+// its lists, members with edit access can create and rename them and only
+// those who can delete items can delete them.
+func NewListService(repo ListRepo, permissions PermissionResolver) *listService {
+	return &listService{
+		listRepo:    repo,
+		permissions: permissions,
+	}
+}
+
+// CreateList creates a list in the personal space of its user, or in its
+// workspace when it has one.
+func (s *listService) CreateList(list *domain.ListCreation) error {
+	if err := list.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	if err := s.authorize(domain.Tenant{UserID: list.UserID, WorkspaceID: list.WorkspaceID}, domain.AccessEdit); err != nil {
+		return err
+	}
+
+	list.ID = uuid.New()
+	list.Status = domain.Active
+	if err := s.listRepo.Save(list); err != nil {
+		return clients.ErrCannotCreateEntity(list.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *listService) GetAllList(tenant domain.Tenant, paging *clients.Paging) ([]domain.List, error) {
+	if err := s.authorize(tenant, domain.AccessView); err != nil {
+		return nil, err
+	}
+
+	lists, err := s.listRepo.GetAll(tenant.Conditions(), paging)
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.List{}.TableName(), err)
+	}
+
+	return lists, nil
+}
+
+func (s *listService) GetListByID(id uuid.UUID, tenant domain.Tenant) (domain.List, error) {
+	if err := s.authorize(tenant, domain.AccessView); err != nil {
+		return domain.List{}, err
+	}
+
+	list, err := s.listRepo.GetList(scoped(tenant, map[string]any{"id": id}))
+	if err != nil {
+		return domain.List{}, clients.ErrCannotGetEntity(list.TableName(), err)
+	}
+
+	return list, nil
+}
+
+func (s *listService) UpdateList(id uuid.UUID, tenant domain.Tenant, listUpdate *domain.ListUpdate) error {
+	if err := listUpdate.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	if err := s.authorize(tenant, domain.AccessEdit); err != nil {
+		return err
+	}
+
+	listUpdate.UpdatedAt = time.Now()
+
+	if _, err := s.listRepo.GetList(scoped(tenant, map[string]any{"id": id})); err != nil {
+		return clients.ErrCannotGetEntity(listUpdate.TableName(), err)
+	}
+
+	if err := s.listRepo.Update(map[string]any{"id": id}, listUpdate); err != nil {
+		return clients.ErrCannotUpdateEntity(listUpdate.TableName(), err)
+	}
+
+	return nil
+}
+
+// DeleteList removes a list according to mode: ListDeleteInbox deletes it and
+// moves its items to the inbox, ListDeleteArchive archives it with its items.
+func (s *listService) DeleteList(id uuid.UUID, tenant domain.Tenant, mode string) error {
+	if mode != domain.ListDeleteInbox && mode != domain.ListDeleteArchive {
+		return clients.ErrInvalidRequest(fmt.Errorf("unknown delete mode %q", mode))
+	}
+
+	if err := s.authorize(tenant, domain.AccessOwner); err != nil {
+		return err
+	}
+
+	if _, err := s.listRepo.GetList(scoped(tenant, map[string]any{"id": id})); err != nil {
+		return clients.ErrCannotGetEntity(domain.List{}.TableName(), err)
+	}
+
+	var err error
+	if mode == domain.ListDeleteArchive {
+		err = s.listRepo.Archive(id)
+	} else {
+		err = s.listRepo.DeleteToInbox(id)
+	}
+
+	if err != nil {
+		return clients.ErrCannotDeleteEntity(domain.List{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// authorize refuses the request unless the user has at least the needed
+// access to the tenant's workspace. Personal lists are confined to the user by
+// the tenant conditions.
+func (s *listService) authorize(tenant domain.Tenant, need domain.Access) error {
+	if tenant.WorkspaceID == nil {
+		return nil
+	}
+
+	access, err := s.permissions.WorkspaceAccess(*tenant.WorkspaceID, tenant.UserID)
+	if err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	if access == domain.AccessNone {
+		return domain.ErrNotWorkspaceMember
+	}
+
+	if access < need {
+		return clients.ErrNoPermission(errors.New("your role in the workspace does not allow this"))
+	}
+
+	return nil
+}
+
+// scoped adds the conditions confining a query to the tenant to conditions.
+func scoped(tenant domain.Tenant, conditions map[string]any) map[string]any {
+	for key, value := range tenant.Conditions() {
+		conditions[key] = value
+	}
+
+	return conditions
+}
@@ -0,0 +1,237 @@
+package list_test
+
+import (
+	"errors"
+	"testing"
+	"todo-app/domain"
+	service "todo-app/list"
+	"todo-app/list/mocks"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateList(t *testing.T) {
+	// Create a mock list repository
+	mockListRepo := new(mocks.ListRepo)
+	listService := service.NewListService(mockListRepo, new(mocks.PermissionResolver))
+
+	t.Run("success", func(t *testing.T) {
+		list := &domain.ListCreation{
+			Name:   "Backlog",
+			UserID: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+		}
+
+		// Setup mock expectation
+		mockListRepo.On("Save", mock.Anything).Return(nil).Once()
+
+		// Call the service method
+		err := listService.CreateList(list)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, list.ID)
+		assert.Equal(t, domain.Active, list.Status)
+
+		// Verify that all expectations were met
+		mockListRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		list := &domain.ListCreation{Name: "  "}
+
+		// Call the service method
+		err := listService.CreateList(list)
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "name can not be null")
+	})
+}
+
+func TestGetAllList(t *testing.T) {
+	// Create a mock list repository
+	mockListRepo := new(mocks.ListRepo)
+	listService := service.NewListService(mockListRepo, new(mocks.PermissionResolver))
+
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	paging := &clients.Paging{Limit: 2, Page: 1}
+
+	t.Run("success", func(t *testing.T) {
+		// Setup mock expectation scoped to the user
+		mockListRepo.On("GetAll", map[string]any{"user_id": userID, "workspace_id": nil}, paging).
+			Return([]domain.List{{Name: "Backlog"}, {Name: "Sprint 12"}}, nil).Once()
+
+		// Call the service method
+		result, err := listService.GetAllList(domain.Tenant{UserID: userID}, paging)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+
+		// Verify that all expectations were met
+		mockListRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// Simulate a repository error
+		mockListRepo.On("GetAll", mock.Anything, paging).Return(nil, errors.New("repository error")).Once()
+
+		// Call the service method
+		result, err := listService.GetAllList(domain.Tenant{UserID: userID}, paging)
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Nil(t, result)
+
+		// Verify that all expectations were met
+		mockListRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateList(t *testing.T) {
+	// Create a mock list repository
+	mockListRepo := new(mocks.ListRepo)
+	listService := service.NewListService(mockListRepo, new(mocks.PermissionResolver))
+
+	listID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	name := "Sprint 13"
+
+	t.Run("success", func(t *testing.T) {
+		mockListRepo.On("GetList", map[string]any{"id": listID, "user_id": userID, "workspace_id": nil}).
+			Return(domain.List{ID: listID, UserID: userID}, nil).Once()
+		mockListRepo.On("Update", map[string]any{"id": listID}, mock.Anything).Return(nil).Once()
+
+		// Call the service method
+		err := listService.UpdateList(listID, domain.Tenant{UserID: userID}, &domain.ListUpdate{Name: &name})
+
+		// Assertions
+		assert.NoError(t, err)
+
+		// Verify that all expectations were met
+		mockListRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		mockListRepo.On("GetList", mock.Anything).Return(domain.List{}, clients.ErrRecordNotFound).Once()
+
+		// Call the service method
+		err := listService.UpdateList(listID, domain.Tenant{UserID: userID}, &domain.ListUpdate{Name: &name})
+
+		// Assertions
+		assert.Error(t, err)
+
+		// Verify that all expectations were met
+		mockListRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteList(t *testing.T) {
+	listID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	t.Run("move items to inbox", func(t *testing.T) {
+		mockListRepo := new(mocks.ListRepo)
+		listService := service.NewListService(mockListRepo, new(mocks.PermissionResolver))
+
+		mockListRepo.On("GetList", mock.Anything).Return(domain.List{ID: listID, UserID: userID}, nil).Once()
+		mockListRepo.On("DeleteToInbox", listID).Return(nil).Once()
+
+		// Call the service method
+		err := listService.DeleteList(listID, domain.Tenant{UserID: userID}, domain.ListDeleteInbox)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockListRepo.AssertExpectations(t)
+	})
+
+	t.Run("archive with items", func(t *testing.T) {
+		mockListRepo := new(mocks.ListRepo)
+		listService := service.NewListService(mockListRepo, new(mocks.PermissionResolver))
+
+		mockListRepo.On("GetList", mock.Anything).Return(domain.List{ID: listID, UserID: userID}, nil).Once()
+		mockListRepo.On("Archive", listID).Return(nil).Once()
+
+		// Call the service method
+		err := listService.DeleteList(listID, domain.Tenant{UserID: userID}, domain.ListDeleteArchive)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockListRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		mockListRepo := new(mocks.ListRepo)
+		listService := service.NewListService(mockListRepo, new(mocks.PermissionResolver))
+
+		// Call the service method
+		err := listService.DeleteList(listID, domain.Tenant{UserID: userID}, "shred")
+
+		// Assertions
+		assert.Error(t, err)
+		mockListRepo.AssertNotCalled(t, "GetList", mock.Anything)
+	})
+}
+
+func TestWorkspaceLists(t *testing.T) {
+	workspaceID, userID := uuid.New(), uuid.New()
+	tenant := domain.Tenant{UserID: userID, WorkspaceID: &workspaceID}
+	paging := &clients.Paging{Limit: 10, Page: 1}
+
+	t.Run("members see the lists of the workspace", func(t *testing.T) {
+		mockListRepo, permissions := new(mocks.ListRepo), new(mocks.PermissionResolver)
+		listService := service.NewListService(mockListRepo, permissions)
+
+		permissions.On("WorkspaceAccess", workspaceID, userID).Return(domain.AccessView, nil).Once()
+		mockListRepo.On("GetAll", map[string]any{"workspace_id": workspaceID}, paging).Return([]domain.List{{Name: "Roadmap"}}, nil).Once()
+
+		lists, err := listService.GetAllList(tenant, paging)
+
+		assert.NoError(t, err)
+		assert.Len(t, lists, 1)
+		mockListRepo.AssertExpectations(t)
+	})
+
+	t.Run("outsiders are refused", func(t *testing.T) {
+		mockListRepo, permissions := new(mocks.ListRepo), new(mocks.PermissionResolver)
+		listService := service.NewListService(mockListRepo, permissions)
+
+		permissions.On("WorkspaceAccess", workspaceID, userID).Return(domain.AccessNone, nil)
+
+		_, err := listService.GetAllList(tenant, paging)
+		assert.ErrorIs(t, err, domain.ErrNotWorkspaceMember)
+
+		_, err = listService.GetListByID(uuid.New(), tenant)
+		assert.ErrorIs(t, err, domain.ErrNotWorkspaceMember)
+
+		mockListRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+		mockListRepo.AssertNotCalled(t, "GetList", mock.Anything)
+	})
+
+	t.Run("viewers can not create", func(t *testing.T) {
+		mockListRepo, permissions := new(mocks.ListRepo), new(mocks.PermissionResolver)
+		listService := service.NewListService(mockListRepo, permissions)
+
+		permissions.On("WorkspaceAccess", workspaceID, userID).Return(domain.AccessView, nil).Once()
+
+		err := listService.CreateList(&domain.ListCreation{Name: "Roadmap", UserID: userID, WorkspaceID: &workspaceID})
+
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		mockListRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("members can not delete", func(t *testing.T) {
+		mockListRepo, permissions := new(mocks.ListRepo), new(mocks.PermissionResolver)
+		listService := service.NewListService(mockListRepo, permissions)
+
+		permissions.On("WorkspaceAccess", workspaceID, userID).Return(domain.AccessEdit, nil).Once()
+
+		err := listService.DeleteList(uuid.New(), tenant, domain.ListDeleteInbox)
+
+		assert.Error(t, err)
+		mockListRepo.AssertNotCalled(t, "DeleteToInbox", mock.Anything)
+	})
+}
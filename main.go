@@ -1,28 +1,48 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/ulule/limiter/v3"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"todo-app/admin"
+	"todo-app/attachment"
+	"todo-app/comment"
 	"todo-app/docs"
+	"todo-app/domain"
 	restApi "todo-app/internal/api/http/gin"
 	"todo-app/internal/api/http/gin/middleware"
 	pgRepo "todo-app/internal/repository/postgres"
 	"todo-app/item"
+	"todo-app/list"
+	"todo-app/pkg/blob"
+	"todo-app/pkg/cursor"
+	"todo-app/pkg/mailer"
 	"todo-app/pkg/memcache"
+	"todo-app/pkg/oidc"
+	"todo-app/pkg/tokenprovider"
 	"todo-app/pkg/tokenprovider/jwt"
 	"todo-app/pkg/util"
+	"todo-app/share"
+	"todo-app/sso"
+	"todo-app/tag"
 	"todo-app/user"
+	"todo-app/workspace"
 )
 
 type Status int
@@ -39,23 +59,95 @@ func main() {
 		log.Fatalln(err)
 	}
 
+	if err := pgRepo.Migrate(db); err != nil {
+		log.Fatalln(err)
+	}
+
 	r := gin.Default()
 	r.Use(middleware.Recover())
 
 	apiVersion := r.Group("v1")
 	docs.SwaggerInfo.BasePath = "/v1"
 
-	itemRepo := pgRepo.NewItemRepo(db)
-	itemService := item.NewItemService(itemRepo)
+	itemRepo := item.NewTransactionalRepo(pgRepo.NewItemRepo(db, cursor.NewSigner(os.Getenv("SECRET_KEY"))))
+	shareRepo := pgRepo.NewShareRepo(db)
+	workspaceRepo := pgRepo.NewWorkspaceRepo(db)
+	permissions := workspace.NewPermissionResolver(workspaceRepo, share.NewPermissionResolver(shareRepo, itemRepo))
+
+	listRepo := pgRepo.NewListRepo(db)
+	listService := list.NewListService(listRepo, permissions)
+
+	tagRepo := pgRepo.NewTagRepo(db)
+	tagService := tag.NewTagService(tagRepo)
+
+	itemService := item.NewItemService(itemRepo, listRepo, tagRepo, permissions, os.Getenv("SUBTASK_DONE_POLICY") == "cascade")
+
+	trashRetention := 720 * time.Hour
+	if value := os.Getenv("TRASH_RETENTION"); value != "" {
+		if trashRetention, err = time.ParseDuration(value); err != nil {
+			log.Fatalln("TRASH_RETENTION:", err)
+		}
+	}
+	go item.RunTrashRetention(context.Background(), itemService, trashRetention, time.Hour)
 
 	userRepo := pgRepo.NewUserRepo(db)
-	hasher := util.NewMd5Hash()
-	tokenProvider := jwt.NewJWTProvider(os.Getenv("SECRET_KEY"))
-	tokenExpire := 60 * 60 * 24 * 30
-	userService := user.NewUserService(userRepo, hasher, tokenProvider, tokenExpire)
+	var hasher user.Hasher
+	switch name := os.Getenv("PASSWORD_HASHER"); name {
+	case "", "argon2id":
+		hasher = util.NewArgon2idHash(util.DefaultArgon2Params)
+	case "bcrypt":
+		hasher = util.NewBcryptHash(bcrypt.DefaultCost)
+	default:
+		log.Fatalf("PASSWORD_HASHER: unknown hasher %q, use argon2id or bcrypt", name)
+	}
+	// JWT_KEYS lists PEM key files: the first signs, the others are retired
+	// keys still verifying the tokens they signed. Without it tokens are
+	// signed with SECRET_KEY.
+	var tokenProvider tokenprovider.Provider = jwt.NewJWTProvider(os.Getenv("SECRET_KEY"))
+	var keySet restApi.KeySet
+	if paths := os.Getenv("JWT_KEYS"); paths != "" {
+		var keys []*jwt.Key
+		for _, path := range strings.Split(paths, ",") {
+			key, err := jwt.LoadKeyFile(strings.TrimSpace(path))
+			if err != nil {
+				log.Fatalln("JWT_KEYS:", err)
+			}
+			keys = append(keys, key)
+		}
+		provider, err := jwt.NewKeySetProvider(keys[0], keys[1:]...)
+		if err != nil {
+			log.Fatalln("JWT_KEYS:", err)
+		}
+		tokenProvider, keySet = provider, provider
+	}
+	tokenExpire := 60 * 15
+	refreshTokenExpire := 60 * 60 * 24 * 30
+
+	redisCache := memcache.NewRedisCache()
+	revocations := memcache.NewTokenRevocation(redisCache)
+
+	// Sessions are cached too, as the auth middleware checks the session of
+	// every request.
+	tokenRepo := memcache.NewSessionCaching(redisCache, pgRepo.NewTokenRepo(db))
+
+	// Users are read and written through the cache, so every change drops
+	// the cached user the auth middleware would otherwise keep serving.
+	userStore := memcache.NewUserCaching(redisCache, userRepo)
+	var mail user.Mailer = mailer.NewFileMailer(os.Getenv("MAIL_DIR"), os.Getenv("MAIL_FROM"))
+	if os.Getenv("MAILER") == "smtp" {
+		mail = mailer.NewSMTPMailer(os.Getenv("SMTP_HOST"), os.Getenv("SMTP_PORT"),
+			os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("MAIL_FROM"))
+	}
+
+	userService := user.NewUserService(userStore, hasher, tokenProvider, tokenRepo, revocations, mail, user.Config{
+		AccessExpiry:         tokenExpire,
+		RefreshExpiry:        refreshTokenExpire,
+		AppURL:               os.Getenv("APP_URL"),
+		RequireVerifiedEmail: os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true",
+	})
 
-	authCache := memcache.NewUserCaching(memcache.NewRedisCache(), userRepo)
-	middlewareAuth := middleware.RequiredAuth(tokenProvider, authCache)
+	middlewareAuth := middleware.RequiredAuth(tokenProvider, userStore, revocations, userService, userService)
+	middlewareSession := middleware.RequiredSession()
 
 	limiterRate := limiter.Rate{
 		Period: 5 * time.Second,
@@ -65,8 +157,83 @@ func main() {
 	limiter := limiter.New(store, limiterRate)
 	middlewareRateLimit := middleware.RateLimiter(limiter)
 
-	restApi.NewItemHandler(apiVersion, itemService, middlewareAuth, middlewareRateLimit)
-	restApi.NewUserHandler(apiVersion, userService)
+	workspaceService := workspace.NewWorkspaceService(workspaceRepo, userStore)
+	middlewareWorkspace := middleware.WorkspaceScope(workspaceService)
+
+	restApi.NewItemHandler(apiVersion, itemService, middlewareAuth, middlewareWorkspace, middlewareRateLimit)
+	restApi.NewListHandler(apiVersion, listService, middlewareAuth, middlewareWorkspace)
+	restApi.NewTagHandler(apiVersion, tagService, middlewareAuth)
+	shareService := share.NewShareService(shareRepo, itemRepo, listRepo, userStore, mail, os.Getenv("APP_URL"))
+	restApi.NewShareHandler(apiVersion, shareService, middlewareAuth)
+	restApi.NewWorkspaceHandler(apiVersion, workspaceService, middlewareAuth)
+	commentService := comment.NewCommentService(pgRepo.NewCommentRepo(db), itemRepo, permissions, userStore)
+	restApi.NewCommentHandler(apiVersion, commentService, middlewareAuth)
+
+	// Attachments are kept on the local disk unless an S3 bucket is configured.
+	var blobs attachment.BlobStore = blob.NewFileStore(os.Getenv("BLOB_DIR"))
+	if os.Getenv("BLOB_STORE") == "s3" {
+		// Downloads stream from S3 to the client for as long as they take, so
+		// only connecting and waiting for the response headers time out.
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = (&net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+		transport.TLSHandshakeTimeout = 10 * time.Second
+		transport.ResponseHeaderTimeout = 30 * time.Second
+
+		blobs = blob.NewS3Store(blob.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		}, &http.Client{Transport: transport})
+	}
+
+	maxAttachmentSize := int64(10 << 20)
+	if value := os.Getenv("ATTACHMENT_MAX_SIZE"); value != "" {
+		if maxAttachmentSize, err = strconv.ParseInt(value, 10, 64); err != nil {
+			log.Fatalln("ATTACHMENT_MAX_SIZE:", err)
+		}
+	}
+
+	// Content types are sniffed from the files, so text formats such as CSV
+	// arrive as text/plain.
+	attachmentTypes := []string{"image/*", "application/pdf", "text/plain", "application/zip"}
+	if value := os.Getenv("ATTACHMENT_TYPES"); value != "" {
+		attachmentTypes = nil
+		for _, contentType := range strings.Split(value, ",") {
+			if contentType = strings.TrimSpace(contentType); contentType != "" {
+				attachmentTypes = append(attachmentTypes, contentType)
+			}
+		}
+	}
+	attachmentLimits := domain.AttachmentLimits{
+		MaxSize: maxAttachmentSize,
+		Types:   attachmentTypes,
+	}
+
+	attachmentService := attachment.NewAttachmentService(pgRepo.NewAttachmentRepo(db), itemRepo, permissions, blobs, attachmentLimits)
+	go attachment.RunOrphanCleanup(context.Background(), attachmentService, time.Minute)
+	restApi.NewAttachmentHandler(apiVersion, attachmentService, middlewareAuth, maxAttachmentSize)
+	// SSO is only offered when an OpenID Connect provider is configured.
+	var ssoService restApi.SSOService
+	if issuer := os.Getenv("OIDC_ISSUER"); issuer != "" {
+		provider := oidc.NewProvider(oidc.Config{
+			Issuer:       issuer,
+			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		}, &http.Client{Timeout: 10 * time.Second})
+		ssoService = sso.NewSSOService(provider, memcache.NewSSOStateStore(redisCache), userService)
+	}
+
+	restApi.NewUserHandler(apiVersion, userService, ssoService, middlewareAuth, middlewareSession)
+
+	adminService := admin.NewAdminService(admin.NewTransactionalRepo(pgRepo.NewAdminRepo(db)), itemService, tokenRepo, userStore)
+	restApi.NewAdminHandler(apiVersion, adminService, middlewareAuth, middlewareSession, middleware.RequiredRole(domain.RoleAdmin))
+
+	if keySet != nil {
+		restApi.NewJWKSHandler(&r.RouterGroup, keySet)
+	}
 
 	r.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -0,0 +1,29 @@
+// Package blob stores file contents by key, either on the local filesystem or
+// in an S3-compatible object store. Keys are slash-separated relative paths.
+package blob
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("blob key is invalid")
+)
+
+// validKey refuses keys that could escape the root of a store: empty,
+// absolute or with empty, "." or ".." segments.
+func validKey(key string) bool {
+	if key == "" || strings.ContainsAny(key, "\\\x00") {
+		return false
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,181 @@
+// Package blobtest runs an in-process S3-compatible server for tests. It keeps
+// objects of a single bucket in memory, checks the Signature Version 4 of
+// every request and supports PUT, HEAD, ranged GET and DELETE of objects.
+package blobtest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type object struct {
+	content     []byte
+	contentType string
+	modified    time.Time
+}
+
+type S3 struct {
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	server *httptest.Server
+
+	mutex   sync.Mutex
+	objects map[string]object
+}
+
+// NewS3 starts a server holding the given bucket. Close it when done.
+func NewS3(region, bucket, accessKey, secretKey string) *S3 {
+	s := &S3{
+		Region:    region,
+		Bucket:    bucket,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		objects:   map[string]object{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+func (s *S3) URL() string { return s.server.URL }
+
+func (s *S3) Client() *http.Client { return s.server.Client() }
+
+func (s *S3) Close() { s.server.Close() }
+
+// Object returns the content and type of the stored object.
+func (s *S3) Object(key string) (content []byte, contentType string, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	obj, ok := s.objects[key]
+
+	return obj.content, obj.contentType, ok
+}
+
+func (s *S3) serve(w http.ResponseWriter, r *http.Request) {
+	if err := s.verify(r); err != nil {
+		writeError(w, http.StatusForbidden, "SignatureDoesNotMatch", err.Error())
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.Bucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", bucket)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+
+		s.mutex.Lock()
+		s.objects[key] = object{content: content, contentType: r.Header.Get("Content-Type"), modified: time.Now()}
+		s.mutex.Unlock()
+	case http.MethodGet, http.MethodHead:
+		s.mutex.Lock()
+		obj, ok := s.objects[key]
+		s.mutex.Unlock()
+
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", key)
+			return
+		}
+
+		w.Header().Set("Content-Type", obj.contentType)
+		http.ServeContent(w, r, "", obj.modified, bytes.NewReader(obj.content))
+	case http.MethodDelete:
+		s.mutex.Lock()
+		delete(s.objects, key)
+		s.mutex.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+// verify recomputes the Signature Version 4 of the request from the headers
+// it claims to sign.
+func (s *S3) verify(r *http.Request) error {
+	fields := map[string]string{}
+	algorithm, params, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if algorithm != "AWS4-HMAC-SHA256" {
+		return fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+	for _, param := range strings.Split(params, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		fields[name] = value
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if len(amzDate) < 8 {
+		return fmt.Errorf("missing date")
+	}
+	scope := amzDate[:8] + "/" + s.Region + "/s3/aws4_request"
+	if fields["Credential"] != s.AccessKey+"/"+scope {
+		return fmt.Errorf("unexpected credential %q", fields["Credential"])
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		fields["SignedHeaders"],
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := sign([]byte("AWS4"+s.SecretKey), amzDate[:8])
+	for _, part := range []string{s.Region, "s3", "aws4_request"} {
+		key = sign(key, part)
+	}
+
+	if !hmac.Equal([]byte(fields["Signature"]), []byte(hex.EncodeToString(sign(key, stringToSign)))) {
+		return fmt.Errorf("signature does not match")
+	}
+
+	return nil
+}
+
+func sign(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, url.QueryEscape(message))
+}
@@ -0,0 +1,63 @@
+package blob
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileStore checks storing, reading and deleting blobs as files
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(dir)
+
+	require.NoError(t, store.Put("items/1/a", strings.NewReader("hello world"), 11, "text/plain"))
+
+	blob, err := store.Open("items/1/a")
+	require.NoError(t, err)
+
+	_, err = blob.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+	content, err := io.ReadAll(blob)
+	require.NoError(t, err)
+	require.NoError(t, blob.Close())
+	assert.Equal(t, "world", string(content))
+
+	require.NoError(t, store.Delete("items/1/a"))
+	_, err = store.Open("items/1/a")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Deleting twice is fine
+	assert.NoError(t, store.Delete("items/1/a"))
+}
+
+// TestFileStoreIncompleteUpload checks that a short upload leaves nothing behind
+func TestFileStoreIncompleteUpload(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(dir)
+
+	err := store.Put("items/1/a", strings.NewReader("hello"), 11, "text/plain")
+	assert.Error(t, err)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "items", "1"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// TestInvalidKeys checks that keys cannot escape the store
+func TestInvalidKeys(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "items/../../secret", "items//a", "items/.", "a\\b"} {
+		err := store.Put(key, strings.NewReader(""), 0, "")
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+
+		_, err = store.Open(key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
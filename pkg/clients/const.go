@@ -1,5 +1,10 @@
 package clients
 
 const (
-	CurrentUser = "current_user"
+	CurrentUser   = "current_user"
+	CurrentToken  = "current_token"
+	CurrentAPIKey = "current_api_key"
+	// CurrentWorkspace holds the membership of the user in the workspace the
+	// request works in, when it selected one.
+	CurrentWorkspace = "current_workspace"
 )
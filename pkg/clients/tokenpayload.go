@@ -1,10 +1,21 @@
 package clients
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+)
+
+// TokenPayload is the data carried by an access token. SID is the session
+// the token was issued to, uuid.Nil for sessions started before sessions were
+// tracked. JTI and Expiry are only set on payloads read back from a validated
+// token.
 type TokenPayload struct {
-	UID   uuid.UUID `json:"user_id"`
-	URole string    `json:"role"`
+	UID    uuid.UUID `json:"user_id"`
+	URole  string    `json:"role"`
+	SID    uuid.UUID `json:"session_id"`
+	JTI    string    `json:"-"`
+	Expiry time.Time `json:"-"`
 }
 
 func (p TokenPayload) UserID() uuid.UUID {
@@ -15,6 +26,18 @@ func (p TokenPayload) Role() string {
 	return p.URole
 }
 
+func (p TokenPayload) SessionID() uuid.UUID {
+	return p.SID
+}
+
+func (p TokenPayload) TokenID() string {
+	return p.JTI
+}
+
+func (p TokenPayload) ExpiresAt() time.Time {
+	return p.Expiry
+}
+
 type Requester interface {
 	GetUserID() uuid.UUID
 	GetEmail() string
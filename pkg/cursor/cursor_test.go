@@ -0,0 +1,40 @@
+package cursor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type position struct {
+	Key string `json:"k"`
+	ID  int    `json:"i"`
+}
+
+// TestSigner checks that tokens round-trip and tampering is detected
+func TestSigner(t *testing.T) {
+	signer := NewSigner("secret")
+
+	token, err := signer.Encode(position{Key: "a", ID: 1})
+	assert.NoError(t, err)
+
+	// Test case 1: A token decodes back to the encoded value
+	var decoded position
+	assert.NoError(t, signer.Decode(token, &decoded))
+	assert.Equal(t, position{Key: "a", ID: 1}, decoded)
+
+	// Test case 2: A token signed with another secret is rejected
+	assert.ErrorIs(t, NewSigner("other").Decode(token, &decoded), ErrInvalidCursor)
+
+	// Test case 3: A modified payload is rejected
+	forged, err := signer.Encode(position{Key: "b", ID: 1})
+	assert.NoError(t, err)
+	payload, _, _ := strings.Cut(forged, ".")
+	_, signature, _ := strings.Cut(token, ".")
+	assert.ErrorIs(t, signer.Decode(payload+"."+signature, &decoded), ErrInvalidCursor)
+
+	// Test case 4: Garbage is rejected
+	assert.ErrorIs(t, signer.Decode("not-a-cursor", &decoded), ErrInvalidCursor)
+	assert.ErrorIs(t, signer.Decode("!!.!!", &decoded), ErrInvalidCursor)
+}
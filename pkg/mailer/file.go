@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer is meant for local development: it writes every mail to an
+// .eml file in dir, or to the log when dir is empty, instead of sending it.
+func NewFileMailer(dir, from string) *fileMailer {
+	return &fileMailer{dir: dir, from: from}
+}
+
+func (m *fileMailer) Send(to, subject, body string) error {
+	msg := message(m.from, to, subject, body)
+
+	if m.dir == "" {
+		log.Printf("mail:\n%s", msg)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("/", "_", "\\", "_").Replace(to))
+
+	return os.WriteFile(filepath.Join(m.dir, name), msg, 0o644)
+}
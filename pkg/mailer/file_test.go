@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileMailer checks that mails are written as .eml files
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewFileMailer(dir, "todo@example.com")
+
+	err := mailer.Send("a@example.com", "Hello\r\nBcc: b@example.com", "Body")
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+
+	// Line breaks in headers are dropped, so no Bcc header is injected
+	assert.Contains(t, string(content), "Subject: HelloBcc: b@example.com\r\n")
+	assert.Contains(t, string(content), "To: a@example.com\r\n")
+	assert.Contains(t, string(content), "\r\n\r\nBody")
+}
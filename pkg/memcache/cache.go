@@ -3,8 +3,13 @@ package memcache
 import (
 	"context"
 	"time"
+
+	"github.com/go-redis/cache/v8"
 )
 
+// ErrCacheMiss is returned by Get when the key is not in the cache.
+var ErrCacheMiss = cache.ErrCacheMiss
+
 type Cache interface {
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Get(ctx context.Context, key string, value interface{}) error
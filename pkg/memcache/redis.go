@@ -42,7 +42,7 @@ func (rdc *redisCache) Set(ctx context.Context, key string, value interface{}, t
 		Ctx:   ctx,
 		Key:   key,
 		Value: value,
-		TTL:   0,
+		TTL:   ttl,
 	})
 }
 
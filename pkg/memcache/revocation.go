@@ -0,0 +1,45 @@
+package memcache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type tokenRevocation struct {
+	store Cache
+}
+
+// NewTokenRevocation keeps the IDs of revoked access tokens in the cache
+// until the tokens expire on their own.
+func NewTokenRevocation(store Cache) *tokenRevocation {
+	return &tokenRevocation{store: store}
+}
+
+func revocationKey(jti string) string {
+	return "revoked-token-" + jti
+}
+
+func (tr *tokenRevocation) Revoke(jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return tr.store.Set(context.Background(), revocationKey(jti), true, ttl)
+}
+
+func (tr *tokenRevocation) IsRevoked(jti string) (bool, error) {
+	var revoked bool
+
+	err := tr.store.Get(context.Background(), revocationKey(jti), &revoked)
+	if errors.Is(err, ErrCacheMiss) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
@@ -0,0 +1,163 @@
+package memcache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+	"todo-app/domain"
+
+	"github.com/google/uuid"
+)
+
+// sessionCacheTTL bounds how long a cached session lives, and so how long a
+// change made around the cache can go unnoticed.
+const sessionCacheTTL = time.Minute
+
+type TokenStore interface {
+	SaveRefreshToken(token *domain.RefreshToken) error
+	GetRefreshToken(conditions map[string]any) (*domain.RefreshToken, error)
+	RotateRefreshToken(id uuid.UUID, next *domain.RefreshToken) error
+	RevokeRefreshTokens(conditions map[string]any, revokedAt time.Time) error
+	SaveSession(session *domain.Session) error
+	GetSession(conditions map[string]any) (*domain.Session, error)
+	ListSessions(conditions map[string]any) ([]domain.Session, error)
+	RevokeSession(userID, id uuid.UUID, revokedAt time.Time) error
+	TouchSession(id uuid.UUID, seenAt time.Time) error
+	SaveUserToken(token *domain.UserToken) error
+	ConsumeUserToken(purpose, tokenHash string, usedAt time.Time) (*domain.UserToken, error)
+	SaveAPIKey(key *domain.APIKey) error
+	GetAPIKey(conditions map[string]any) (*domain.APIKey, error)
+	ListAPIKeys(conditions map[string]any) ([]domain.APIKey, error)
+	RevokeAPIKey(userID, id uuid.UUID, revokedAt time.Time) error
+	TouchAPIKey(id uuid.UUID, usedAt time.Time) error
+	ReplaceRecoveryCodes(userID uuid.UUID, codes []domain.RecoveryCode) error
+	ConsumeRecoveryCode(userID uuid.UUID, codeHash string, usedAt time.Time) error
+}
+
+// sessionCaching caches sessions looked up by ID, so that checking the
+// session of every authenticated request does not read the database. Session
+// writes go through it and drop the cached session; everything else is passed
+// to the real store.
+type sessionCaching struct {
+	TokenStore
+	store Cache
+}
+
+func NewSessionCaching(store Cache, realStore TokenStore) *sessionCaching {
+	return &sessionCaching{
+		TokenStore: realStore,
+		store:      store,
+	}
+}
+
+func (sc *sessionCaching) GetSession(conditions map[string]any) (*domain.Session, error) {
+	ctx := context.Background()
+
+	// Only lookups by ID alone are cached, others go to the real store
+	id, ok := conditions["id"].(uuid.UUID)
+	if !ok || len(conditions) != 1 {
+		return sc.TokenStore.GetSession(conditions)
+	}
+
+	var cached cachedSession
+	if err := sc.store.Get(ctx, sessionKey(id), &cached); err == nil && cached.ID != uuid.Nil {
+		return cached.session(), nil
+	}
+
+	realSession, err := sc.TokenStore.GetSession(conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheErr := sc.store.Set(ctx, sessionKey(id), newCachedSession(realSession), sessionCacheTTL); cacheErr != nil {
+		log.Printf("failed to set cache: %v", cacheErr)
+	}
+
+	return realSession, nil
+}
+
+func (sc *sessionCaching) RevokeSession(userID, id uuid.UUID, revokedAt time.Time) error {
+	defer sc.invalidate(id)
+
+	return sc.TokenStore.RevokeSession(userID, id, revokedAt)
+}
+
+func (sc *sessionCaching) TouchSession(id uuid.UUID, seenAt time.Time) error {
+	defer sc.invalidate(id)
+
+	return sc.TokenStore.TouchSession(id, seenAt)
+}
+
+// RevokeRefreshTokens drops the sessions the revocation may end: the session
+// named by the conditions, or else every active session of their user.
+func (sc *sessionCaching) RevokeRefreshTokens(conditions map[string]any, revokedAt time.Time) error {
+	var ids []uuid.UUID
+
+	if id, ok := conditions["session_id"].(uuid.UUID); ok {
+		ids = append(ids, id)
+	} else if userID, ok := conditions["user_id"].(uuid.UUID); ok {
+		sessions, err := sc.TokenStore.ListSessions(map[string]any{"user_id": userID, "revoked_at": nil})
+		if err != nil {
+			return err
+		}
+
+		for _, session := range sessions {
+			ids = append(ids, session.ID)
+		}
+	}
+
+	defer func() {
+		for _, id := range ids {
+			sc.invalidate(id)
+		}
+	}()
+
+	return sc.TokenStore.RevokeRefreshTokens(conditions, revokedAt)
+}
+
+func (sc *sessionCaching) invalidate(id uuid.UUID) {
+	if err := sc.store.Delete(context.Background(), sessionKey(id)); err != nil && !errors.Is(err, ErrCacheMiss) {
+		log.Printf("failed to invalidate cache: %v", err)
+	}
+}
+
+// cachedSession is the cached form of a session. domain.Session hides its
+// owner and revocation from JSON, which the cache must keep.
+type cachedSession struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+func newCachedSession(session *domain.Session) cachedSession {
+	return cachedSession{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		LastSeenAt: session.LastSeenAt,
+		RevokedAt:  session.RevokedAt,
+		CreatedAt:  session.CreatedAt,
+	}
+}
+
+func (c cachedSession) session() *domain.Session {
+	return &domain.Session{
+		ID:         c.ID,
+		UserID:     c.UserID,
+		UserAgent:  c.UserAgent,
+		IP:         c.IP,
+		LastSeenAt: c.LastSeenAt,
+		RevokedAt:  c.RevokedAt,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+func sessionKey(id uuid.UUID) string {
+	return "session-" + id.String()
+}
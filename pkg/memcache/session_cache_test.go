@@ -0,0 +1,101 @@
+package memcache
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionStore is a TokenStore holding sessions and counting lookups.
+type sessionStore struct {
+	TokenStore
+	sessions map[uuid.UUID]*domain.Session
+	lookups  int
+}
+
+func (s *sessionStore) GetSession(conditions map[string]any) (*domain.Session, error) {
+	s.lookups++
+	session := *s.sessions[conditions["id"].(uuid.UUID)]
+	return &session, nil
+}
+
+func (s *sessionStore) ListSessions(conditions map[string]any) ([]domain.Session, error) {
+	var sessions []domain.Session
+	for _, session := range s.sessions {
+		if session.UserID == conditions["user_id"] && session.RevokedAt == nil {
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, nil
+}
+
+func (s *sessionStore) RevokeSession(_, id uuid.UUID, revokedAt time.Time) error {
+	s.sessions[id].RevokedAt = &revokedAt
+	return nil
+}
+
+func (s *sessionStore) TouchSession(id uuid.UUID, seenAt time.Time) error {
+	s.sessions[id].LastSeenAt = seenAt
+	return nil
+}
+
+func (s *sessionStore) RevokeRefreshTokens(conditions map[string]any, revokedAt time.Time) error {
+	for _, session := range s.sessions {
+		if session.UserID == conditions["user_id"] {
+			session.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+// TestSessionCachingInvalidation checks that session writes drop the cached session
+func TestSessionCachingInvalidation(t *testing.T) {
+	userID, first, second := uuid.New(), uuid.New(), uuid.New()
+	store := &sessionStore{sessions: map[uuid.UUID]*domain.Session{
+		first:  {ID: first, UserID: userID},
+		second: {ID: second, UserID: userID},
+	}}
+	caching := NewSessionCaching(mapCache{}, store)
+
+	// Test case 1: Check that lookups by ID are served from the cache
+	for i := 0; i < 2; i++ {
+		session, err := caching.GetSession(map[string]any{"id": first})
+		require.NoError(t, err)
+		assert.Equal(t, userID, session.UserID)
+		assert.Nil(t, session.RevokedAt)
+	}
+	assert.Equal(t, 1, store.lookups)
+
+	// Test case 2: Check that a touch is visible on the next lookup
+	seenAt := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, caching.TouchSession(first, seenAt))
+
+	session, err := caching.GetSession(map[string]any{"id": first})
+	require.NoError(t, err)
+	assert.True(t, seenAt.Equal(session.LastSeenAt))
+	assert.Equal(t, 2, store.lookups)
+
+	// Test case 3: Check that a revoked session stays revoked once cached
+	require.NoError(t, caching.RevokeSession(userID, first, time.Now()))
+
+	for i := 0; i < 2; i++ {
+		session, err = caching.GetSession(map[string]any{"id": first})
+		require.NoError(t, err)
+		assert.NotNil(t, session.RevokedAt)
+	}
+	assert.Equal(t, 3, store.lookups)
+
+	// Test case 4: Check that revoking the tokens of a user drops their sessions
+	_, err = caching.GetSession(map[string]any{"id": second})
+	require.NoError(t, err)
+	require.NoError(t, caching.RevokeRefreshTokens(map[string]any{"user_id": userID}, time.Now()))
+
+	session, err = caching.GetSession(map[string]any{"id": second})
+	require.NoError(t, err)
+	assert.NotNil(t, session.RevokedAt)
+	assert.Equal(t, 5, store.lookups)
+}
@@ -0,0 +1,47 @@
+package memcache
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+)
+
+type ssoStates struct {
+	store Cache
+}
+
+// NewSSOStateStore keeps the state of logins at an identity provider in the
+// cache until they complete or expire.
+func NewSSOStateStore(store Cache) *ssoStates {
+	return &ssoStates{store: store}
+}
+
+func ssoStateKey(state string) string {
+	return "sso-state-" + state
+}
+
+func (s *ssoStates) Save(state string, data *domain.SSOState, ttl time.Duration) error {
+	return s.store.Set(context.Background(), ssoStateKey(state), data, ttl)
+}
+
+// Take returns the state and drops it, so every login completes once.
+func (s *ssoStates) Take(state string) (*domain.SSOState, error) {
+	var data domain.SSOState
+
+	err := s.store.Get(context.Background(), ssoStateKey(state), &data)
+	if errors.Is(err, ErrCacheMiss) {
+		return nil, clients.ErrRecordNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Delete(context.Background(), ssoStateKey(state)); err != nil && !errors.Is(err, ErrCacheMiss) {
+		return nil, err
+	}
+
+	return &data, nil
+}
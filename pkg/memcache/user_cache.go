@@ -2,6 +2,7 @@ package memcache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -12,9 +13,23 @@ import (
 )
 
 type RealStore interface {
+	Save(user *domain.UserCreate) error
 	GetUser(conditions map[string]any) (*domain.User, error)
+	Update(id uuid.UUID, data *domain.UserUpdate) error
+	UpdatePassword(id uuid.UUID, password string) error
+	MarkEmailVerified(id uuid.UUID, verifiedAt time.Time) error
+	Delete(id uuid.UUID, deletedAt time.Time) error
+	CountSoleOwnedWorkspaces(userID uuid.UUID) (int64, error)
+	SetTOTPSecret(id uuid.UUID, secret string) error
+	EnableTOTP(id uuid.UUID, enabledAt time.Time) error
+	UseTOTPStep(id uuid.UUID, step int64) error
+	GetIdentity(conditions map[string]any) (*domain.UserIdentity, error)
+	SaveIdentity(identity *domain.UserIdentity) error
 }
 
+// userCaching caches users looked up by ID. Every write goes through it to
+// the real store and drops the cached user, so the cache never serves a user
+// that was changed, banned or deleted since.
 type userCaching struct {
 	store     Cache
 	realStore RealStore
@@ -32,13 +47,13 @@ func (uc *userCaching) GetUser(conditions map[string]interface{}) (*domain.User,
 	var ctx = context.Background()
 	var user domain.User
 
-	// Safely extract userId with comma-ok pattern to avoid panics
+	// Only lookups by ID alone are cached, others go to the real store
 	userId, ok := conditions["id"].(uuid.UUID)
-	if !ok {
-		return nil, fmt.Errorf("invalid user ID type")
+	if !ok || len(conditions) != 1 {
+		return uc.realStore.GetUser(conditions)
 	}
 
-	key := fmt.Sprintf("user-%d", userId)
+	key := userKey(userId)
 
 	// Try to get the user from the cache
 	if err := uc.store.Get(ctx, key, &user); err == nil && user.ID != uuid.Nil {
@@ -68,3 +83,72 @@ func (uc *userCaching) GetUser(conditions map[string]interface{}) (*domain.User,
 
 	return realUser, nil
 }
+
+func (uc *userCaching) Save(user *domain.UserCreate) error {
+	return uc.realStore.Save(user)
+}
+
+func (uc *userCaching) Update(id uuid.UUID, data *domain.UserUpdate) error {
+	defer uc.Invalidate(id)
+
+	return uc.realStore.Update(id, data)
+}
+
+func (uc *userCaching) UpdatePassword(id uuid.UUID, password string) error {
+	defer uc.Invalidate(id)
+
+	return uc.realStore.UpdatePassword(id, password)
+}
+
+func (uc *userCaching) MarkEmailVerified(id uuid.UUID, verifiedAt time.Time) error {
+	defer uc.Invalidate(id)
+
+	return uc.realStore.MarkEmailVerified(id, verifiedAt)
+}
+
+func (uc *userCaching) Delete(id uuid.UUID, deletedAt time.Time) error {
+	defer uc.Invalidate(id)
+
+	return uc.realStore.Delete(id, deletedAt)
+}
+
+func (uc *userCaching) CountSoleOwnedWorkspaces(userID uuid.UUID) (int64, error) {
+	return uc.realStore.CountSoleOwnedWorkspaces(userID)
+}
+
+func (uc *userCaching) SetTOTPSecret(id uuid.UUID, secret string) error {
+	defer uc.Invalidate(id)
+
+	return uc.realStore.SetTOTPSecret(id, secret)
+}
+
+func (uc *userCaching) EnableTOTP(id uuid.UUID, enabledAt time.Time) error {
+	defer uc.Invalidate(id)
+
+	return uc.realStore.EnableTOTP(id, enabledAt)
+}
+
+func (uc *userCaching) UseTOTPStep(id uuid.UUID, step int64) error {
+	defer uc.Invalidate(id)
+
+	return uc.realStore.UseTOTPStep(id, step)
+}
+
+func (uc *userCaching) GetIdentity(conditions map[string]any) (*domain.UserIdentity, error) {
+	return uc.realStore.GetIdentity(conditions)
+}
+
+func (uc *userCaching) SaveIdentity(identity *domain.UserIdentity) error {
+	return uc.realStore.SaveIdentity(identity)
+}
+
+// Invalidate drops the cached user, for changes made around the cache.
+func (uc *userCaching) Invalidate(id uuid.UUID) {
+	if err := uc.store.Delete(context.Background(), userKey(id)); err != nil && !errors.Is(err, ErrCacheMiss) {
+		log.Printf("failed to invalidate cache: %v", err)
+	}
+}
+
+func userKey(id uuid.UUID) string {
+	return fmt.Sprintf("user-%s", id)
+}
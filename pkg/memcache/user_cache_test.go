@@ -0,0 +1,107 @@
+package memcache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapCache is an in-memory Cache for tests.
+type mapCache map[string][]byte
+
+func (m mapCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	m[key] = data
+	return err
+}
+
+func (m mapCache) Get(_ context.Context, key string, value interface{}) error {
+	data, ok := m[key]
+	if !ok {
+		return ErrCacheMiss
+	}
+	return json.Unmarshal(data, value)
+}
+
+func (m mapCache) Delete(_ context.Context, key string) error {
+	if _, ok := m[key]; !ok {
+		return ErrCacheMiss
+	}
+	delete(m, key)
+	return nil
+}
+
+// countingStore is a RealStore holding one user and counting lookups.
+type countingStore struct {
+	user    domain.User
+	lookups int
+}
+
+func (s *countingStore) Save(*domain.UserCreate) error { return nil }
+
+func (s *countingStore) GetUser(map[string]any) (*domain.User, error) {
+	s.lookups++
+	user := s.user
+	return &user, nil
+}
+
+func (s *countingStore) Update(_ uuid.UUID, data *domain.UserUpdate) error {
+	s.user.FirstName = *data.FirstName
+	return nil
+}
+
+func (s *countingStore) UpdatePassword(uuid.UUID, string) error { return nil }
+
+func (s *countingStore) MarkEmailVerified(uuid.UUID, time.Time) error { return nil }
+
+func (s *countingStore) Delete(uuid.UUID, time.Time) error { return nil }
+
+func (s *countingStore) CountSoleOwnedWorkspaces(uuid.UUID) (int64, error) { return 0, nil }
+
+func (s *countingStore) SetTOTPSecret(uuid.UUID, string) error { return nil }
+
+func (s *countingStore) EnableTOTP(uuid.UUID, time.Time) error { return nil }
+
+func (s *countingStore) UseTOTPStep(uuid.UUID, int64) error { return nil }
+
+func (s *countingStore) GetIdentity(map[string]any) (*domain.UserIdentity, error) {
+	return nil, clients.ErrRecordNotFound
+}
+
+func (s *countingStore) SaveIdentity(*domain.UserIdentity) error { return nil }
+
+// TestUserCachingInvalidation checks that writes drop the cached user
+func TestUserCachingInvalidation(t *testing.T) {
+	id := uuid.New()
+	store := &countingStore{user: domain.User{ID: id, FirstName: "Ann"}}
+	caching := NewUserCaching(mapCache{}, store)
+
+	// Test case 1: Check that lookups by ID are served from the cache
+	for i := 0; i < 2; i++ {
+		user, err := caching.GetUser(map[string]any{"id": id})
+		require.NoError(t, err)
+		assert.Equal(t, "Ann", user.FirstName)
+	}
+	assert.Equal(t, 1, store.lookups)
+
+	// Test case 2: Check that an update is visible on the next lookup
+	name := "Bea"
+	require.NoError(t, caching.Update(id, &domain.UserUpdate{FirstName: &name}))
+
+	user, err := caching.GetUser(map[string]any{"id": id})
+	require.NoError(t, err)
+	assert.Equal(t, "Bea", user.FirstName)
+	assert.Equal(t, 2, store.lookups)
+
+	// Test case 3: Check that other lookups bypass the cache
+	_, err = caching.GetUser(map[string]any{"email": "a@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.lookups)
+}
@@ -0,0 +1,137 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"todo-app/pkg/oidc"
+	"todo-app/pkg/oidc/oidctest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProvider(t *testing.T) (*oidctest.Provider, *oidc.Provider) {
+	idp := oidctest.NewProvider("todo-app", "secret")
+	t.Cleanup(idp.Close)
+
+	provider := oidc.NewProvider(oidc.Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     "todo-app",
+		ClientSecret: "secret",
+		RedirectURL:  "https://app.example.com/sso/callback",
+	}, idp.Client())
+
+	return idp, provider
+}
+
+// login runs the flow up to the code exchange with the given verifier.
+func login(t *testing.T, idp *oidctest.Provider, provider *oidc.Provider, verifier string) (*oidc.IDToken, error) {
+	challengeVerifier, err := oidc.NewVerifier()
+	require.NoError(t, err)
+	if verifier == "" {
+		verifier = challengeVerifier
+	}
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", oidc.Challenge(challengeVerifier))
+	require.NoError(t, err)
+
+	code, state, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "state", state)
+
+	return provider.Exchange(context.Background(), code, verifier)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	idp, provider := setupProvider(t)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, idp.Issuer()+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+	assert.Equal(t, "openid email profile", parsed.Query().Get("scope"))
+}
+
+func TestExchange(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		idp, provider := setupProvider(t)
+		idp.SetIdentity(oidctest.Identity{Subject: "42", Email: "ann@example.com", EmailVerified: true, GivenName: "Ann"})
+
+		token, err := login(t, idp, provider, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, idp.Issuer(), token.Issuer)
+		assert.Equal(t, "42", token.Subject)
+		assert.Equal(t, "ann@example.com", token.Email)
+		assert.True(t, token.EmailVerified)
+		assert.Equal(t, "Ann", token.GivenName)
+		assert.Equal(t, "nonce", token.Nonce)
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		idp, provider := setupProvider(t)
+
+		_, err := login(t, idp, provider, "not the verifier")
+
+		assert.ErrorIs(t, err, oidc.ErrTokenExchange)
+	})
+
+	t.Run("token for another client", func(t *testing.T) {
+		idp, provider := setupProvider(t)
+		idp.SetClaims("other-app", "")
+
+		_, err := login(t, idp, provider, "")
+
+		assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	})
+
+	t.Run("token of another issuer", func(t *testing.T) {
+		idp, provider := setupProvider(t)
+		idp.SetClaims("", "https://evil.example.com")
+
+		_, err := login(t, idp, provider, "")
+
+		assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	})
+
+	t.Run("unknown keys refetch the key set at most once a minute", func(t *testing.T) {
+		idp, provider := setupProvider(t)
+
+		_, err := login(t, idp, provider, "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, idp.KeyFetches())
+
+		idp.SetKeyID("forged")
+		for i := 0; i < 3; i++ {
+			_, err = login(t, idp, provider, "")
+			assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+		}
+		assert.Equal(t, 1, idp.KeyFetches())
+
+		// Known keys keep working meanwhile
+		idp.SetKeyID("")
+		_, err = login(t, idp, provider, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("code used twice", func(t *testing.T) {
+		idp, provider := setupProvider(t)
+
+		verifier, err := oidc.NewVerifier()
+		require.NoError(t, err)
+		authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", oidc.Challenge(verifier))
+		require.NoError(t, err)
+		code, _, err := idp.Authorize(authURL)
+		require.NoError(t, err)
+
+		_, err = provider.Exchange(context.Background(), code, verifier)
+		require.NoError(t, err)
+
+		_, err = provider.Exchange(context.Background(), code, verifier)
+		assert.ErrorIs(t, err, oidc.ErrTokenExchange)
+	})
+}
@@ -0,0 +1,26 @@
+package tokenprovider
+
+// JSONWebKey is the public part of a signing key as of RFC 7517. Only the
+// members of the key type are set.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeySetProvider is a Provider whose tokens can be verified with public keys
+// alone.
+type KeySetProvider interface {
+	Provider
+	JWKS() JSONWebKeySet
+}
@@ -1,12 +1,12 @@
 package jwt
 
 import (
-	"fmt"
 	"time"
 	"todo-app/pkg/clients"
 	"todo-app/pkg/tokenprovider"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 )
 
 type jwtProvider struct {
@@ -33,22 +33,42 @@ func (t *token) GetToken() string {
 }
 
 func (j *jwtProvider) Generate(data tokenprovider.TokenPayload, expiry int) (tokenprovider.Token, error) {
-	// generate the JWT
 	now := time.Now()
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims(data, now, expiry))
 
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, myClaims{
+	return signToken(t, []byte(j.secret), now, expiry)
+}
+
+func (j *jwtProvider) Validate(myToken string) (tokenprovider.TokenPayload, error) {
+	return parseToken(myToken, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, tokenprovider.ErrInvalidToken
+		}
+		return []byte(j.secret), nil
+	})
+}
+
+func (j *jwtProvider) SecretKey() string {
+	return j.secret
+}
+
+func newClaims(data tokenprovider.TokenPayload, now time.Time, expiry int) myClaims {
+	return myClaims{
 		clients.TokenPayload{
 			UID:   data.UserID(),
 			URole: data.Role(),
+			SID:   data.SessionID(),
 		},
 		jwt.StandardClaims{
 			ExpiresAt: now.Local().Add(time.Second * time.Duration(expiry)).Unix(),
 			IssuedAt:  now.Local().Unix(),
-			Id:        fmt.Sprintf("%d", now.UnixNano()),
+			Id:        uuid.NewString(),
 		},
-	})
+	}
+}
 
-	myToken, err := t.SignedString([]byte(j.secret))
+func signToken(t *jwt.Token, key interface{}, now time.Time, expiry int) (tokenprovider.Token, error) {
+	myToken, err := t.SignedString(key)
 	if err != nil {
 		return nil, err
 	}
@@ -61,10 +81,8 @@ func (j *jwtProvider) Generate(data tokenprovider.TokenPayload, expiry int) (tok
 	}, nil
 }
 
-func (j *jwtProvider) Validate(myToken string) (tokenprovider.TokenPayload, error) {
-	res, err := jwt.ParseWithClaims(myToken, &myClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secret), nil
-	})
+func parseToken(myToken string, keyFunc jwt.Keyfunc) (tokenprovider.TokenPayload, error) {
+	res, err := jwt.ParseWithClaims(myToken, &myClaims{}, keyFunc)
 
 	if err != nil {
 		return nil, tokenprovider.ErrInvalidToken
@@ -81,10 +99,10 @@ func (j *jwtProvider) Validate(myToken string) (tokenprovider.TokenPayload, erro
 		return nil, tokenprovider.ErrInvalidToken
 	}
 
-	// return the token
-	return claims.Payload, nil
-}
+	payload := claims.Payload
+	payload.JTI = claims.Id
+	payload.Expiry = time.Unix(claims.ExpiresAt, 0)
 
-func (j *jwtProvider) SecretKey() string {
-	return j.secret
+	// return the token
+	return payload, nil
 }
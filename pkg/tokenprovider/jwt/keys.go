@@ -0,0 +1,174 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"todo-app/pkg/tokenprovider"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+var ErrUnsupportedKey = errors.New("key must be RSA, ECDSA P-256 or Ed25519")
+
+// Key is a key of a key set. Private is nil for retired keys kept to verify
+// the tokens they signed. ID is the RFC 7638 thumbprint of the public key, so
+// it is stable across restarts and servers.
+type Key struct {
+	ID      string
+	Public  crypto.PublicKey
+	Private crypto.Signer
+	method  jwt.SigningMethod
+}
+
+// LoadKeyFile reads a PEM encoded private or public key.
+func LoadKeyFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParseKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+// ParseKey parses a PEM encoded key: a PKCS #8, PKCS #1 or SEC 1 private key,
+// or a PKIX public key.
+func ParseKey(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var parsed any
+	var err error
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(parsed)
+}
+
+// NewKey wraps a private or public key of a supported type.
+func NewKey(key any) (*Key, error) {
+	k := &Key{}
+
+	if signer, ok := key.(crypto.Signer); ok {
+		k.Private = signer
+		key = signer.Public()
+	}
+
+	switch public := key.(type) {
+	case *rsa.PublicKey:
+		k.method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if public.Curve != elliptic.P256() {
+			return nil, ErrUnsupportedKey
+		}
+		k.method = jwt.SigningMethodES256
+	case ed25519.PublicKey:
+		k.method = SigningMethodEdDSA
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	k.Public = key
+	k.ID = k.thumbprint()
+
+	return k, nil
+}
+
+// Alg returns the JWS algorithm of the key.
+func (k *Key) Alg() string {
+	return k.method.Alg()
+}
+
+// JWK returns the public key as a JSON Web Key.
+func (k *Key) JWK() tokenprovider.JSONWebKey {
+	jwk := tokenprovider.JSONWebKey{Kid: k.ID, Use: "sig", Alg: k.Alg()}
+
+	switch public := k.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encode(public.N.Bytes())
+		jwk.E = encode(big.NewInt(int64(public.E)).Bytes())
+	case *ecdsa.PublicKey:
+		jwk.Kty = "EC"
+		jwk.Crv = "P-256"
+		jwk.X = encode(public.X.FillBytes(make([]byte, 32)))
+		jwk.Y = encode(public.Y.FillBytes(make([]byte, 32)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encode(public)
+	}
+
+	return jwk
+}
+
+// thumbprint hashes the required members of the JWK in lexicographic order,
+// as RFC 7638 prescribes.
+func (k *Key) thumbprint() string {
+	jwk := k.JWK()
+
+	var members any
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+
+	return encode(sum[:])
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
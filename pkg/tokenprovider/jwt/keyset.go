@@ -0,0 +1,63 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+	"todo-app/pkg/tokenprovider"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// keySetProvider signs with one asymmetric key and verifies with every key of
+// the set, so a key can be rotated without invalidating the tokens it signed:
+// the new key signs while the old one keeps verifying until they expire.
+type keySetProvider struct {
+	signing *Key
+	retired []*Key
+	keys    map[string]*Key
+}
+
+// NewKeySetProvider signs with the private key of signing and verifies tokens
+// signed by it or by any of the retired keys.
+func NewKeySetProvider(signing *Key, retired ...*Key) (*keySetProvider, error) {
+	if signing == nil || signing.Private == nil {
+		return nil, errors.New("the signing key must be a private key")
+	}
+
+	keys := map[string]*Key{signing.ID: signing}
+	for _, key := range retired {
+		keys[key.ID] = key
+	}
+
+	return &keySetProvider{signing: signing, retired: retired, keys: keys}, nil
+}
+
+func (p *keySetProvider) Generate(data tokenprovider.TokenPayload, expiry int) (tokenprovider.Token, error) {
+	now := time.Now()
+	t := jwt.NewWithClaims(p.signing.method, newClaims(data, now, expiry))
+	t.Header["kid"] = p.signing.ID
+
+	return signToken(t, p.signing.Private, now, expiry)
+}
+
+func (p *keySetProvider) Validate(myToken string) (tokenprovider.TokenPayload, error) {
+	return parseToken(myToken, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := p.keys[kid]
+		// The algorithm is pinned by the key, never taken from the token.
+		if !ok || token.Method != key.method {
+			return nil, tokenprovider.ErrInvalidToken
+		}
+		return key.Public, nil
+	})
+}
+
+// JWKS returns the public keys, the signing key first.
+func (p *keySetProvider) JWKS() tokenprovider.JSONWebKeySet {
+	set := tokenprovider.JSONWebKeySet{Keys: []tokenprovider.JSONWebKey{p.signing.JWK()}}
+	for _, key := range p.retired {
+		set.Keys = append(set.Keys, key.JWK())
+	}
+
+	return set
+}
@@ -0,0 +1,216 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/tokenprovider"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKeys(t *testing.T) map[string]*Key {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys := map[string]*Key{}
+	for _, private := range []any{rsaKey, ecKey, edKey} {
+		key, err := NewKey(private)
+		require.NoError(t, err)
+		keys[key.Alg()] = key
+	}
+
+	return keys
+}
+
+// TestKeySetProvider checks that every algorithm round-trips a token
+func TestKeySetProvider(t *testing.T) {
+	payload := clients.TokenPayload{UID: uuid.New(), URole: "user"}
+
+	for alg, key := range generateKeys(t) {
+		t.Run(alg, func(t *testing.T) {
+			provider, err := NewKeySetProvider(key)
+			require.NoError(t, err)
+
+			token, err := provider.Generate(payload, 60)
+			require.NoError(t, err)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token.GetToken(), &myClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, alg, parsed.Header["alg"])
+			assert.Equal(t, key.ID, parsed.Header["kid"])
+
+			validated, err := provider.Validate(token.GetToken())
+			require.NoError(t, err)
+			assert.Equal(t, payload.UID, validated.UserID())
+			assert.NotEmpty(t, validated.TokenID())
+
+			jwks := provider.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, key.ID, jwks.Keys[0].Kid)
+			assert.Equal(t, alg, jwks.Keys[0].Alg)
+		})
+	}
+}
+
+// TestKeySetRotation checks that a retired key verifies but no longer signs
+func TestKeySetRotation(t *testing.T) {
+	keys := generateKeys(t)
+	payload := clients.TokenPayload{UID: uuid.New(), URole: "user"}
+
+	before, err := NewKeySetProvider(keys["RS256"])
+	require.NoError(t, err)
+	oldToken, err := before.Generate(payload, 60)
+	require.NoError(t, err)
+
+	// The public half of the old key is enough to verify its tokens.
+	retired, err := NewKey(keys["RS256"].Public)
+	require.NoError(t, err)
+	assert.Equal(t, keys["RS256"].ID, retired.ID)
+
+	after, err := NewKeySetProvider(keys["EdDSA"], retired)
+	require.NoError(t, err)
+
+	// Test case 1: Tokens of the old key still validate
+	_, err = after.Validate(oldToken.GetToken())
+	assert.NoError(t, err)
+
+	// Test case 2: New tokens are signed by the new key
+	newToken, err := after.Generate(payload, 60)
+	require.NoError(t, err)
+	parsed, _, err := new(jwt.Parser).ParseUnverified(newToken.GetToken(), &myClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, keys["EdDSA"].ID, parsed.Header["kid"])
+
+	// Test case 3: Both keys are published, the signing key first
+	jwks := after.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, keys["EdDSA"].ID, jwks.Keys[0].Kid)
+	assert.Equal(t, keys["RS256"].ID, jwks.Keys[1].Kid)
+
+	// Test case 4: Once dropped from the set, the old key no longer verifies
+	dropped, err := NewKeySetProvider(keys["EdDSA"])
+	require.NoError(t, err)
+	_, err = dropped.Validate(oldToken.GetToken())
+	assert.ErrorIs(t, err, tokenprovider.ErrInvalidToken)
+
+	// Test case 5: A retired key cannot sign
+	_, err = NewKeySetProvider(retired)
+	assert.Error(t, err)
+}
+
+// TestKeySetRejects checks tokens the provider must not accept
+func TestKeySetRejects(t *testing.T) {
+	keys := generateKeys(t)
+	provider, err := NewKeySetProvider(keys["ES256"])
+	require.NoError(t, err)
+
+	claims := newClaims(clients.TokenPayload{UID: uuid.New()}, time.Now(), 60)
+
+	// Test case 1: A token of an unknown key
+	other, err := NewKeySetProvider(keys["EdDSA"])
+	require.NoError(t, err)
+	token, err := other.Generate(clients.TokenPayload{UID: uuid.New()}, 60)
+	require.NoError(t, err)
+	_, err = provider.Validate(token.GetToken())
+	assert.ErrorIs(t, err, tokenprovider.ErrInvalidToken)
+
+	// Test case 2: An HMAC token keyed with the public key bytes
+	public, err := x509.MarshalPKIXPublicKey(keys["ES256"].Public)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	forged.Header["kid"] = keys["ES256"].ID
+	signed, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}))
+	require.NoError(t, err)
+	_, err = provider.Validate(signed)
+	assert.ErrorIs(t, err, tokenprovider.ErrInvalidToken)
+
+	// Test case 3: An unsigned token
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	unsigned.Header["kid"] = keys["ES256"].ID
+	signed, err = unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	_, err = provider.Validate(signed)
+	assert.ErrorIs(t, err, tokenprovider.ErrInvalidToken)
+
+	// Test case 4: The signature of another token
+	token, err = provider.Generate(clients.TokenPayload{UID: uuid.New()}, 60)
+	require.NoError(t, err)
+	parts := strings.Split(token.GetToken(), ".")
+	another, err := provider.Generate(clients.TokenPayload{UID: uuid.New()}, 60)
+	require.NoError(t, err)
+	parts[2] = strings.Split(another.GetToken(), ".")[2]
+	_, err = provider.Validate(strings.Join(parts, "."))
+	assert.ErrorIs(t, err, tokenprovider.ErrInvalidToken)
+}
+
+// TestParseKey checks the PEM encodings a key can be loaded from
+func TestParseKey(t *testing.T) {
+	keys := generateKeys(t)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(keys["EdDSA"].Private)
+	require.NoError(t, err)
+	sec1, err := x509.MarshalECPrivateKey(keys["ES256"].Private.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+	pkcs1 := x509.MarshalPKCS1PrivateKey(keys["RS256"].Private.(*rsa.PrivateKey))
+	public, err := x509.MarshalPKIXPublicKey(keys["RS256"].Public)
+	require.NoError(t, err)
+
+	cases := []struct {
+		block   *pem.Block
+		id      string
+		private bool
+	}{
+		{&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}, keys["EdDSA"].ID, true},
+		{&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}, keys["ES256"].ID, true},
+		{&pem.Block{Type: "RSA PRIVATE KEY", Bytes: pkcs1}, keys["RS256"].ID, true},
+		{&pem.Block{Type: "PUBLIC KEY", Bytes: public}, keys["RS256"].ID, false},
+	}
+
+	for _, c := range cases {
+		key, err := ParseKey(pem.EncodeToMemory(c.block))
+		require.NoError(t, err, c.block.Type)
+		assert.Equal(t, c.id, key.ID, c.block.Type)
+		assert.Equal(t, c.private, key.Private != nil, c.block.Type)
+	}
+
+	// P-384 is not ES256
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, err = NewKey(p384)
+	assert.ErrorIs(t, err, ErrUnsupportedKey)
+
+	_, err = ParseKey([]byte("not a key"))
+	assert.Error(t, err)
+}
+
+// TestThumbprint checks the key id against the RFC 7638 example
+func TestThumbprint(t *testing.T) {
+	n := "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	key := tokenprovider.JSONWebKey{Kty: "RSA", N: n, E: "AQAB"}
+
+	nBytes, err := jwt.DecodeSegment(key.N)
+	require.NoError(t, err)
+	public := &rsa.PublicKey{E: 65537}
+	public.N = new(big.Int).SetBytes(nBytes)
+
+	k, err := NewKey(public)
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", k.ID)
+}
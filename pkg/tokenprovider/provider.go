@@ -2,6 +2,7 @@ package tokenprovider
 
 import (
 	"errors"
+	"time"
 	"todo-app/pkg/clients"
 
 	"github.com/google/uuid"
@@ -10,12 +11,17 @@ import (
 type Provider interface {
 	Generate(data TokenPayload, expiry int) (Token, error)
 	Validate(token string) (TokenPayload, error)
-	SecretKey() string
 }
 
+// TokenPayload is the data of a token. TokenID and ExpiresAt identify a
+// validated token, so it can be revoked before it expires. SessionID is
+// uuid.Nil for tokens issued before sessions were tracked.
 type TokenPayload interface {
 	UserID() uuid.UUID
 	Role() string
+	SessionID() uuid.UUID
+	TokenID() string
+	ExpiresAt() time.Time
 }
 
 type Token interface {
@@ -38,4 +44,9 @@ var (
 		"invalid token provided",
 		"ErrInvalidToken",
 	)
+
+	ErrTokenRevoked = clients.NewUnauthorized(errors.New("token has been revoked"),
+		"token has been revoked",
+		"ErrTokenRevoked",
+	)
 )
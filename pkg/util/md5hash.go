@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 )
 
+// md5Hash is the hasher of legacy salted MD5 passwords. New passwords are
+// hashed with argon2id or bcrypt.
 type md5Hash struct{}
 
 func NewMd5Hash() *md5Hash {
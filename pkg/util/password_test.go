@@ -0,0 +1,72 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// cheapArgon2Params keep the tests fast.
+var cheapArgon2Params = Argon2Params{Time: 1, Memory: 1024, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+// TestArgon2idHash checks the encoding and verification of argon2id hashes
+func TestArgon2idHash(t *testing.T) {
+	hasher := NewArgon2idHash(cheapArgon2Params)
+
+	encoded, err := hasher.Hash("secret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	// Test case 1: Check that the same password verifies and another does not
+	ok, err := hasher.Verify("secret", encoded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify("guess", encoded)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Test case 2: Check that hashing twice uses different salts
+	again, err := hasher.Hash("secret")
+	require.NoError(t, err)
+	assert.NotEqual(t, encoded, again)
+
+	// Test case 3: Check that other parameters or algorithms need a rehash
+	assert.False(t, hasher.NeedsRehash(encoded))
+	assert.True(t, NewArgon2idHash(DefaultArgon2Params).NeedsRehash(encoded))
+	assert.True(t, hasher.NeedsRehash("$2a$10$abcdefghijklmnopqrstuv"))
+}
+
+// TestBcryptHash checks bcrypt hashes and verifying across hashers
+func TestBcryptHash(t *testing.T) {
+	hasher := NewBcryptHash(bcrypt.MinCost)
+
+	encoded, err := hasher.Hash("secret")
+	require.NoError(t, err)
+
+	ok, err := hasher.Verify("secret", encoded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify("guess", encoded)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.False(t, hasher.NeedsRehash(encoded))
+	assert.True(t, NewBcryptHash(bcrypt.MinCost+1).NeedsRehash(encoded))
+
+	// An argon2id hash still verifies after switching to bcrypt
+	argon2, err := NewArgon2idHash(cheapArgon2Params).Hash("secret")
+	require.NoError(t, err)
+
+	ok, err = hasher.Verify("secret", argon2)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, hasher.NeedsRehash(argon2))
+
+	_, err = hasher.Verify("secret", "5ebe2294ecd0e0f08eab7690d2a6ee69")
+	assert.ErrorIs(t, err, ErrUnsupportedHash)
+}
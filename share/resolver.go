@@ -0,0 +1,70 @@
+package share
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// maxDepth bounds the walk up the parents of an item.
+const maxDepth = 32
+
+type permissionResolver struct {
+	shareRepo ShareRepo
+	items     ItemGetter
+}
+
+// NewPermissionResolver creates the resolver deciding every access to an
+// item: its owner may do anything, other users what the accepted shares of
+// the item, of its parents or of their lists grant them.
+func NewPermissionResolver(repo ShareRepo, items ItemGetter) *permissionResolver {
+	return &permissionResolver{
+		shareRepo: repo,
+		items:     items,
+	}
+}
+
+func (r *permissionResolver) ItemAccess(item domain.Item, userID uuid.UUID) (domain.Access, error) {
+	if item.UserID == userID {
+		return domain.AccessOwner, nil
+	}
+
+	var itemIDs, listIDs []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+
+	for current := item; ; {
+		seen[current.ID] = true
+		itemIDs = append(itemIDs, current.ID)
+		if current.ListID != nil {
+			listIDs = append(listIDs, *current.ListID)
+		}
+
+		if current.ParentID == nil || seen[*current.ParentID] || len(itemIDs) >= maxDepth {
+			break
+		}
+
+		parent, err := r.items.GetItem(map[string]any{"id": *current.ParentID})
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			break
+		}
+		if err != nil {
+			return domain.AccessNone, err
+		}
+
+		current = parent
+	}
+
+	grants, err := r.shareRepo.GetGrants(item.UserID, userID, itemIDs, listIDs)
+	if err != nil {
+		return domain.AccessNone, err
+	}
+
+	access := domain.AccessNone
+	for _, grant := range grants {
+		access = max(access, grant.Access())
+	}
+
+	return access, nil
+}
@@ -0,0 +1,90 @@
+package share_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/share"
+	"todo-app/share/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemAccess(t *testing.T) {
+	ownerID, userID := uuid.New(), uuid.New()
+	listID, parentID := uuid.New(), uuid.New()
+	acceptedAt := time.Now()
+
+	parent := domain.Item{ID: parentID, UserID: ownerID, ListID: &listID}
+	subtask := domain.Item{ID: uuid.New(), UserID: ownerID, ParentID: &parentID}
+
+	t.Run("owner", func(t *testing.T) {
+		shareRepo, items := new(mocks.ShareRepo), new(mocks.ItemGetter)
+		resolver := service.NewPermissionResolver(shareRepo, items)
+
+		access, err := resolver.ItemAccess(subtask, ownerID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AccessOwner, access)
+		shareRepo.AssertNotCalled(t, "GetGrants", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("shares of the parents and their lists count", func(t *testing.T) {
+		shareRepo, items := new(mocks.ShareRepo), new(mocks.ItemGetter)
+		resolver := service.NewPermissionResolver(shareRepo, items)
+
+		items.On("GetItem", map[string]any{"id": parentID}).Return(parent, nil).Once()
+		shareRepo.On("GetGrants", ownerID, userID, []uuid.UUID{subtask.ID, parentID}, []uuid.UUID{listID}).Return([]domain.Share{
+			{Role: domain.ShareViewer, AcceptedAt: &acceptedAt},
+			{Role: domain.ShareEditor, AcceptedAt: &acceptedAt},
+		}, nil).Once()
+
+		access, err := resolver.ItemAccess(subtask, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AccessEdit, access)
+	})
+
+	t.Run("no share", func(t *testing.T) {
+		shareRepo, items := new(mocks.ShareRepo), new(mocks.ItemGetter)
+		resolver := service.NewPermissionResolver(shareRepo, items)
+
+		shareRepo.On("GetGrants", ownerID, userID, []uuid.UUID{parentID}, []uuid.UUID{listID}).Return([]domain.Share{}, nil).Once()
+
+		access, err := resolver.ItemAccess(parent, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AccessNone, access)
+	})
+
+	t.Run("parent cycle", func(t *testing.T) {
+		shareRepo, items := new(mocks.ShareRepo), new(mocks.ItemGetter)
+		resolver := service.NewPermissionResolver(shareRepo, items)
+
+		a, b := uuid.New(), uuid.New()
+		itemA := domain.Item{ID: a, UserID: ownerID, ParentID: &b}
+		itemB := domain.Item{ID: b, UserID: ownerID, ParentID: &a}
+		items.On("GetItem", map[string]any{"id": b}).Return(itemB, nil).Once()
+		shareRepo.On("GetGrants", ownerID, userID, []uuid.UUID{a, b}, []uuid.UUID(nil)).Return([]domain.Share{}, nil).Once()
+
+		_, err := resolver.ItemAccess(itemA, userID)
+
+		assert.NoError(t, err)
+		items.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		shareRepo, items := new(mocks.ShareRepo), new(mocks.ItemGetter)
+		resolver := service.NewPermissionResolver(shareRepo, items)
+
+		items.On("GetItem", mock.Anything).Return(domain.Item{}, clients.ErrDB(assert.AnError)).Once()
+
+		_, err := resolver.ItemAccess(subtask, userID)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,246 @@
+package share
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name ShareRepo
+type ShareRepo interface {
+	Save(share *domain.Share) error
+	GetShare(conditions map[string]any) (*domain.Share, error)
+	ListShares(conditions map[string]any) ([]domain.Share, error)
+	Accept(id, userID uuid.UUID, acceptedAt time.Time) error
+	Delete(id uuid.UUID) error
+	// GetGrants returns the accepted shares of the owner with the user that
+	// cover any of the items or lists.
+	GetGrants(ownerID, userID uuid.UUID, itemIDs, listIDs []uuid.UUID) ([]domain.Share, error)
+}
+
+//go:generate mockery --name ItemGetter
+type ItemGetter interface {
+	GetItem(filter map[string]any) (domain.Item, error)
+}
+
+//go:generate mockery --name ListGetter
+type ListGetter interface {
+	GetList(filter map[string]any) (domain.List, error)
+}
+
+//go:generate mockery --name UserGetter
+type UserGetter interface {
+	GetUser(conditions map[string]any) (*domain.User, error)
+}
+
+// Mailer sends plain text mails.
+//
+//go:generate mockery --name Mailer
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+type shareService struct {
+	shareRepo ShareRepo
+	items     ItemGetter
+	lists     ListGetter
+	users     UserGetter
+	mailer    Mailer
+	appURL    string
+}
+
+// NewShareService creates the share service. Invitations link to the
+// invitations page under appURL.
+func NewShareService(repo ShareRepo, items ItemGetter, lists ListGetter, users UserGetter, mailer Mailer, appURL string) *shareService {
+	return &shareService{
+		shareRepo: repo,
+		items:     items,
+		lists:     lists,
+		users:     users,
+		mailer:    mailer,
+		appURL:    appURL,
+	}
+}
+
+// ShareItem invites the email to the item of the owner and its subtasks.
+// Workspace items are shared with the workspace members only.
+func (s *shareService) ShareItem(ownerID, itemID uuid.UUID, data *domain.ShareCreation) (*domain.Share, error) {
+	item, err := s.items.GetItem(map[string]any{"id": itemID, "user_id": ownerID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	if item.WorkspaceID != nil {
+		return nil, domain.ErrWorkspaceShare
+	}
+
+	if item.Status == domain.Deleted {
+		return nil, clients.ErrEntityDeleted(item.TableName(), nil)
+	}
+
+	return s.invite(ownerID, &domain.Share{ItemID: &itemID}, item.Title, data)
+}
+
+// ShareList invites the email to every item of the list of the owner.
+// Workspace lists are shared with the workspace members only.
+func (s *shareService) ShareList(ownerID, listID uuid.UUID, data *domain.ShareCreation) (*domain.Share, error) {
+	list, err := s.lists.GetList(map[string]any{"id": listID, "user_id": ownerID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(list.TableName(), err)
+	}
+
+	if list.WorkspaceID != nil {
+		return nil, domain.ErrWorkspaceShare
+	}
+
+	return s.invite(ownerID, &domain.Share{ListID: &listID}, list.Name, data)
+}
+
+func (s *shareService) invite(ownerID uuid.UUID, share *domain.Share, title string, data *domain.ShareCreation) (*domain.Share, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	owner, err := s.users.GetUser(map[string]any{"id": ownerID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if strings.EqualFold(owner.Email, data.Email) {
+		return nil, domain.ErrShareWithSelf
+	}
+
+	conditions := map[string]any{"email": data.Email}
+	if share.ItemID != nil {
+		conditions["item_id"] = *share.ItemID
+	} else {
+		conditions["list_id"] = *share.ListID
+	}
+	if _, err := s.shareRepo.GetShare(conditions); err == nil {
+		return nil, domain.ErrShareExisted
+	} else if !errors.Is(err, clients.ErrRecordNotFound) {
+		return nil, clients.ErrCannotGetEntity(share.TableName(), err)
+	}
+
+	now := time.Now()
+	share.ID = uuid.New()
+	share.OwnerID = ownerID
+	share.Email = data.Email
+	share.Role = data.Role
+	share.CreatedAt = &now
+
+	if err := s.shareRepo.Save(share); err != nil {
+		return nil, clients.ErrCannotCreateEntity(share.TableName(), err)
+	}
+
+	// The invitation also shows up in the invitations of the invitee, so a
+	// failed mail does not lose it.
+	subject := fmt.Sprintf("%s shared %q with you", owner.Email, title)
+	body := fmt.Sprintf("%s invited you to %q as %s.\n\nSee your invitations at %s/invitations\n",
+		owner.Email, title, share.Role, s.appURL)
+	if err := s.mailer.Send(share.Email, subject, body); err != nil {
+		log.Println("send invitation:", err)
+	}
+
+	return share, nil
+}
+
+// GetItemShares lists the collaborators and pending invitations of an item
+// of the owner.
+func (s *shareService) GetItemShares(ownerID, itemID uuid.UUID) ([]domain.Share, error) {
+	return s.listShares(map[string]any{"owner_id": ownerID, "item_id": itemID})
+}
+
+// GetListShares lists the collaborators and pending invitations of a list of
+// the owner.
+func (s *shareService) GetListShares(ownerID, listID uuid.UUID) ([]domain.Share, error) {
+	return s.listShares(map[string]any{"owner_id": ownerID, "list_id": listID})
+}
+
+// GetInvitations lists the pending invitations to the email of the user.
+func (s *shareService) GetInvitations(userID uuid.UUID) ([]domain.Share, error) {
+	user, err := s.users.GetUser(map[string]any{"id": userID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	return s.listShares(map[string]any{"email": strings.ToLower(user.Email), "accepted_at": nil})
+}
+
+func (s *shareService) listShares(conditions map[string]any) ([]domain.Share, error) {
+	shares, err := s.shareRepo.ListShares(conditions)
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Share{}.TableName(), err)
+	}
+
+	return shares, nil
+}
+
+// AcceptInvitation binds an invitation to the user it was sent to. The email
+// has to be verified, so nobody can claim invitations by registering with
+// someone else's email.
+func (s *shareService) AcceptInvitation(userID, id uuid.UUID) error {
+	user, err := s.users.GetUser(map[string]any{"id": userID})
+	if err != nil {
+		return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.EmailVerifiedAt == nil {
+		return domain.ErrEmailNotVerified
+	}
+
+	share, err := s.shareRepo.GetShare(map[string]any{"id": id, "email": strings.ToLower(user.Email)})
+	if err != nil {
+		return clients.ErrCannotGetEntity(domain.Share{}.TableName(), err)
+	}
+
+	if share.AcceptedAt != nil {
+		return domain.ErrShareAccepted
+	}
+
+	if err := s.shareRepo.Accept(id, userID, time.Now()); err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return domain.ErrShareAccepted
+		}
+
+		return clients.ErrCannotUpdateEntity(share.TableName(), err)
+	}
+
+	return nil
+}
+
+// DeleteShare revokes a share as its owner, leaves it as its collaborator or
+// declines it as the invitee.
+func (s *shareService) DeleteShare(userID, id uuid.UUID) error {
+	share, err := s.shareRepo.GetShare(map[string]any{"id": id})
+	if err != nil {
+		return clients.ErrCannotGetEntity(domain.Share{}.TableName(), err)
+	}
+
+	allowed := share.OwnerID == userID || (share.UserID != nil && *share.UserID == userID)
+	if !allowed && share.AcceptedAt == nil {
+		user, err := s.users.GetUser(map[string]any{"id": userID})
+		if err != nil {
+			return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+		}
+
+		allowed = strings.EqualFold(user.Email, share.Email)
+	}
+
+	// Shares of others are reported as not found, not to reveal them.
+	if !allowed {
+		return clients.ErrCannotGetEntity(share.TableName(), clients.ErrRecordNotFound)
+	}
+
+	if err := s.shareRepo.Delete(id); err != nil {
+		return clients.ErrCannotDeleteEntity(share.TableName(), err)
+	}
+
+	return nil
+}
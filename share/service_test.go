@@ -0,0 +1,238 @@
+package share_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/share"
+	"todo-app/share/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type shareMocks struct {
+	shareRepo *mocks.ShareRepo
+	items     *mocks.ItemGetter
+	lists     *mocks.ListGetter
+	users     *mocks.UserGetter
+	mailer    *mocks.Mailer
+}
+
+// shareService lists the methods under test, as the service type itself is
+// unexported.
+type shareService interface {
+	ShareItem(ownerID, itemID uuid.UUID, data *domain.ShareCreation) (*domain.Share, error)
+	ShareList(ownerID, listID uuid.UUID, data *domain.ShareCreation) (*domain.Share, error)
+	GetInvitations(userID uuid.UUID) ([]domain.Share, error)
+	AcceptInvitation(userID, id uuid.UUID) error
+	DeleteShare(userID, id uuid.UUID) error
+}
+
+func setupShareService() (*shareMocks, shareService) {
+	m := &shareMocks{new(mocks.ShareRepo), new(mocks.ItemGetter), new(mocks.ListGetter), new(mocks.UserGetter), new(mocks.Mailer)}
+
+	return m, service.NewShareService(m.shareRepo, m.items, m.lists, m.users, m.mailer, "https://todo.example.com")
+}
+
+func TestShareItem(t *testing.T) {
+	owner := &domain.User{ID: uuid.New(), Email: "owner@example.com"}
+	itemID := uuid.New()
+
+	t.Run("invites the email", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		m.items.On("GetItem", map[string]any{"id": itemID, "user_id": owner.ID}).
+			Return(domain.Item{ID: itemID, UserID: owner.ID, Title: "Groceries", Status: domain.Active}, nil).Once()
+		m.users.On("GetUser", map[string]any{"id": owner.ID}).Return(owner, nil).Once()
+		m.shareRepo.On("GetShare", map[string]any{"item_id": itemID, "email": "ann@example.com"}).Return(nil, clients.ErrRecordNotFound).Once()
+		m.shareRepo.On("Save", mock.MatchedBy(func(share *domain.Share) bool {
+			return share.OwnerID == owner.ID && *share.ItemID == itemID && share.ListID == nil &&
+				share.Email == "ann@example.com" && share.Role == domain.ShareEditor && share.AcceptedAt == nil
+		})).Return(nil).Once()
+		m.mailer.On("Send", "ann@example.com", mock.MatchedBy(func(subject string) bool {
+			return strings.Contains(subject, "Groceries")
+		}), mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "https://todo.example.com/invitations")
+		})).Return(nil).Once()
+
+		share, err := shareService.ShareItem(owner.ID, itemID, &domain.ShareCreation{Email: " Ann@Example.com ", Role: domain.ShareEditor})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ann@example.com", share.Email)
+		m.shareRepo.AssertExpectations(t)
+		m.mailer.AssertExpectations(t)
+	})
+
+	t.Run("only the owner can share", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		m.items.On("GetItem", mock.Anything).Return(domain.Item{}, clients.ErrRecordNotFound).Once()
+
+		_, err := shareService.ShareItem(uuid.New(), itemID, &domain.ShareCreation{Email: "ann@example.com"})
+
+		assert.Error(t, err)
+		m.shareRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("with oneself", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		m.items.On("GetItem", mock.Anything).Return(domain.Item{ID: itemID, UserID: owner.ID, Status: domain.Active}, nil).Once()
+		m.users.On("GetUser", mock.Anything).Return(owner, nil).Once()
+
+		_, err := shareService.ShareItem(owner.ID, itemID, &domain.ShareCreation{Email: "OWNER@example.com"})
+
+		assert.ErrorIs(t, err, domain.ErrShareWithSelf)
+	})
+
+	t.Run("twice", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		m.items.On("GetItem", mock.Anything).Return(domain.Item{ID: itemID, UserID: owner.ID, Status: domain.Active}, nil).Once()
+		m.users.On("GetUser", mock.Anything).Return(owner, nil).Once()
+		m.shareRepo.On("GetShare", mock.Anything).Return(&domain.Share{ID: uuid.New()}, nil).Once()
+
+		_, err := shareService.ShareItem(owner.ID, itemID, &domain.ShareCreation{Email: "ann@example.com"})
+
+		assert.ErrorIs(t, err, domain.ErrShareExisted)
+		m.shareRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("workspace item", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		workspaceID := uuid.New()
+		m.items.On("GetItem", mock.Anything).Return(domain.Item{ID: itemID, UserID: owner.ID, WorkspaceID: &workspaceID, Status: domain.Active}, nil).Once()
+
+		_, err := shareService.ShareItem(owner.ID, itemID, &domain.ShareCreation{Email: "ann@example.com"})
+
+		assert.ErrorIs(t, err, domain.ErrWorkspaceShare)
+		m.shareRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		m.items.On("GetItem", mock.Anything).Return(domain.Item{ID: itemID, UserID: owner.ID, Status: domain.Active}, nil).Once()
+
+		_, err := shareService.ShareItem(owner.ID, itemID, &domain.ShareCreation{Email: "ann@example.com", Role: "admin"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "role must be viewer or editor")
+	})
+}
+
+func TestShareList(t *testing.T) {
+	m, shareService := setupShareService()
+
+	owner := &domain.User{ID: uuid.New(), Email: "owner@example.com"}
+	listID := uuid.New()
+
+	m.lists.On("GetList", map[string]any{"id": listID, "user_id": owner.ID}).
+		Return(domain.List{ID: listID, UserID: owner.ID, Name: "Home"}, nil).Once()
+	m.users.On("GetUser", mock.Anything).Return(owner, nil).Once()
+	m.shareRepo.On("GetShare", map[string]any{"list_id": listID, "email": "ann@example.com"}).Return(nil, clients.ErrRecordNotFound).Once()
+	m.shareRepo.On("Save", mock.MatchedBy(func(share *domain.Share) bool {
+		return *share.ListID == listID && share.ItemID == nil && share.Role == domain.ShareViewer
+	})).Return(nil).Once()
+	m.mailer.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := shareService.ShareList(owner.ID, listID, &domain.ShareCreation{Email: "ann@example.com"})
+
+	assert.NoError(t, err)
+	m.shareRepo.AssertExpectations(t)
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	verifiedAt := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "Ann@example.com", EmailVerifiedAt: &verifiedAt}
+	shareID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		m.users.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+		m.shareRepo.On("GetShare", map[string]any{"id": shareID, "email": "ann@example.com"}).
+			Return(&domain.Share{ID: shareID, Email: "ann@example.com"}, nil).Once()
+		m.shareRepo.On("Accept", shareID, user.ID, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, shareService.AcceptInvitation(user.ID, shareID))
+		m.shareRepo.AssertExpectations(t)
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		unverified := &domain.User{ID: user.ID, Email: user.Email}
+		m.users.On("GetUser", mock.Anything).Return(unverified, nil).Once()
+
+		err := shareService.AcceptInvitation(user.ID, shareID)
+
+		assert.ErrorIs(t, err, domain.ErrEmailNotVerified)
+		m.shareRepo.AssertNotCalled(t, "Accept", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invitation of someone else", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		m.users.On("GetUser", mock.Anything).Return(user, nil).Once()
+		m.shareRepo.On("GetShare", mock.Anything).Return(nil, clients.ErrRecordNotFound).Once()
+
+		assert.Error(t, shareService.AcceptInvitation(user.ID, shareID))
+		m.shareRepo.AssertNotCalled(t, "Accept", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepted already", func(t *testing.T) {
+		m, shareService := setupShareService()
+
+		m.users.On("GetUser", mock.Anything).Return(user, nil).Once()
+		m.shareRepo.On("GetShare", mock.Anything).Return(&domain.Share{ID: shareID, AcceptedAt: &verifiedAt}, nil).Once()
+
+		assert.ErrorIs(t, shareService.AcceptInvitation(user.ID, shareID), domain.ErrShareAccepted)
+	})
+}
+
+func TestDeleteShare(t *testing.T) {
+	ownerID, collaboratorID := uuid.New(), uuid.New()
+	acceptedAt := time.Now()
+	accepted := &domain.Share{ID: uuid.New(), OwnerID: ownerID, UserID: &collaboratorID, Email: "ann@example.com", AcceptedAt: &acceptedAt}
+	pending := &domain.Share{ID: uuid.New(), OwnerID: ownerID, Email: "ann@example.com"}
+
+	cases := []struct {
+		name    string
+		share   *domain.Share
+		userID  uuid.UUID
+		email   string
+		allowed bool
+	}{
+		{"owner revokes", accepted, ownerID, "", true},
+		{"collaborator leaves", accepted, collaboratorID, "", true},
+		{"invitee declines", pending, uuid.New(), "ann@example.com", true},
+		{"stranger", pending, uuid.New(), "bob@example.com", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m, shareService := setupShareService()
+
+			m.shareRepo.On("GetShare", map[string]any{"id": c.share.ID}).Return(c.share, nil).Once()
+			m.users.On("GetUser", map[string]any{"id": c.userID}).Return(&domain.User{ID: c.userID, Email: c.email}, nil).Maybe()
+			m.shareRepo.On("Delete", c.share.ID).Return(nil).Maybe()
+
+			err := shareService.DeleteShare(c.userID, c.share.ID)
+
+			if c.allowed {
+				assert.NoError(t, err)
+				m.shareRepo.AssertCalled(t, "Delete", c.share.ID)
+			} else {
+				assert.Error(t, err)
+				m.shareRepo.AssertNotCalled(t, "Delete", mock.Anything)
+			}
+		})
+	}
+}
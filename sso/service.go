@@ -0,0 +1,125 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/oidc"
+)
+
+// stateExpiry bounds the time a user has to log in at the provider.
+const stateExpiry = 10 * time.Minute
+
+// IdentityProvider runs the authorization code flow of an OpenID Connect
+// provider.
+//
+//go:generate mockery --name IdentityProvider
+type IdentityProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier string) (*oidc.IDToken, error)
+}
+
+// StateStore keeps the logins in progress by their state parameter. Take
+// returns a state at most once and fails with ErrRecordNotFound afterwards.
+//
+//go:generate mockery --name StateStore
+type StateStore interface {
+	Save(state string, data *domain.SSOState, ttl time.Duration) error
+	Take(state string) (*domain.SSOState, error)
+}
+
+// UserLogin logs in the user vouched for by the provider.
+//
+//go:generate mockery --name UserLogin
+type UserLogin interface {
+	LoginExternal(identity *domain.ExternalIdentity, client domain.ClientInfo) (*domain.LoginResult, error)
+}
+
+type ssoService struct {
+	provider IdentityProvider
+	states   StateStore
+	users    UserLogin
+}
+
+func NewSSOService(provider IdentityProvider, states StateStore, users UserLogin) *ssoService {
+	return &ssoService{
+		provider: provider,
+		states:   states,
+		users:    users,
+	}
+}
+
+// Start begins a login at the provider. The state, nonce and PKCE verifier
+// are kept on the server, so the callback can only complete a login started
+// here.
+func (s *ssoService) Start(ctx context.Context) (*domain.SSOStart, error) {
+	state, err := oidc.RandomString()
+	if err != nil {
+		return nil, clients.ErrInternal(err)
+	}
+
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return nil, clients.ErrInternal(err)
+	}
+
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return nil, clients.ErrInternal(err)
+	}
+
+	if err := s.states.Save(state, &domain.SSOState{Nonce: nonce, CodeVerifier: verifier}, stateExpiry); err != nil {
+		return nil, clients.ErrInternal(err)
+	}
+
+	authURL, err := s.provider.AuthCodeURL(ctx, state, nonce, oidc.Challenge(verifier))
+	if err != nil {
+		return nil, clients.ErrInternal(err)
+	}
+
+	return &domain.SSOStart{AuthURL: authURL}, nil
+}
+
+// Callback completes a login with the parameters the provider sent the user
+// back with.
+func (s *ssoService) Callback(ctx context.Context, data *domain.SSOCallback) (*domain.LoginResult, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	state, err := s.states.Take(data.State)
+	if err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return nil, domain.ErrSSOStateInvalid
+		}
+
+		return nil, clients.ErrInternal(err)
+	}
+
+	if data.Error != "" {
+		log.Printf("sso: provider returned %s: %s", data.Error, data.ErrorDescription)
+		return nil, domain.ErrSSOLoginFailed
+	}
+
+	token, err := s.provider.Exchange(ctx, data.Code, state.CodeVerifier)
+	if err != nil {
+		log.Println("sso:", err)
+		return nil, domain.ErrSSOLoginFailed
+	}
+
+	if token.Nonce != state.Nonce {
+		return nil, domain.ErrSSOLoginFailed
+	}
+
+	return s.users.LoginExternal(&domain.ExternalIdentity{
+		Issuer:        token.Issuer,
+		Subject:       token.Subject,
+		Email:         token.Email,
+		EmailVerified: token.EmailVerified,
+		FirstName:     token.GivenName,
+		LastName:      token.FamilyName,
+	}, data.Client)
+}
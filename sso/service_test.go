@@ -0,0 +1,145 @@
+package sso_test
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/oidc"
+	"todo-app/pkg/oidc/oidctest"
+	service "todo-app/sso"
+	"todo-app/sso/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mapStates is an in-memory StateStore.
+type mapStates struct {
+	mutex  sync.Mutex
+	states map[string]domain.SSOState
+}
+
+func (s *mapStates) Save(state string, data *domain.SSOState, _ time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.states[state] = *data
+	return nil
+}
+
+func (s *mapStates) Take(state string) (*domain.SSOState, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, ok := s.states[state]
+	if !ok {
+		return nil, clients.ErrRecordNotFound
+	}
+	delete(s.states, state)
+
+	return &data, nil
+}
+
+type ssoService interface {
+	Start(ctx context.Context) (*domain.SSOStart, error)
+	Callback(ctx context.Context, data *domain.SSOCallback) (*domain.LoginResult, error)
+}
+
+// setupSSOService runs the service against an in-process provider, so the
+// whole flow is exercised without network.
+func setupSSOService(t *testing.T) (*oidctest.Provider, *mocks.UserLogin, ssoService) {
+	idp := oidctest.NewProvider("todo-app", "secret")
+	t.Cleanup(idp.Close)
+
+	provider := oidc.NewProvider(oidc.Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     "todo-app",
+		ClientSecret: "secret",
+		RedirectURL:  "https://app.example.com/sso/callback",
+	}, idp.Client())
+
+	users := new(mocks.UserLogin)
+
+	return idp, users, service.NewSSOService(provider, &mapStates{states: map[string]domain.SSOState{}}, users)
+}
+
+func TestSSOLogin(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		idp, users, ssoService := setupSSOService(t)
+		idp.SetIdentity(oidctest.Identity{Subject: "42", Email: "ann@example.com", EmailVerified: true, GivenName: "Ann", FamilyName: "Lee"})
+
+		result := &domain.LoginResult{TokenPair: &domain.TokenPair{RefreshToken: "refresh"}}
+		client := domain.ClientInfo{UserAgent: "Firefox", IP: "203.0.113.7"}
+		users.On("LoginExternal", &domain.ExternalIdentity{
+			Issuer: idp.Issuer(), Subject: "42", Email: "ann@example.com", EmailVerified: true, FirstName: "Ann", LastName: "Lee",
+		}, client).Return(result, nil).Once()
+
+		start, err := ssoService.Start(context.Background())
+		require.NoError(t, err)
+
+		code, state, err := idp.Authorize(start.AuthURL)
+		require.NoError(t, err)
+
+		login, err := ssoService.Callback(context.Background(), &domain.SSOCallback{Code: code, State: state, Client: client})
+
+		require.NoError(t, err)
+		assert.Equal(t, result, login)
+		users.AssertExpectations(t)
+
+		// The state works once
+		_, err = ssoService.Callback(context.Background(), &domain.SSOCallback{Code: code, State: state})
+		assert.Equal(t, domain.ErrSSOStateInvalid, err)
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		_, users, ssoService := setupSSOService(t)
+
+		_, err := ssoService.Callback(context.Background(), &domain.SSOCallback{Code: "code", State: "forged"})
+
+		assert.Equal(t, domain.ErrSSOStateInvalid, err)
+		users.AssertNotCalled(t, "LoginExternal", mock.Anything, mock.Anything)
+	})
+
+	t.Run("denied at the provider", func(t *testing.T) {
+		_, users, ssoService := setupSSOService(t)
+
+		start, err := ssoService.Start(context.Background())
+		require.NoError(t, err)
+		state := stateOf(t, start.AuthURL)
+
+		_, err = ssoService.Callback(context.Background(), &domain.SSOCallback{State: state, Error: "access_denied"})
+
+		assert.Equal(t, domain.ErrSSOLoginFailed, err)
+		users.AssertNotCalled(t, "LoginExternal", mock.Anything, mock.Anything)
+	})
+
+	t.Run("code of another login", func(t *testing.T) {
+		idp, users, ssoService := setupSSOService(t)
+
+		first, err := ssoService.Start(context.Background())
+		require.NoError(t, err)
+		second, err := ssoService.Start(context.Background())
+		require.NoError(t, err)
+
+		code, _, err := idp.Authorize(first.AuthURL)
+		require.NoError(t, err)
+
+		// The verifier of the second login does not match the code
+		_, err = ssoService.Callback(context.Background(), &domain.SSOCallback{Code: code, State: stateOf(t, second.AuthURL)})
+
+		assert.Equal(t, domain.ErrSSOLoginFailed, err)
+		users.AssertNotCalled(t, "LoginExternal", mock.Anything, mock.Anything)
+	})
+}
+
+func stateOf(t *testing.T, authURL string) string {
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+
+	return parsed.Query().Get("state")
+}
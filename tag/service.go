@@ -0,0 +1,107 @@
+package tag
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name TagRepo
+type TagRepo interface {
+	Save(tag *domain.TagCreation) error
+	GetTags(filter map[string]any) ([]domain.Tag, error)
+	GetTag(filter map[string]any) (domain.Tag, error)
+	Update(filter map[string]any, tag *domain.TagUpdate) error
+	Delete(filter map[string]any) error
+}
+
+type tagService struct {
+	tagRepo TagRepo
+}
+
+func NewTagService(repo TagRepo) *tagService {
+	return &tagService{
+		tagRepo: repo,
+	}
+}
+
+func (s *tagService) CreateTag(tag *domain.TagCreation) error {
+	if err := tag.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	if err := s.checkNameFree(tag.UserID, tag.Name, uuid.Nil); err != nil {
+		return err
+	}
+
+	tag.ID = uuid.New()
+	if err := s.tagRepo.Save(tag); err != nil {
+		return clients.ErrCannotCreateEntity(tag.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *tagService) GetAllTag(userID uuid.UUID) ([]domain.Tag, error) {
+	tags, err := s.tagRepo.GetTags(map[string]any{"user_id": userID})
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Tag{}.TableName(), err)
+	}
+
+	return tags, nil
+}
+
+func (s *tagService) UpdateTag(id, userID uuid.UUID, tagUpdate *domain.TagUpdate) error {
+	if err := tagUpdate.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	tagUpdate.UpdatedAt = time.Now()
+
+	if _, err := s.tagRepo.GetTag(map[string]any{"id": id, "user_id": userID}); err != nil {
+		return clients.ErrCannotGetEntity(tagUpdate.TableName(), err)
+	}
+
+	if tagUpdate.Name != nil {
+		if err := s.checkNameFree(userID, *tagUpdate.Name, id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.tagRepo.Update(map[string]any{"id": id}, tagUpdate); err != nil {
+		return clients.ErrCannotUpdateEntity(tagUpdate.TableName(), err)
+	}
+
+	return nil
+}
+
+// DeleteTag deletes the tag and detaches it from every item.
+func (s *tagService) DeleteTag(id, userID uuid.UUID) error {
+	if err := s.tagRepo.Delete(map[string]any{"id": id, "user_id": userID}); err != nil {
+		return clients.ErrCannotDeleteEntity(domain.Tag{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// checkNameFree makes sure no other tag of the user, apart from exceptID, is
+// already called name.
+func (s *tagService) checkNameFree(userID uuid.UUID, name string, exceptID uuid.UUID) error {
+	existing, err := s.tagRepo.GetTag(map[string]any{"user_id": userID, "name": name})
+	if err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return nil
+		}
+
+		return clients.ErrCannotGetEntity(domain.Tag{}.TableName(), err)
+	}
+
+	if existing.ID != exceptID {
+		return clients.ErrEntityExisted(domain.Tag{}.TableName(), nil)
+	}
+
+	return nil
+}
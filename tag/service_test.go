@@ -0,0 +1,139 @@
+package tag_test
+
+import (
+	"errors"
+	"testing"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/tag"
+	"todo-app/tag/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTag(t *testing.T) {
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	t.Run("success with default color", func(t *testing.T) {
+		mockTagRepo := new(mocks.TagRepo)
+		tagService := service.NewTagService(mockTagRepo)
+		tag := &domain.TagCreation{UserID: userID, Name: " urgent "}
+
+		mockTagRepo.On("GetTag", map[string]any{"user_id": userID, "name": "urgent"}).
+			Return(domain.Tag{}, clients.ErrRecordNotFound).Once()
+		mockTagRepo.On("Save", tag).Return(nil).Once()
+
+		// Call the service method
+		err := tagService.CreateTag(tag)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, tag.ID)
+		assert.Equal(t, "urgent", tag.Name)
+		assert.Equal(t, domain.DefaultTagColor, tag.Color)
+		mockTagRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		mockTagRepo := new(mocks.TagRepo)
+		tagService := service.NewTagService(mockTagRepo)
+
+		// Call the service method
+		err := tagService.CreateTag(&domain.TagCreation{UserID: userID, Name: "urgent", Color: "red"})
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "color must be a hex color")
+	})
+
+	t.Run("error - name taken", func(t *testing.T) {
+		mockTagRepo := new(mocks.TagRepo)
+		tagService := service.NewTagService(mockTagRepo)
+
+		mockTagRepo.On("GetTag", mock.Anything).Return(domain.Tag{ID: uuid.New(), Name: "urgent"}, nil).Once()
+
+		// Call the service method
+		err := tagService.CreateTag(&domain.TagCreation{UserID: userID, Name: "urgent"})
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Equal(t, "ErrtagsAlreadyExists", err.(*clients.AppError).Key)
+		mockTagRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestUpdateTag(t *testing.T) {
+	tagID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	color := "#ff8800"
+
+	t.Run("success", func(t *testing.T) {
+		mockTagRepo := new(mocks.TagRepo)
+		tagService := service.NewTagService(mockTagRepo)
+
+		mockTagRepo.On("GetTag", map[string]any{"id": tagID, "user_id": userID}).
+			Return(domain.Tag{ID: tagID, UserID: userID}, nil).Once()
+		mockTagRepo.On("Update", map[string]any{"id": tagID}, mock.Anything).Return(nil).Once()
+
+		// Call the service method
+		err := tagService.UpdateTag(tagID, userID, &domain.TagUpdate{Color: &color})
+
+		// Assertions
+		assert.NoError(t, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - keeping its own name", func(t *testing.T) {
+		mockTagRepo := new(mocks.TagRepo)
+		tagService := service.NewTagService(mockTagRepo)
+		name := "urgent"
+
+		mockTagRepo.On("GetTag", map[string]any{"id": tagID, "user_id": userID}).
+			Return(domain.Tag{ID: tagID, UserID: userID}, nil).Once()
+		mockTagRepo.On("GetTag", map[string]any{"user_id": userID, "name": name}).
+			Return(domain.Tag{ID: tagID, UserID: userID, Name: name}, nil).Once()
+		mockTagRepo.On("Update", map[string]any{"id": tagID}, mock.Anything).Return(nil).Once()
+
+		// Call the service method
+		err := tagService.UpdateTag(tagID, userID, &domain.TagUpdate{Name: &name})
+
+		// Assertions
+		assert.NoError(t, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteTag(t *testing.T) {
+	tagID := uuid.New()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	t.Run("success", func(t *testing.T) {
+		mockTagRepo := new(mocks.TagRepo)
+		tagService := service.NewTagService(mockTagRepo)
+
+		mockTagRepo.On("Delete", map[string]any{"id": tagID, "user_id": userID}).Return(nil).Once()
+
+		// Call the service method
+		err := tagService.DeleteTag(tagID, userID)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - repository error", func(t *testing.T) {
+		mockTagRepo := new(mocks.TagRepo)
+		tagService := service.NewTagService(mockTagRepo)
+
+		mockTagRepo.On("Delete", mock.Anything).Return(errors.New("cannot delete entity")).Once()
+
+		// Call the service method
+		err := tagService.DeleteTag(tagID, userID)
+
+		// Assertions
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot delete entity")
+	})
+}
@@ -0,0 +1,192 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// Lifetimes of the tokens mailed to users.
+const (
+	verificationTokenExpiry  = 48 * time.Hour
+	passwordResetTokenExpiry = time.Hour
+)
+
+// VerifyEmail marks the email of the user holding a verification token as
+// verified.
+func (s *userService) VerifyEmail(data *domain.VerifyEmailRequest) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	token, err := s.consumeToken(domain.TokenPurposeEmailVerification, data.Token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.MarkEmailVerified(token.UserID, time.Now()); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// ResendVerification mails a new verification link. Unknown and already
+// verified emails are ignored silently, so the endpoint does not reveal which
+// emails have an account.
+func (s *userService) ResendVerification(data *domain.EmailRequest) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	user, err := s.userRepo.GetUser(map[string]any{"email": data.Email})
+	if err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return nil
+		}
+
+		return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	// A failure is only logged: only unverified accounts get this far, so an
+	// error would tell which emails have one.
+	if err := s.sendVerification(user.ID, user.Email); err != nil {
+		log.Println("send verification mail:", err)
+	}
+
+	return nil
+}
+
+// ForgotPassword mails a password reset link. Like ResendVerification, it
+// succeeds for unknown emails.
+func (s *userService) ForgotPassword(data *domain.EmailRequest) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	user, err := s.userRepo.GetUser(map[string]any{"email": data.Email})
+	if err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return nil
+		}
+
+		return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	token, err := s.issueToken(user.ID, domain.TokenPurposePasswordReset, passwordResetTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Someone asked to reset the password of your account.\n\n"+
+		"Choose a new password within the next hour:\n%s\n\n"+
+		"If it was not you, ignore this mail and your password stays the same.\n", s.link("/reset-password", token))
+
+	// A failure is only logged: answering differently for registered emails
+	// would tell which of them have an account.
+	if err := s.mailer.Send(user.Email, "Reset your password", body); err != nil {
+		log.Println("send password reset mail:", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password for the user holding a reset token and
+// revokes their refresh tokens, signing out every other device. Receiving the
+// reset mail also proves the user owns the email.
+func (s *userService) ResetPassword(data *domain.ResetPasswordRequest) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	token, err := s.consumeToken(domain.TokenPurposePasswordReset, data.Token)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := s.hasher.Hash(data.Password)
+	if err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	if err := s.userRepo.UpdatePassword(token.UserID, hashed); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	now := time.Now()
+	if err := s.tokenRepo.RevokeRefreshTokens(map[string]any{"user_id": token.UserID}, now); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.RefreshToken{}.TableName(), err)
+	}
+
+	if err := s.userRepo.MarkEmailVerified(token.UserID, now); err != nil {
+		log.Println("verify email on reset:", err)
+	}
+
+	return nil
+}
+
+func (s *userService) sendVerification(userID uuid.UUID, email string) error {
+	token, err := s.issueToken(userID, domain.TokenPurposeEmailVerification, verificationTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Welcome to Todo App!\n\n"+
+		"Confirm your email within the next two days:\n%s\n", s.link("/verify-email", token))
+
+	if err := s.mailer.Send(email, "Verify your email", body); err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	return nil
+}
+
+// issueToken stores a new single-use token and returns its plain value.
+func (s *userService) issueToken(userID uuid.UUID, purpose string, expiry time.Duration) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	record := &domain.UserToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: domain.HashToken(token),
+		ExpiresAt: s.now().Add(expiry),
+	}
+
+	if err := s.tokenRepo.SaveUserToken(record); err != nil {
+		return "", clients.ErrCannotCreateEntity(record.TableName(), err)
+	}
+
+	return token, nil
+}
+
+func (s *userService) consumeToken(purpose, token string) (*domain.UserToken, error) {
+	record, err := s.tokenRepo.ConsumeUserToken(purpose, domain.HashToken(token), s.now())
+	if err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return nil, domain.ErrUserTokenInvalid
+		}
+
+		return nil, clients.ErrCannotUpdateEntity(domain.UserToken{}.TableName(), err)
+	}
+
+	return record, nil
+}
+
+// link returns the URL of an app page carrying token.
+func (s *userService) link(path, token string) string {
+	return s.cfg.AppURL + path + "?token=" + url.QueryEscape(token)
+}
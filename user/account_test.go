@@ -0,0 +1,154 @@
+package user_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	userID := uuid.New()
+	hash := domain.HashToken("token")
+
+	t.Run("success", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.tokenRepo.On("ConsumeUserToken", domain.TokenPurposeEmailVerification, hash, mock.Anything).
+			Return(&domain.UserToken{UserID: userID}, nil).Once()
+		m.userRepo.On("MarkEmailVerified", userID, mock.Anything).Return(nil).Once()
+
+		err := userService.VerifyEmail(&domain.VerifyEmailRequest{Token: "token"})
+
+		assert.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("used or expired token", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.tokenRepo.On("ConsumeUserToken", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, clients.ErrRecordNotFound).Once()
+
+		err := userService.VerifyEmail(&domain.VerifyEmailRequest{Token: "token"})
+
+		assert.ErrorIs(t, err, domain.ErrUserTokenInvalid)
+		m.userRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything)
+	})
+}
+
+func TestResendVerification(t *testing.T) {
+	t.Run("mails a verification link", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{AppURL: "https://todo.example.com"})
+
+		user := &domain.User{ID: uuid.New(), Email: "a@example.com"}
+		m.userRepo.On("GetUser", map[string]any{"email": user.Email}).Return(user, nil).Once()
+		m.tokenRepo.On("SaveUserToken", mock.MatchedBy(func(token *domain.UserToken) bool {
+			return token.UserID == user.ID && token.Purpose == domain.TokenPurposeEmailVerification
+		})).Return(nil).Once()
+		m.mailer.On("Send", user.Email, "Verify your email", mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "https://todo.example.com/verify-email?token=")
+		})).Return(nil).Once()
+
+		err := userService.ResendVerification(&domain.EmailRequest{Email: user.Email})
+
+		assert.NoError(t, err)
+		m.mailer.AssertExpectations(t)
+	})
+
+	t.Run("a failed mail succeeds silently", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		user := &domain.User{ID: uuid.New(), Email: "a@example.com"}
+		m.userRepo.On("GetUser", map[string]any{"email": user.Email}).Return(user, nil).Once()
+		m.tokenRepo.On("SaveUserToken", mock.Anything).Return(nil).Once()
+		m.mailer.On("Send", user.Email, "Verify your email", mock.Anything).Return(errors.New("smtp down")).Once()
+
+		err := userService.ResendVerification(&domain.EmailRequest{Email: user.Email})
+
+		assert.NoError(t, err)
+		m.mailer.AssertExpectations(t)
+	})
+}
+
+func TestForgotPassword(t *testing.T) {
+	t.Run("mails a reset link", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{AppURL: "https://todo.example.com"})
+
+		user := &domain.User{ID: uuid.New(), Email: "a@example.com"}
+		m.userRepo.On("GetUser", map[string]any{"email": user.Email}).Return(user, nil).Once()
+		m.tokenRepo.On("SaveUserToken", mock.MatchedBy(func(token *domain.UserToken) bool {
+			return token.UserID == user.ID && token.Purpose == domain.TokenPurposePasswordReset
+		})).Return(nil).Once()
+		m.mailer.On("Send", user.Email, "Reset your password", mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "https://todo.example.com/reset-password?token=")
+		})).Return(nil).Once()
+
+		err := userService.ForgotPassword(&domain.EmailRequest{Email: " a@example.com "})
+
+		assert.NoError(t, err)
+		m.mailer.AssertExpectations(t)
+	})
+
+	t.Run("a failed mail succeeds silently", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		user := &domain.User{ID: uuid.New(), Email: "a@example.com"}
+		m.userRepo.On("GetUser", map[string]any{"email": user.Email}).Return(user, nil).Once()
+		m.tokenRepo.On("SaveUserToken", mock.Anything).Return(nil).Once()
+		m.mailer.On("Send", user.Email, "Reset your password", mock.Anything).Return(errors.New("smtp down")).Once()
+
+		err := userService.ForgotPassword(&domain.EmailRequest{Email: user.Email})
+
+		assert.NoError(t, err)
+		m.mailer.AssertExpectations(t)
+	})
+
+	t.Run("unknown email succeeds silently", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.userRepo.On("GetUser", mock.Anything).Return(nil, clients.ErrRecordNotFound).Once()
+
+		err := userService.ForgotPassword(&domain.EmailRequest{Email: "nobody@example.com"})
+
+		assert.NoError(t, err)
+		m.mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("sets the password and signs out", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.tokenRepo.On("ConsumeUserToken", domain.TokenPurposePasswordReset, domain.HashToken("token"), mock.Anything).
+			Return(&domain.UserToken{UserID: userID}, nil).Once()
+		m.userRepo.On("UpdatePassword", userID, mock.MatchedBy(func(password string) bool {
+			return strings.HasPrefix(password, "$2a$")
+		})).Return(nil).Once()
+		m.tokenRepo.On("RevokeRefreshTokens", map[string]any{"user_id": userID}, mock.Anything).Return(nil).Once()
+		m.userRepo.On("MarkEmailVerified", userID, mock.Anything).Return(nil).Once()
+
+		err := userService.ResetPassword(&domain.ResetPasswordRequest{Token: "token", Password: "new secret"})
+
+		assert.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		_, userService := setupUserService(service.Config{})
+
+		err := userService.ResetPassword(&domain.ResetPasswordRequest{Token: "token"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "password can not be null")
+	})
+}
@@ -0,0 +1,103 @@
+package user
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyTouchInterval bounds how often the last use of a key is written, so
+// a busy script does not cost a write per request.
+const apiKeyTouchInterval = time.Minute
+
+// apiKeyPrefixLength is the number of characters of a key kept in clear.
+const apiKeyPrefixLength = len(domain.APIKeyPrefix) + 8
+
+// CreateAPIKey creates a key for the user. The key itself is only part of
+// the result; afterwards only its hash is known.
+func (s *userService) CreateAPIKey(userID uuid.UUID, data *domain.APIKeyCreation) (*domain.APIKeyCreated, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	key := domain.APIKeyPrefix + secret
+
+	apiKey := domain.APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      data.Name,
+		Prefix:    key[:apiKeyPrefixLength],
+		KeyHash:   domain.HashToken(key),
+		Scopes:    strings.Join(data.Scopes, ","),
+		ExpiresAt: data.ExpiresAt,
+	}
+
+	if err := s.tokenRepo.SaveAPIKey(&apiKey); err != nil {
+		return nil, clients.ErrCannotCreateEntity(apiKey.TableName(), err)
+	}
+
+	return &domain.APIKeyCreated{APIKey: apiKey, Key: key}, nil
+}
+
+// ListAPIKeys lists the keys of the user that are not revoked.
+func (s *userService) ListAPIKeys(userID uuid.UUID) ([]domain.APIKey, error) {
+	keys, err := s.tokenRepo.ListAPIKeys(map[string]any{"user_id": userID, "revoked_at": nil})
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.APIKey{}.TableName(), err)
+	}
+
+	return keys, nil
+}
+
+func (s *userService) RevokeAPIKey(userID, id uuid.UUID) error {
+	if err := s.tokenRepo.RevokeAPIKey(userID, id, time.Now()); err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return clients.ErrCannotGetEntity(domain.APIKey{}.TableName(), err)
+		}
+
+		return clients.ErrCannotUpdateEntity(domain.APIKey{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey returns the active key matching key and records its use.
+func (s *userService) AuthenticateAPIKey(key string) (*domain.APIKey, error) {
+	if !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	apiKey, err := s.tokenRepo.GetAPIKey(map[string]any{"key_hash": domain.HashToken(key)})
+	if err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyInvalid
+		}
+
+		return nil, clients.ErrCannotGetEntity(domain.APIKey{}.TableName(), err)
+	}
+
+	now := time.Now()
+	if !apiKey.Active(now) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		// Failing to record the use must not fail the request.
+		if err := s.tokenRepo.TouchAPIKey(apiKey.ID, now); err != nil {
+			log.Println("touch api key:", err)
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,140 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		var saved *domain.APIKey
+		m.tokenRepo.On("SaveAPIKey", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*domain.APIKey)
+		}).Return(nil).Once()
+
+		created, err := userService.CreateAPIKey(userID, &domain.APIKeyCreation{Name: " CI ", Scopes: []string{"read", "write"}})
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Key, domain.APIKeyPrefix))
+		assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+		assert.Equal(t, "CI", created.Name)
+
+		// Only the hash of the key is stored
+		assert.Equal(t, domain.HashToken(created.Key), saved.KeyHash)
+		assert.Equal(t, "read,write", saved.Scopes)
+		assert.Equal(t, userID, saved.UserID)
+	})
+
+	t.Run("defaults to read", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.tokenRepo.On("SaveAPIKey", mock.MatchedBy(func(key *domain.APIKey) bool {
+			return key.Scopes == domain.ScopeRead
+		})).Return(nil).Once()
+
+		_, err := userService.CreateAPIKey(userID, &domain.APIKeyCreation{Name: "Backup"})
+
+		assert.NoError(t, err)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		past := time.Now().Add(-time.Hour)
+		invalid := []domain.APIKeyCreation{
+			{},
+			{Name: "CI", Scopes: []string{"admin"}},
+			{Name: "CI", ExpiresAt: &past},
+		}
+
+		for _, data := range invalid {
+			_, err := userService.CreateAPIKey(userID, &data)
+
+			assert.Error(t, err)
+		}
+		m.tokenRepo.AssertNotCalled(t, "SaveAPIKey", mock.Anything)
+	})
+}
+
+func TestListAPIKeys(t *testing.T) {
+	m, userService := setupUserService(service.Config{})
+
+	userID := uuid.New()
+	m.tokenRepo.On("ListAPIKeys", map[string]any{"user_id": userID, "revoked_at": nil}).
+		Return([]domain.APIKey{{Name: "CI"}}, nil).Once()
+
+	keys, err := userService.ListAPIKeys(userID)
+
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	const key = domain.APIKeyPrefix + "secret"
+	conditions := map[string]any{"key_hash": domain.HashToken(key)}
+
+	t.Run("records the use", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		apiKey := &domain.APIKey{ID: uuid.New(), UserID: uuid.New(), Scopes: domain.ScopeRead}
+		m.tokenRepo.On("GetAPIKey", conditions).Return(apiKey, nil).Once()
+		m.tokenRepo.On("TouchAPIKey", apiKey.ID, mock.Anything).Return(nil).Once()
+
+		result, err := userService.AuthenticateAPIKey(key)
+
+		require.NoError(t, err)
+		assert.Equal(t, apiKey.UserID, result.UserID)
+		assert.NotNil(t, result.LastUsedAt)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("recent use is not written again", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		lastUsed := time.Now().Add(-time.Second)
+		m.tokenRepo.On("GetAPIKey", conditions).Return(&domain.APIKey{ID: uuid.New(), LastUsedAt: &lastUsed}, nil).Once()
+
+		_, err := userService.AuthenticateAPIKey(key)
+
+		assert.NoError(t, err)
+		m.tokenRepo.AssertNotCalled(t, "TouchAPIKey", mock.Anything, mock.Anything)
+	})
+
+	t.Run("revoked or expired", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+
+		for _, apiKey := range []*domain.APIKey{{RevokedAt: &past}, {ExpiresAt: &past}} {
+			m, userService := setupUserService(service.Config{})
+			m.tokenRepo.On("GetAPIKey", conditions).Return(apiKey, nil).Once()
+
+			_, err := userService.AuthenticateAPIKey(key)
+
+			assert.Equal(t, domain.ErrAPIKeyInvalid, err)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+		m.tokenRepo.On("GetAPIKey", conditions).Return(nil, clients.ErrRecordNotFound).Once()
+
+		_, err := userService.AuthenticateAPIKey(key)
+		assert.Equal(t, domain.ErrAPIKeyInvalid, err)
+
+		_, err = userService.AuthenticateAPIKey("not a key")
+		assert.Equal(t, domain.ErrAPIKeyInvalid, err)
+	})
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"crypto/subtle"
+	"log"
+	"strings"
+	"sync"
+	"todo-app/domain"
+	"todo-app/pkg/util"
+)
+
+// legacyHasher made the salted MD5 hashes stored before passwords moved to
+// argon2id and bcrypt. It is only used to verify those hashes once more.
+var legacyHasher = util.NewMd5Hash()
+
+// isLegacyHash reports whether encoded is a salted MD5 hash. Every other
+// supported encoding starts with a $ sign.
+func isLegacyHash(encoded string) bool {
+	return !strings.HasPrefix(encoded, "$")
+}
+
+// checkPassword verifies password against the stored hash of user. After a
+// successful check, legacy and outdated hashes are replaced by a hash of the
+// configured hasher, so accounts migrate as their users log in.
+func (s *userService) checkPassword(user *domain.User, password string) (bool, error) {
+	ok, err := s.verifyPassword(user, password)
+	if err != nil {
+		return false, err
+	}
+
+	if ok && (isLegacyHash(user.Password) || s.hasher.NeedsRehash(user.Password)) {
+		s.rehash(user, password)
+	}
+
+	return ok, nil
+}
+
+func (s *userService) verifyPassword(user *domain.User, password string) (bool, error) {
+	if isLegacyHash(user.Password) {
+		hashed := legacyHasher.Hash(password + user.Salt)
+		return subtle.ConstantTimeCompare([]byte(hashed), []byte(user.Password)) == 1, nil
+	}
+
+	return s.hasher.Verify(password, user.Password)
+}
+
+// rehash stores a fresh hash of password. A failure only delays the
+// migration to the next login, so it does not fail the login.
+func (s *userService) rehash(user *domain.User, password string) {
+	hashed, err := s.hasher.Hash(password)
+	if err != nil {
+		log.Println("rehash password:", err)
+		return
+	}
+
+	if err := s.userRepo.UpdatePassword(user.ID, hashed); err != nil {
+		log.Println("rehash password:", err)
+	}
+}
+
+// dummyHash is a hash of the configured hasher that matches no password.
+type dummyHash struct {
+	once    sync.Once
+	encoded string
+}
+
+// verifyDummy spends the time of a password check when there is no user to
+// check against, so that response times do not tell which emails have an
+// account.
+func (s *userService) verifyDummy(password string) {
+	s.dummy.once.Do(func() {
+		encoded, err := s.hasher.Hash(util.GenSalt(32))
+		if err != nil {
+			log.Println("dummy password hash:", err)
+		}
+		s.dummy.encoded = encoded
+	})
+
+	s.hasher.Verify(password, s.dummy.encoded)
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/tokenprovider"
+
+	"github.com/google/uuid"
+)
+
+func (s *userService) GetProfile(userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetUser(map[string]any{"id": userID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	return user, nil
+}
+
+func (s *userService) UpdateProfile(userID uuid.UUID, data *domain.UserUpdate) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	data.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(userID, data); err != nil {
+		return clients.ErrCannotUpdateEntity(data.TableName(), err)
+	}
+
+	return nil
+}
+
+// ChangePassword replaces the password of the user after checking the current
+// one, and revokes their refresh tokens so other devices have to log in again.
+func (s *userService) ChangePassword(userID uuid.UUID, data *domain.ChangePasswordRequest) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	user, err := s.userRepo.GetUser(map[string]any{"id": userID})
+	if err != nil {
+		return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	ok, err := s.verifyPassword(user, data.CurrentPassword)
+	if err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	if !ok {
+		return domain.ErrCurrentPasswordInvalid
+	}
+
+	hashed, err := s.hasher.Hash(data.NewPassword)
+	if err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	if err := s.userRepo.UpdatePassword(userID, hashed); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	if err := s.tokenRepo.RevokeRefreshTokens(map[string]any{"user_id": userID}, time.Now()); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.RefreshToken{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// DeleteAccount soft-deletes the user together with their personal items and
+// signs them out everywhere. Their email is freed for a new account. Users who
+// are the only owner of a workspace have to hand it over or delete it first.
+func (s *userService) DeleteAccount(userID uuid.UUID, accessToken tokenprovider.TokenPayload) error {
+	owned, err := s.userRepo.CountSoleOwnedWorkspaces(userID)
+	if err != nil {
+		return clients.ErrCannotGetEntity(domain.Workspace{}.TableName(), err)
+	}
+
+	if owned > 0 {
+		return domain.ErrSoleWorkspaceOwner
+	}
+
+	now := time.Now()
+
+	if err := s.userRepo.Delete(userID, now); err != nil {
+		return clients.ErrCannotDeleteEntity(domain.User{}.TableName(), err)
+	}
+
+	if err := s.tokenRepo.RevokeRefreshTokens(map[string]any{"user_id": userID}, now); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.RefreshToken{}.TableName(), err)
+	}
+
+	if err := s.revoker.Revoke(accessToken.TokenID(), accessToken.ExpiresAt()); err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/util"
+	service "todo-app/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUpdateProfile(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.userRepo.On("Update", userID, mock.MatchedBy(func(data *domain.UserUpdate) bool {
+			return *data.FirstName == "Ann" && !data.UpdatedAt.IsZero()
+		})).Return(nil).Once()
+
+		err := userService.UpdateProfile(userID, &domain.UserUpdate{FirstName: ptrToString("  Ann ")})
+
+		assert.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		_, userService := setupUserService(service.Config{})
+
+		err := userService.UpdateProfile(userID, &domain.UserUpdate{Phone: ptrToString("call me")})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "phone must be a phone number")
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	hashed, err := util.NewBcryptHash(bcrypt.MinCost).Hash("secret")
+	require.NoError(t, err)
+
+	user := &domain.User{ID: uuid.New(), Password: hashed, Status: clients.Active}
+
+	t.Run("success", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+		m.userRepo.On("UpdatePassword", user.ID, mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("RevokeRefreshTokens", map[string]any{"user_id": user.ID}, mock.Anything).Return(nil).Once()
+
+		err := userService.ChangePassword(user.ID, &domain.ChangePasswordRequest{CurrentPassword: "secret", NewPassword: "new secret"})
+
+		assert.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+
+		err := userService.ChangePassword(user.ID, &domain.ChangePasswordRequest{CurrentPassword: "guess", NewPassword: "new secret"})
+
+		assert.ErrorIs(t, err, domain.ErrCurrentPasswordInvalid)
+		m.userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteAccount(t *testing.T) {
+	userID := uuid.New()
+	accessToken := clients.TokenPayload{UID: userID, JTI: "jti", Expiry: time.Now().Add(time.Minute)}
+
+	t.Run("deletes and signs out", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.userRepo.On("CountSoleOwnedWorkspaces", userID).Return(int64(0), nil).Once()
+		m.userRepo.On("Delete", userID, mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("RevokeRefreshTokens", map[string]any{"user_id": userID}, mock.Anything).Return(nil).Once()
+		m.revoker.On("Revoke", "jti", accessToken.Expiry).Return(nil).Once()
+
+		err := userService.DeleteAccount(userID, accessToken)
+
+		assert.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+		m.revoker.AssertExpectations(t)
+	})
+
+	t.Run("the only owner of a workspace can not delete", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.userRepo.On("CountSoleOwnedWorkspaces", userID).Return(int64(1), nil).Once()
+
+		err := userService.DeleteAccount(userID, accessToken)
+
+		assert.ErrorIs(t, err, domain.ErrSoleWorkspaceOwner)
+		m.userRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+// Helper function to return a pointer to a string
+func ptrToString(s string) *string {
+	return &s
+}
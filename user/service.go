@@ -1,37 +1,119 @@
 package user
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"log"
+	"time"
 	"todo-app/domain"
 	"todo-app/pkg/clients"
 	"todo-app/pkg/tokenprovider"
-	"todo-app/pkg/util"
 
 	"github.com/google/uuid"
 )
 
+//go:generate mockery --name UserRepo
 type UserRepo interface {
 	Save(user *domain.UserCreate) error
 	GetUser(conditions map[string]any) (*domain.User, error)
+	UpdatePassword(id uuid.UUID, password string) error
+	MarkEmailVerified(id uuid.UUID, verifiedAt time.Time) error
+	Update(id uuid.UUID, data *domain.UserUpdate) error
+	// Delete deactivates the user, frees their email and removes their
+	// memberships, shares and linked identities.
+	Delete(id uuid.UUID, deletedAt time.Time) error
+	// CountSoleOwnedWorkspaces counts the workspaces the user is the only
+	// owner of.
+	CountSoleOwnedWorkspaces(userID uuid.UUID) (int64, error)
+	SetTOTPSecret(id uuid.UUID, secret string) error
+	EnableTOTP(id uuid.UUID, enabledAt time.Time) error
+	UseTOTPStep(id uuid.UUID, step int64) error
+	GetIdentity(conditions map[string]any) (*domain.UserIdentity, error)
+	SaveIdentity(identity *domain.UserIdentity) error
 }
 
+// Hasher hashes passwords into a self-describing encoding that carries its
+// salt and cost parameters.
 type Hasher interface {
-	Hash(data string) string
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash reports whether encoded should be replaced by a fresh
+	// hash, because it was made by another algorithm or with other costs.
+	NeedsRehash(encoded string) bool
+}
+
+//go:generate mockery --name TokenRepo
+type TokenRepo interface {
+	SaveRefreshToken(token *domain.RefreshToken) error
+	GetRefreshToken(conditions map[string]any) (*domain.RefreshToken, error)
+	RotateRefreshToken(id uuid.UUID, next *domain.RefreshToken) error
+	RevokeRefreshTokens(conditions map[string]any, revokedAt time.Time) error
+	SaveSession(session *domain.Session) error
+	GetSession(conditions map[string]any) (*domain.Session, error)
+	ListSessions(conditions map[string]any) ([]domain.Session, error)
+	RevokeSession(userID, id uuid.UUID, revokedAt time.Time) error
+	TouchSession(id uuid.UUID, seenAt time.Time) error
+	SaveUserToken(token *domain.UserToken) error
+	ConsumeUserToken(purpose, tokenHash string, usedAt time.Time) (*domain.UserToken, error)
+	SaveAPIKey(key *domain.APIKey) error
+	GetAPIKey(conditions map[string]any) (*domain.APIKey, error)
+	ListAPIKeys(conditions map[string]any) ([]domain.APIKey, error)
+	RevokeAPIKey(userID, id uuid.UUID, revokedAt time.Time) error
+	TouchAPIKey(id uuid.UUID, usedAt time.Time) error
+	ReplaceRecoveryCodes(userID uuid.UUID, codes []domain.RecoveryCode) error
+	ConsumeRecoveryCode(userID uuid.UUID, codeHash string, usedAt time.Time) error
+}
+
+// TokenRevoker revokes access tokens by their ID until they expire.
+//
+//go:generate mockery --name TokenRevoker
+type TokenRevoker interface {
+	Revoke(jti string, expiresAt time.Time) error
+}
+
+// Mailer sends plain text mails.
+//
+//go:generate mockery --name Mailer
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// Config holds the settings of the user service.
+type Config struct {
+	// AccessExpiry and RefreshExpiry are the lifetimes of access and refresh
+	// tokens in seconds.
+	AccessExpiry  int
+	RefreshExpiry int
+	// AppURL is the base of the links mailed to users.
+	AppURL string
+	// RequireVerifiedEmail refuses logins until the email is verified.
+	RequireVerifiedEmail bool
+	// Now returns the current time for TOTP codes and single-use tokens. It
+	// defaults to time.Now.
+	Now func() time.Time
 }
 
 type userService struct {
 	userRepo      UserRepo
 	hasher        Hasher
 	tokenProvider tokenprovider.Provider
-	expiry        int
+	tokenRepo     TokenRepo
+	revoker       TokenRevoker
+	mailer        Mailer
+	cfg           Config
+	dummy         dummyHash
 }
 
-func NewUserService(repo UserRepo, hasher Hasher, tokenProvider tokenprovider.Provider, expiry int) *userService {
+func NewUserService(repo UserRepo, hasher Hasher, tokenProvider tokenprovider.Provider, tokenRepo TokenRepo, revoker TokenRevoker, mailer Mailer, cfg Config) *userService {
 	return &userService{
 		userRepo:      repo,
 		hasher:        hasher,
 		tokenProvider: tokenProvider,
-		expiry:        expiry,
+		tokenRepo:     tokenRepo,
+		revoker:       revoker,
+		mailer:        mailer,
+		cfg:           cfg,
 	}
 }
 
@@ -51,41 +133,234 @@ func (s *userService) Register(data *domain.UserCreate) error {
 		return domain.ErrEmailExisted
 	}
 
-	salt := util.GenSalt(50)
+	hashed, err := s.hasher.Hash(data.Password)
+	if err != nil {
+		return clients.ErrInternal(err)
+	}
 
 	data.ID = uuid.New()
-	data.Password = s.hasher.Hash(data.Password + salt)
-	data.Salt = salt
+	data.Password = hashed
 	data.Role = 1
+	data.Status = clients.Active
 
 	if err := s.userRepo.Save(data); err != nil {
 		return clients.ErrCannotCreateEntity(data.TableName(), err)
 	}
 
+	// The account exists at this point; a failed mail can be sent again
+	// through ResendVerification.
+	if err := s.sendVerification(data.ID, data.Email); err != nil {
+		log.Println("send verification:", err)
+	}
+
 	return nil
 }
 
-func (s *userService) Login(data *domain.UserLogin) (tokenprovider.Token, error) {
+// Login checks the password of the user. Users with two-factor
+// authentication get a challenge to complete through LoginTwoFactor instead
+// of a token pair.
+func (s *userService) Login(data *domain.UserLogin) (*domain.LoginResult, error) {
 	user, err := s.userRepo.GetUser(map[string]interface{}{"email": data.Email})
-	if err != nil {
+	if err != nil || user.Status == clients.Deleted {
+		s.verifyDummy(data.Password)
 		return nil, domain.ErrEmailOrPasswordInvalid
 	}
 
-	passHashed := s.hasher.Hash(data.Password + user.Salt)
+	ok, err := s.checkPassword(user, data.Password)
+	if err != nil {
+		return nil, clients.ErrInternal(err)
+	}
 
-	if user.Password != passHashed {
+	if !ok {
 		return nil, domain.ErrEmailOrPasswordInvalid
 	}
 
+	if s.cfg.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+		return nil, domain.ErrEmailNotVerified
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return s.twoFactorChallenge(user.ID)
+	}
+
+	pair, err := s.startSession(user, data.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginResult{TokenPair: pair}, nil
+}
+
+// startSession records a session for a user who passed every login check,
+// and issues its first token pair.
+func (s *userService) startSession(user *domain.User, client domain.ClientInfo) (*domain.TokenPair, error) {
+	now := time.Now()
+	session := &domain.Session{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		LastSeenAt: now,
+		CreatedAt:  &now,
+	}
+
+	if err := s.tokenRepo.SaveSession(session); err != nil {
+		return nil, clients.ErrCannotCreateEntity(session.TableName(), err)
+	}
+
+	token, err := s.newRefreshToken(user.ID, &session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.SaveRefreshToken(token.record); err != nil {
+		return nil, clients.ErrCannotCreateEntity(token.record.TableName(), err)
+	}
+
+	return s.tokenPair(user, token)
+}
+
+// Refresh exchanges a refresh token for a new token pair. Every refresh token
+// is used once; presenting one that was already used revokes all refresh
+// tokens of its user, as the token has likely leaked.
+func (s *userService) Refresh(data *domain.RefreshRequest) (*domain.TokenPair, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	current, err := s.tokenRepo.GetRefreshToken(map[string]any{"token_hash": domain.HashToken(data.RefreshToken)})
+	if err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return nil, domain.ErrRefreshTokenInvalid
+		}
+
+		return nil, clients.ErrCannotGetEntity(domain.RefreshToken{}.TableName(), err)
+	}
+
+	if current.RevokedAt != nil {
+		return nil, s.revokeAll(current.UserID, domain.ErrRefreshTokenReused)
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return nil, domain.ErrRefreshTokenInvalid
+	}
+
+	user, err := s.userRepo.GetUser(map[string]any{"id": current.UserID})
+	if err != nil || user.Status == 0 {
+		return nil, domain.ErrRefreshTokenInvalid
+	}
+
+	next, err := s.newRefreshToken(user.ID, current.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.RotateRefreshToken(current.ID, next.record); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return nil, s.revokeAll(current.UserID, domain.ErrRefreshTokenReused)
+		}
+
+		return nil, clients.ErrCannotUpdateEntity(current.TableName(), err)
+	}
+
+	return s.tokenPair(user, next)
+}
+
+// Logout revokes the access token of the request and ends its session. When
+// data.All is set it ends every session of the user. Tokens issued before
+// sessions were tracked have none, so the given refresh token is revoked
+// instead.
+func (s *userService) Logout(userID uuid.UUID, accessToken tokenprovider.TokenPayload, data *domain.LogoutRequest) error {
+	if err := s.revoker.Revoke(accessToken.TokenID(), accessToken.ExpiresAt()); err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	conditions := map[string]any{"user_id": userID}
+	switch {
+	case data.All:
+	case accessToken.SessionID() != uuid.Nil:
+		conditions["session_id"] = accessToken.SessionID()
+	case data.RefreshToken != "":
+		conditions["token_hash"] = domain.HashToken(data.RefreshToken)
+	default:
+		return nil
+	}
+
+	if err := s.tokenRepo.RevokeRefreshTokens(conditions, time.Now()); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.RefreshToken{}.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *userService) now() time.Time {
+	if s.cfg.Now != nil {
+		return s.cfg.Now()
+	}
+
+	return time.Now()
+}
+
+func (s *userService) revokeAll(userID uuid.UUID, cause error) error {
+	if err := s.tokenRepo.RevokeRefreshTokens(map[string]any{"user_id": userID}, time.Now()); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.RefreshToken{}.TableName(), err)
+	}
+
+	return cause
+}
+
+// refreshToken is a newly issued refresh token with its stored record.
+type refreshToken struct {
+	token  string
+	record *domain.RefreshToken
+}
+
+func (s *userService) newRefreshToken(userID uuid.UUID, sessionID *uuid.UUID) (*refreshToken, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &refreshToken{
+		token: token,
+		record: &domain.RefreshToken{
+			ID:        uuid.New(),
+			UserID:    userID,
+			SessionID: sessionID,
+			TokenHash: domain.HashToken(token),
+			ExpiresAt: time.Now().Add(time.Duration(s.cfg.RefreshExpiry) * time.Second),
+		},
+	}, nil
+}
+
+func (s *userService) tokenPair(user *domain.User, refresh *refreshToken) (*domain.TokenPair, error) {
 	payload := &clients.TokenPayload{
 		UID:   user.ID,
 		URole: user.Role.String(),
 	}
 
-	accessToken, err := s.tokenProvider.Generate(payload, s.expiry)
+	if refresh.record.SessionID != nil {
+		payload.SID = *refresh.record.SessionID
+	}
+
+	accessToken, err := s.tokenProvider.Generate(payload, s.cfg.AccessExpiry)
 	if err != nil {
 		return nil, clients.ErrInternal(err)
 	}
 
-	return accessToken, nil
+	return &domain.TokenPair{
+		AccessToken:      accessToken,
+		RefreshToken:     refresh.token,
+		RefreshExpiresAt: refresh.record.ExpiresAt,
+	}, nil
+}
+
+// randomToken returns an unguessable token for use in URLs.
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", clients.ErrInternal(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
@@ -0,0 +1,320 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/tokenprovider"
+	"todo-app/pkg/tokenprovider/jwt"
+	"todo-app/pkg/util"
+	service "todo-app/user"
+	"todo-app/user/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// userService is the part of the service exercised by these tests.
+type userService interface {
+	Register(data *domain.UserCreate) error
+	Login(data *domain.UserLogin) (*domain.LoginResult, error)
+	LoginTwoFactor(data *domain.TwoFactorLoginRequest) (*domain.TokenPair, error)
+	Refresh(data *domain.RefreshRequest) (*domain.TokenPair, error)
+	Logout(userID uuid.UUID, accessToken tokenprovider.TokenPayload, data *domain.LogoutRequest) error
+	VerifyEmail(data *domain.VerifyEmailRequest) error
+	ResendVerification(data *domain.EmailRequest) error
+	ForgotPassword(data *domain.EmailRequest) error
+	ResetPassword(data *domain.ResetPasswordRequest) error
+	UpdateProfile(userID uuid.UUID, data *domain.UserUpdate) error
+	ChangePassword(userID uuid.UUID, data *domain.ChangePasswordRequest) error
+	DeleteAccount(userID uuid.UUID, accessToken tokenprovider.TokenPayload) error
+	CreateAPIKey(userID uuid.UUID, data *domain.APIKeyCreation) (*domain.APIKeyCreated, error)
+	ListAPIKeys(userID uuid.UUID) ([]domain.APIKey, error)
+	AuthenticateAPIKey(key string) (*domain.APIKey, error)
+	ListSessions(userID, current uuid.UUID) ([]domain.Session, error)
+	RevokeSession(userID, id uuid.UUID) error
+	AuthenticateSession(id uuid.UUID) error
+	EnrollTwoFactor(userID uuid.UUID) (*domain.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(userID uuid.UUID, data *domain.TwoFactorCodeRequest) (*domain.RecoveryCodes, error)
+	DisableTwoFactor(userID uuid.UUID, data *domain.TwoFactorCodeRequest) error
+	LoginExternal(identity *domain.ExternalIdentity, client domain.ClientInfo) (*domain.LoginResult, error)
+}
+
+type userMocks struct {
+	userRepo  *mocks.UserRepo
+	tokenRepo *mocks.TokenRepo
+	revoker   *mocks.TokenRevoker
+	mailer    *mocks.Mailer
+}
+
+func setupUserService(cfg service.Config) (*userMocks, userService) {
+	m := &userMocks{new(mocks.UserRepo), new(mocks.TokenRepo), new(mocks.TokenRevoker), new(mocks.Mailer)}
+
+	cfg.AccessExpiry, cfg.RefreshExpiry = 900, 3600
+	userService := service.NewUserService(m.userRepo, util.NewBcryptHash(bcrypt.MinCost), jwt.NewJWTProvider("secret"),
+		m.tokenRepo, m.revoker, m.mailer, cfg)
+
+	return m, userService
+}
+
+func TestRegister(t *testing.T) {
+	m, userService := setupUserService(service.Config{AppURL: "https://todo.example.com"})
+
+	m.userRepo.On("GetUser", mock.Anything).Return(nil, clients.ErrRecordNotFound).Once()
+	m.userRepo.On("Save", mock.MatchedBy(func(user *domain.UserCreate) bool {
+		return strings.HasPrefix(user.Password, "$2a$") && user.Salt == "" && user.Status == clients.Active
+	})).Return(nil).Once()
+
+	m.tokenRepo.On("SaveUserToken", mock.MatchedBy(func(token *domain.UserToken) bool {
+		return token.Purpose == domain.TokenPurposeEmailVerification
+	})).Return(nil).Once()
+	m.mailer.On("Send", "a@example.com", "Verify your email", mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "https://todo.example.com/verify-email?token=")
+	})).Return(nil).Once()
+
+	err := userService.Register(&domain.UserCreate{Email: "a@example.com", Password: "secret"})
+
+	assert.NoError(t, err)
+	m.userRepo.AssertExpectations(t)
+	m.mailer.AssertExpectations(t)
+}
+
+func TestLogin(t *testing.T) {
+	hashed, err := util.NewBcryptHash(bcrypt.MinCost).Hash("secret")
+	require.NoError(t, err)
+
+	user := &domain.User{ID: uuid.New(), Email: "a@example.com", Password: hashed, Status: 1}
+
+	t.Run("issues a token pair", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		client := domain.ClientInfo{UserAgent: "Firefox", IP: "203.0.113.7"}
+		var session *domain.Session
+
+		m.userRepo.On("GetUser", map[string]any{"email": user.Email}).Return(user, nil).Once()
+		m.tokenRepo.On("SaveSession", mock.MatchedBy(func(s *domain.Session) bool {
+			return s.UserID == user.ID && s.UserAgent == client.UserAgent && s.IP == client.IP
+		})).Run(func(args mock.Arguments) {
+			session = args.Get(0).(*domain.Session)
+		}).Return(nil).Once()
+		m.tokenRepo.On("SaveRefreshToken", mock.MatchedBy(func(token *domain.RefreshToken) bool {
+			return token.UserID == user.ID && token.TokenHash != "" && *token.SessionID == session.ID
+		})).Return(nil).Once()
+
+		result, err := userService.Login(&domain.UserLogin{Email: user.Email, Password: "secret", Client: client})
+
+		require.NoError(t, err)
+		assert.False(t, result.TwoFactorRequired)
+		pair := result.TokenPair
+		require.NotNil(t, pair)
+		assert.NotEmpty(t, pair.RefreshToken)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), pair.RefreshExpiresAt, time.Minute)
+
+		// The access token carries the session
+		payload, err := jwt.NewJWTProvider("secret").Validate(pair.AccessToken.GetToken())
+		require.NoError(t, err)
+		assert.Equal(t, session.ID, payload.SessionID())
+		m.userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.userRepo.On("GetUser", map[string]any{"email": user.Email}).Return(user, nil).Once()
+
+		_, err := userService.Login(&domain.UserLogin{Email: user.Email, Password: "guess"})
+
+		assert.ErrorIs(t, err, domain.ErrEmailOrPasswordInvalid)
+	})
+
+	t.Run("unknown email takes as long as a wrong password", func(t *testing.T) {
+		m := &userMocks{new(mocks.UserRepo), new(mocks.TokenRepo), new(mocks.TokenRevoker), new(mocks.Mailer)}
+		hasher := &countingHasher{Hasher: util.NewBcryptHash(bcrypt.MinCost)}
+		userService := service.NewUserService(m.userRepo, hasher, jwt.NewJWTProvider("secret"), m.tokenRepo, m.revoker, m.mailer, service.Config{})
+
+		m.userRepo.On("GetUser", map[string]any{"email": "nobody@example.com"}).Return(nil, clients.ErrRecordNotFound).Once()
+
+		_, err := userService.Login(&domain.UserLogin{Email: "nobody@example.com", Password: "secret"})
+
+		assert.ErrorIs(t, err, domain.ErrEmailOrPasswordInvalid)
+		assert.Equal(t, 1, hasher.verified)
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{RequireVerifiedEmail: true})
+
+		m.userRepo.On("GetUser", map[string]any{"email": user.Email}).Return(user, nil).Once()
+
+		_, err := userService.Login(&domain.UserLogin{Email: user.Email, Password: "secret"})
+
+		assert.ErrorIs(t, err, domain.ErrEmailNotVerified)
+		m.tokenRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything)
+	})
+
+	t.Run("migrates a legacy hash", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		legacy := &domain.User{ID: uuid.New(), Email: "b@example.com", Salt: "salt", Status: 1}
+		legacy.Password = util.NewMd5Hash().Hash("secret" + legacy.Salt)
+
+		m.userRepo.On("GetUser", map[string]any{"email": legacy.Email}).Return(legacy, nil).Twice()
+		m.userRepo.On("UpdatePassword", legacy.ID, mock.MatchedBy(func(password string) bool {
+			return strings.HasPrefix(password, "$2a$")
+		})).Return(nil).Once()
+		m.tokenRepo.On("SaveSession", mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveRefreshToken", mock.Anything).Return(nil).Once()
+
+		_, err := userService.Login(&domain.UserLogin{Email: legacy.Email, Password: "secret"})
+		assert.NoError(t, err)
+
+		// A wrong password leaves the legacy hash alone
+		_, err = userService.Login(&domain.UserLogin{Email: legacy.Email, Password: "guess"})
+		assert.ErrorIs(t, err, domain.ErrEmailOrPasswordInvalid)
+
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("rehashes with the configured hasher", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		argon2, err := util.NewArgon2idHash(util.Argon2Params{Time: 1, Memory: 1024, Threads: 1, SaltLen: 16, KeyLen: 32}).Hash("secret")
+		require.NoError(t, err)
+		other := &domain.User{ID: uuid.New(), Email: "c@example.com", Password: argon2, Status: 1}
+
+		m.userRepo.On("GetUser", map[string]any{"email": other.Email}).Return(other, nil).Once()
+		m.userRepo.On("UpdatePassword", other.ID, mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveSession", mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveRefreshToken", mock.Anything).Return(nil).Once()
+
+		_, err = userService.Login(&domain.UserLogin{Email: other.Email, Password: "secret"})
+
+		assert.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+	})
+}
+
+// countingHasher counts the passwords it verifies.
+type countingHasher struct {
+	service.Hasher
+	verified int
+}
+
+func (h *countingHasher) Verify(password, encoded string) (bool, error) {
+	h.verified++
+	return h.Hasher.Verify(password, encoded)
+}
+
+func TestRefresh(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Status: 1}
+	hash := domain.HashToken("refresh")
+
+	t.Run("rotates the token", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		sessionID := uuid.New()
+		current := &domain.RefreshToken{ID: uuid.New(), UserID: user.ID, SessionID: &sessionID, TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour)}
+		m.tokenRepo.On("GetRefreshToken", map[string]any{"token_hash": hash}).Return(current, nil).Once()
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+		m.tokenRepo.On("RotateRefreshToken", current.ID, mock.MatchedBy(func(next *domain.RefreshToken) bool {
+			return next.UserID == user.ID && next.TokenHash != hash && *next.SessionID == sessionID
+		})).Return(nil).Once()
+
+		pair, err := userService.Refresh(&domain.RefreshRequest{RefreshToken: "refresh"})
+
+		require.NoError(t, err)
+		assert.NotEqual(t, "refresh", pair.RefreshToken)
+
+		// The session carries over to the new access token
+		payload, err := jwt.NewJWTProvider("secret").Validate(pair.AccessToken.GetToken())
+		require.NoError(t, err)
+		assert.Equal(t, sessionID, payload.SessionID())
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("reuse revokes every refresh token", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		revokedAt := time.Now().Add(-time.Minute)
+		used := &domain.RefreshToken{ID: uuid.New(), UserID: user.ID, TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+		m.tokenRepo.On("GetRefreshToken", mock.Anything).Return(used, nil).Once()
+		m.tokenRepo.On("RevokeRefreshTokens", map[string]any{"user_id": user.ID}, mock.Anything).Return(nil).Once()
+
+		_, err := userService.Refresh(&domain.RefreshRequest{RefreshToken: "refresh"})
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		expired := &domain.RefreshToken{ID: uuid.New(), UserID: user.ID, TokenHash: hash, ExpiresAt: time.Now().Add(-time.Minute)}
+		m.tokenRepo.On("GetRefreshToken", mock.Anything).Return(expired, nil).Once()
+
+		_, err := userService.Refresh(&domain.RefreshRequest{RefreshToken: "refresh"})
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.tokenRepo.On("GetRefreshToken", mock.Anything).Return(nil, clients.ErrRecordNotFound).Once()
+
+		_, err := userService.Refresh(&domain.RefreshRequest{RefreshToken: "refresh"})
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	userID := uuid.New()
+	accessToken := clients.TokenPayload{UID: userID, JTI: "jti", Expiry: time.Now().Add(time.Minute)}
+
+	t.Run("revokes the access and refresh token", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.revoker.On("Revoke", "jti", accessToken.Expiry).Return(nil).Once()
+		m.tokenRepo.On("RevokeRefreshTokens", map[string]any{"user_id": userID, "token_hash": domain.HashToken("refresh")}, mock.Anything).
+			Return(nil).Once()
+
+		err := userService.Logout(userID, accessToken, &domain.LogoutRequest{RefreshToken: "refresh"})
+
+		assert.NoError(t, err)
+		m.revoker.AssertExpectations(t)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("ends the session of the access token", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		sessionToken := accessToken
+		sessionToken.SID = uuid.New()
+		m.revoker.On("Revoke", "jti", accessToken.Expiry).Return(nil).Once()
+		m.tokenRepo.On("RevokeRefreshTokens", map[string]any{"user_id": userID, "session_id": sessionToken.SID}, mock.Anything).
+			Return(nil).Once()
+
+		err := userService.Logout(userID, sessionToken, &domain.LogoutRequest{RefreshToken: "refresh"})
+
+		assert.NoError(t, err)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("everywhere", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.revoker.On("Revoke", "jti", accessToken.Expiry).Return(nil).Once()
+		m.tokenRepo.On("RevokeRefreshTokens", map[string]any{"user_id": userID}, mock.Anything).Return(nil).Once()
+
+		err := userService.Logout(userID, accessToken, &domain.LogoutRequest{All: true})
+
+		assert.NoError(t, err)
+		m.tokenRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"errors"
+	"log"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// sessionTouchInterval bounds how often the last use of a session is written.
+const sessionTouchInterval = time.Minute
+
+// ListSessions lists the active sessions of the user, marking the one with
+// the ID current.
+func (s *userService) ListSessions(userID, current uuid.UUID) ([]domain.Session, error) {
+	sessions, err := s.tokenRepo.ListSessions(map[string]any{"user_id": userID, "revoked_at": nil})
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Session{}.TableName(), err)
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession signs the user out of the session: its refresh tokens are
+// revoked and its access tokens rejected from then on.
+func (s *userService) RevokeSession(userID, id uuid.UUID) error {
+	if err := s.tokenRepo.RevokeSession(userID, id, time.Now()); err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return clients.ErrCannotGetEntity(domain.Session{}.TableName(), err)
+		}
+
+		return clients.ErrCannotUpdateEntity(domain.Session{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// AuthenticateSession checks that the session of an access token is still
+// active and records its use.
+func (s *userService) AuthenticateSession(id uuid.UUID) error {
+	session, err := s.tokenRepo.GetSession(map[string]any{"id": id})
+	if err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return domain.ErrSessionRevoked
+		}
+
+		return clients.ErrCannotGetEntity(domain.Session{}.TableName(), err)
+	}
+
+	if session.RevokedAt != nil {
+		return domain.ErrSessionRevoked
+	}
+
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		// Failing to record the use must not fail the request.
+		if err := s.tokenRepo.TouchSession(session.ID, now); err != nil {
+			log.Println("touch session:", err)
+		}
+	}
+
+	return nil
+}
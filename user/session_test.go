@@ -0,0 +1,98 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSessions(t *testing.T) {
+	m, userService := setupUserService(service.Config{})
+
+	userID := uuid.New()
+	sessions := []domain.Session{{ID: uuid.New()}, {ID: uuid.New()}}
+	m.tokenRepo.On("ListSessions", map[string]any{"user_id": userID, "revoked_at": nil}).Return(sessions, nil).Once()
+
+	listed, err := userService.ListSessions(userID, sessions[1].ID)
+
+	require.NoError(t, err)
+	assert.False(t, listed[0].Current)
+	assert.True(t, listed[1].Current)
+}
+
+func TestRevokeSession(t *testing.T) {
+	userID, id := uuid.New(), uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.tokenRepo.On("RevokeSession", userID, id, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, userService.RevokeSession(userID, id))
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.tokenRepo.On("RevokeSession", userID, id, mock.Anything).Return(clients.ErrRecordNotFound).Once()
+
+		err := userService.RevokeSession(userID, id)
+
+		require.Error(t, err)
+		assert.Equal(t, clients.ErrRecordNotFound, err.(*clients.AppError).RootError())
+	})
+}
+
+func TestAuthenticateSession(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("records a use after a minute", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		session := &domain.Session{ID: id, LastSeenAt: time.Now().Add(-2 * time.Minute)}
+		m.tokenRepo.On("GetSession", map[string]any{"id": id}).Return(session, nil).Once()
+		m.tokenRepo.On("TouchSession", id, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, userService.AuthenticateSession(id))
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("recent use", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		session := &domain.Session{ID: id, LastSeenAt: time.Now().Add(-10 * time.Second)}
+		m.tokenRepo.On("GetSession", map[string]any{"id": id}).Return(session, nil).Once()
+
+		assert.NoError(t, userService.AuthenticateSession(id))
+		m.tokenRepo.AssertNotCalled(t, "TouchSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		revokedAt := time.Now()
+		session := &domain.Session{ID: id, LastSeenAt: time.Now().Add(-time.Hour), RevokedAt: &revokedAt}
+		m.tokenRepo.On("GetSession", map[string]any{"id": id}).Return(session, nil).Once()
+
+		err := userService.AuthenticateSession(id)
+
+		assert.ErrorIs(t, err, domain.ErrSessionRevoked)
+		m.tokenRepo.AssertNotCalled(t, "TouchSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.tokenRepo.On("GetSession", mock.Anything).Return(nil, clients.ErrRecordNotFound).Once()
+
+		assert.ErrorIs(t, userService.AuthenticateSession(id), domain.ErrSessionRevoked)
+	})
+}
@@ -0,0 +1,129 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// LoginExternal logs in a user vouched for by an OpenID Connect provider.
+// Users with two-factor authentication get a challenge like on a password
+// login.
+func (s *userService) LoginExternal(identity *domain.ExternalIdentity, client domain.ClientInfo) (*domain.LoginResult, error) {
+	user, err := s.externalUser(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status == clients.Deleted {
+		return nil, domain.ErrSSOLoginFailed
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return s.twoFactorChallenge(user.ID)
+	}
+
+	pair, err := s.startSession(user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginResult{TokenPair: pair}, nil
+}
+
+// externalUser finds the user linked to identity. An identity seen for the
+// first time is linked to the user with its email, or to a new user, but
+// only if the provider verified the email.
+func (s *userService) externalUser(identity *domain.ExternalIdentity) (*domain.User, error) {
+	link, err := s.userRepo.GetIdentity(map[string]any{"issuer": identity.Issuer, "subject": identity.Subject})
+	if err == nil {
+		user, err := s.userRepo.GetUser(map[string]any{"id": link.UserID})
+		if err != nil {
+			return nil, clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+		}
+
+		return user, nil
+	}
+
+	if !errors.Is(err, clients.ErrRecordNotFound) {
+		return nil, clients.ErrCannotGetEntity(domain.UserIdentity{}.TableName(), err)
+	}
+
+	email := strings.TrimSpace(identity.Email)
+	if email == "" || !identity.EmailVerified {
+		return nil, domain.ErrSSOEmailNotVerified
+	}
+
+	user, err := s.userRepo.GetUser(map[string]any{"email": email})
+	if errors.Is(err, clients.ErrRecordNotFound) {
+		if user, err = s.provisionUser(email, identity); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	link = &domain.UserIdentity{
+		ID:      uuid.New(),
+		UserID:  user.ID,
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		Email:   email,
+	}
+
+	if err := s.userRepo.SaveIdentity(link); err != nil {
+		return nil, clients.ErrCannotCreateEntity(link.TableName(), err)
+	}
+
+	// The provider verified the email, which is as good as our own link.
+	if user.EmailVerifiedAt == nil {
+		now := s.now()
+		if err := s.userRepo.MarkEmailVerified(user.ID, now); err != nil {
+			return nil, clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+		}
+		user.EmailVerifiedAt = &now
+	}
+
+	return user, nil
+}
+
+// provisionUser creates the account of a user who first logs in through a
+// provider. Their password is random; they can set one through
+// ForgotPassword.
+func (s *userService) provisionUser(email string, identity *domain.ExternalIdentity) (*domain.User, error) {
+	password, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	hashed, err := s.hasher.Hash(password)
+	if err != nil {
+		return nil, clients.ErrInternal(err)
+	}
+
+	data := &domain.UserCreate{
+		ID:        uuid.New(),
+		Email:     email,
+		Password:  hashed,
+		FirstName: identity.FirstName,
+		LastName:  identity.LastName,
+		Role:      domain.RoleUser,
+		Status:    clients.Active,
+	}
+
+	if err := s.userRepo.Save(data); err != nil {
+		return nil, clients.ErrCannotCreateEntity(data.TableName(), err)
+	}
+
+	return &domain.User{
+		ID:        data.ID,
+		Email:     data.Email,
+		FirstName: data.FirstName,
+		LastName:  data.LastName,
+		Role:      data.Role,
+		Status:    data.Status,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginExternal(t *testing.T) {
+	identity := &domain.ExternalIdentity{
+		Issuer: "https://idp.example.com", Subject: "42", Email: "ann@example.com", EmailVerified: true, FirstName: "Ann",
+	}
+	byIdentity := map[string]any{"issuer": identity.Issuer, "subject": identity.Subject}
+	verifiedAt := time.Now()
+
+	t.Run("linked identity", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		userID := uuid.New()
+		m.userRepo.On("GetIdentity", byIdentity).Return(&domain.UserIdentity{UserID: userID}, nil).Once()
+		m.userRepo.On("GetUser", map[string]any{"id": userID}).
+			Return(&domain.User{ID: userID, Email: "old@example.com", Status: clients.Active, EmailVerifiedAt: &verifiedAt}, nil).Once()
+		m.tokenRepo.On("SaveSession", mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveRefreshToken", mock.Anything).Return(nil).Once()
+
+		result, err := userService.LoginExternal(identity, domain.ClientInfo{})
+
+		require.NoError(t, err)
+		require.NotNil(t, result.TokenPair)
+		m.userRepo.AssertNotCalled(t, "SaveIdentity", mock.Anything)
+	})
+
+	t.Run("links the user with the email", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		user := &domain.User{ID: uuid.New(), Email: identity.Email, Status: clients.Active}
+		m.userRepo.On("GetIdentity", byIdentity).Return(nil, clients.ErrRecordNotFound).Once()
+		m.userRepo.On("GetUser", map[string]any{"email": identity.Email}).Return(user, nil).Once()
+		m.userRepo.On("SaveIdentity", mock.MatchedBy(func(link *domain.UserIdentity) bool {
+			return link.UserID == user.ID && link.Issuer == identity.Issuer && link.Subject == identity.Subject
+		})).Return(nil).Once()
+		m.userRepo.On("MarkEmailVerified", user.ID, mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveSession", mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveRefreshToken", mock.Anything).Return(nil).Once()
+
+		_, err := userService.LoginExternal(identity, domain.ClientInfo{})
+
+		assert.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("provisions a new user", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		m.userRepo.On("GetIdentity", byIdentity).Return(nil, clients.ErrRecordNotFound).Once()
+		m.userRepo.On("GetUser", map[string]any{"email": identity.Email}).Return(nil, clients.ErrRecordNotFound).Once()
+		m.userRepo.On("Save", mock.MatchedBy(func(data *domain.UserCreate) bool {
+			return data.Email == identity.Email && data.FirstName == "Ann" && data.Status == clients.Active &&
+				data.Role == domain.RoleUser && data.Password != ""
+		})).Return(nil).Once()
+		m.userRepo.On("SaveIdentity", mock.Anything).Return(nil).Once()
+		m.userRepo.On("MarkEmailVerified", mock.Anything, mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveSession", mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveRefreshToken", mock.Anything).Return(nil).Once()
+
+		result, err := userService.LoginExternal(identity, domain.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result.TokenPair)
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		unverified := *identity
+		unverified.EmailVerified = false
+		m.userRepo.On("GetIdentity", byIdentity).Return(nil, clients.ErrRecordNotFound).Once()
+
+		_, err := userService.LoginExternal(&unverified, domain.ClientInfo{})
+
+		assert.Equal(t, domain.ErrSSOEmailNotVerified, err)
+		m.userRepo.AssertNotCalled(t, "GetUser", mock.Anything)
+	})
+
+	t.Run("banned user", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		userID := uuid.New()
+		m.userRepo.On("GetIdentity", byIdentity).Return(&domain.UserIdentity{UserID: userID}, nil).Once()
+		m.userRepo.On("GetUser", map[string]any{"id": userID}).Return(&domain.User{ID: userID, Status: clients.Deleted}, nil).Once()
+
+		_, err := userService.LoginExternal(identity, domain.ClientInfo{})
+
+		assert.Equal(t, domain.ErrSSOLoginFailed, err)
+		m.tokenRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything)
+	})
+
+	t.Run("two-factor user gets a challenge", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		userID := uuid.New()
+		m.userRepo.On("GetIdentity", byIdentity).Return(&domain.UserIdentity{UserID: userID}, nil).Once()
+		m.userRepo.On("GetUser", map[string]any{"id": userID}).
+			Return(&domain.User{ID: userID, Status: clients.Active, EmailVerifiedAt: &verifiedAt, TOTPEnabledAt: &verifiedAt}, nil).Once()
+		m.tokenRepo.On("SaveUserToken", mock.Anything).Return(nil).Once()
+
+		result, err := userService.LoginExternal(identity, domain.ClientInfo{})
+
+		require.NoError(t, err)
+		assert.True(t, result.TwoFactorRequired)
+		assert.Nil(t, result.TokenPair)
+	})
+}
@@ -0,0 +1,230 @@
+package user
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/totp"
+
+	"github.com/google/uuid"
+)
+
+// totpIssuer names the app in authenticator apps.
+const totpIssuer = "Todo App"
+
+// totpSkew is the number of time steps a code may be off, for clock drift.
+const totpSkew = 1
+
+const twoFactorChallengeExpiry = 5 * time.Minute
+
+const recoveryCodeCount = 10
+
+// EnrollTwoFactor creates a TOTP secret for the user. Two-factor
+// authentication is only turned on once a code of the secret is confirmed;
+// enrolling again replaces a pending secret.
+func (s *userService) EnrollTwoFactor(userID uuid.UUID) (*domain.TwoFactorEnrollment, error) {
+	user, err := s.userRepo.GetUser(map[string]any{"id": userID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, clients.ErrInternal(err)
+	}
+
+	if err := s.userRepo.SetTOTPSecret(userID, secret); err != nil {
+		return nil, clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	return &domain.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor turns two-factor authentication on with a code of the
+// enrolled secret, and returns the recovery codes. They are only shown here.
+func (s *userService) ConfirmTwoFactor(userID uuid.UUID, data *domain.TwoFactorCodeRequest) (*domain.RecoveryCodes, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	user, err := s.userRepo.GetUser(map[string]any{"id": userID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+
+	if err := s.checkSecondFactor(user, data.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, records, err := newRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.ReplaceRecoveryCodes(userID, records); err != nil {
+		return nil, clients.ErrCannotCreateEntity(domain.RecoveryCode{}.TableName(), err)
+	}
+
+	if err := s.userRepo.EnableTOTP(userID, s.now()); err != nil {
+		return nil, clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	return &domain.RecoveryCodes{Codes: codes}, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off, given a TOTP or
+// recovery code.
+func (s *userService) DisableTwoFactor(userID uuid.UUID, data *domain.TwoFactorCodeRequest) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	user, err := s.userRepo.GetUser(map[string]any{"id": userID})
+	if err != nil {
+		return clients.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return domain.ErrTwoFactorNotEnrolled
+	}
+
+	if err := s.checkSecondFactor(user, data.Code, true); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.SetTOTPSecret(userID, ""); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	if err := s.tokenRepo.ReplaceRecoveryCodes(userID, nil); err != nil {
+		return clients.ErrCannotDeleteEntity(domain.RecoveryCode{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// LoginTwoFactor completes a login with the challenge returned by Login and
+// a TOTP or recovery code. Every challenge is used once, so a wrong code
+// means logging in with the password again.
+func (s *userService) LoginTwoFactor(data *domain.TwoFactorLoginRequest) (*domain.TokenPair, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	challenge, err := s.consumeToken(domain.TokenPurposeTwoFactor, data.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserTokenInvalid) {
+			return nil, domain.ErrTwoFactorChallengeInvalid
+		}
+
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUser(map[string]any{"id": challenge.UserID})
+	if err != nil || user.Status == clients.Deleted {
+		return nil, domain.ErrTwoFactorChallengeInvalid
+	}
+
+	if err := s.checkSecondFactor(user, data.Code, true); err != nil {
+		return nil, err
+	}
+
+	return s.startSession(user, data.Client)
+}
+
+func (s *userService) twoFactorChallenge(userID uuid.UUID) (*domain.LoginResult, error) {
+	token, err := s.issueToken(userID, domain.TokenPurposeTwoFactor, twoFactorChallengeExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := s.now().Add(twoFactorChallengeExpiry)
+
+	return &domain.LoginResult{
+		TwoFactorRequired:  true,
+		ChallengeToken:     token,
+		ChallengeExpiresAt: &expiresAt,
+	}, nil
+}
+
+// checkSecondFactor accepts a TOTP code of the secret of the user, once, and
+// with allowRecovery an unused recovery code.
+func (s *userService) checkSecondFactor(user *domain.User, code string, allowRecovery bool) error {
+	if user.TOTPSecret == "" {
+		return domain.ErrTwoFactorNotEnrolled
+	}
+
+	now := s.now()
+
+	step, ok, err := totp.Validate(user.TOTPSecret, code, now, totpSkew)
+	if err != nil {
+		return clients.ErrInternal(err)
+	}
+
+	if ok {
+		// A code is only good for one login, even within its time step.
+		if err := s.userRepo.UseTOTPStep(user.ID, step); err != nil {
+			if errors.Is(err, clients.ErrRecordNotFound) {
+				return domain.ErrTwoFactorCodeInvalid
+			}
+
+			return clients.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+		}
+
+		return nil
+	}
+
+	if !allowRecovery {
+		return domain.ErrTwoFactorCodeInvalid
+	}
+
+	codeHash := domain.HashToken(domain.NormalizeRecoveryCode(code))
+	if err := s.tokenRepo.ConsumeRecoveryCode(user.ID, codeHash, now); err != nil {
+		if errors.Is(err, clients.ErrRecordNotFound) {
+			return domain.ErrTwoFactorCodeInvalid
+		}
+
+		return clients.ErrCannotUpdateEntity(domain.RecoveryCode{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// newRecoveryCodes returns fresh recovery codes such as "k3m9q-x2v7p" along
+// with their records.
+func newRecoveryCodes(userID uuid.UUID) ([]string, []domain.RecoveryCode, error) {
+	codes := make([]string, recoveryCodeCount)
+	records := make([]domain.RecoveryCode, recoveryCodeCount)
+
+	for i := range codes {
+		buf := make([]byte, 7)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, clients.ErrInternal(err)
+		}
+
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(buf))[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+		records[i] = domain.RecoveryCode{
+			ID:       uuid.New(),
+			UserID:   userID,
+			CodeHash: domain.HashToken(domain.NormalizeRecoveryCode(codes[i])),
+		}
+	}
+
+	return codes, records, nil
+}
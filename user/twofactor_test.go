@@ -0,0 +1,219 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	"todo-app/pkg/totp"
+	"todo-app/pkg/util"
+	service "todo-app/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// totpSecret is the key of the RFC 6238 test vectors.
+const totpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// clockAt returns a config whose clock is stopped at t, so TOTP codes are
+// deterministic.
+func clockAt(t time.Time) service.Config {
+	return service.Config{Now: func() time.Time { return t }}
+}
+
+func totpCode(t *testing.T, at time.Time) string {
+	code, err := totp.Code(totpSecret, at)
+	require.NoError(t, err)
+
+	return code
+}
+
+func TestEnrollTwoFactor(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		var secret string
+		m.userRepo.On("GetUser", map[string]any{"id": userID}).Return(&domain.User{ID: userID, Email: "ann@example.com"}, nil).Once()
+		m.userRepo.On("SetTOTPSecret", userID, mock.Anything).Run(func(args mock.Arguments) {
+			secret = args.String(1)
+		}).Return(nil).Once()
+
+		enrollment, err := userService.EnrollTwoFactor(userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, secret, enrollment.Secret)
+		assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/"))
+		assert.Contains(t, enrollment.URI, "secret="+secret)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		m, userService := setupUserService(service.Config{})
+
+		enabledAt := time.Now()
+		m.userRepo.On("GetUser", map[string]any{"id": userID}).Return(&domain.User{ID: userID, TOTPEnabledAt: &enabledAt}, nil).Once()
+
+		_, err := userService.EnrollTwoFactor(userID)
+
+		assert.Equal(t, domain.ErrTwoFactorEnabled, err)
+		m.userRepo.AssertNotCalled(t, "SetTOTPSecret", mock.Anything, mock.Anything)
+	})
+}
+
+func TestConfirmTwoFactor(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	user := &domain.User{ID: uuid.New(), TOTPSecret: totpSecret}
+
+	t.Run("success", func(t *testing.T) {
+		m, userService := setupUserService(clockAt(now))
+
+		var stored []domain.RecoveryCode
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+		m.userRepo.On("UseTOTPStep", user.ID, totp.Step(now)).Return(nil).Once()
+		m.tokenRepo.On("ReplaceRecoveryCodes", user.ID, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).([]domain.RecoveryCode)
+		}).Return(nil).Once()
+		m.userRepo.On("EnableTOTP", user.ID, now).Return(nil).Once()
+
+		codes, err := userService.ConfirmTwoFactor(user.ID, &domain.TwoFactorCodeRequest{Code: totpCode(t, now)})
+
+		require.NoError(t, err)
+		require.Len(t, codes.Codes, 10)
+		require.Len(t, stored, 10)
+		assert.Equal(t, domain.HashToken(domain.NormalizeRecoveryCode(codes.Codes[0])), stored[0].CodeHash)
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("code of another time", func(t *testing.T) {
+		m, userService := setupUserService(clockAt(now))
+
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+
+		_, err := userService.ConfirmTwoFactor(user.ID, &domain.TwoFactorCodeRequest{Code: totpCode(t, now.Add(5*time.Minute))})
+
+		assert.Equal(t, domain.ErrTwoFactorCodeInvalid, err)
+		m.userRepo.AssertNotCalled(t, "EnableTOTP", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		m, userService := setupUserService(clockAt(now))
+
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(&domain.User{ID: user.ID}, nil).Once()
+
+		_, err := userService.ConfirmTwoFactor(user.ID, &domain.TwoFactorCodeRequest{Code: "123456"})
+
+		assert.Equal(t, domain.ErrTwoFactorNotEnrolled, err)
+	})
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	hashed, err := util.NewBcryptHash(bcrypt.MinCost).Hash("secret")
+	require.NoError(t, err)
+
+	enabledAt := now.Add(-time.Hour)
+	user := &domain.User{ID: uuid.New(), Email: "a@example.com", Password: hashed, Status: clients.Active,
+		TOTPSecret: totpSecret, TOTPEnabledAt: &enabledAt}
+
+	t.Run("password login returns a challenge", func(t *testing.T) {
+		m, userService := setupUserService(clockAt(now))
+
+		m.userRepo.On("GetUser", map[string]any{"email": user.Email}).Return(user, nil).Once()
+		m.tokenRepo.On("SaveUserToken", mock.MatchedBy(func(token *domain.UserToken) bool {
+			return token.Purpose == domain.TokenPurposeTwoFactor && token.ExpiresAt.Equal(now.Add(5*time.Minute))
+		})).Return(nil).Once()
+
+		result, err := userService.Login(&domain.UserLogin{Email: user.Email, Password: "secret"})
+
+		require.NoError(t, err)
+		assert.True(t, result.TwoFactorRequired)
+		assert.NotEmpty(t, result.ChallengeToken)
+		assert.Nil(t, result.TokenPair)
+		m.tokenRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything)
+	})
+
+	t.Run("with a totp code", func(t *testing.T) {
+		m, userService := setupUserService(clockAt(now))
+
+		m.tokenRepo.On("ConsumeUserToken", domain.TokenPurposeTwoFactor, domain.HashToken("challenge"), now).
+			Return(&domain.UserToken{UserID: user.ID}, nil).Once()
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+		m.userRepo.On("UseTOTPStep", user.ID, totp.Step(now)-1).Return(nil).Once()
+		m.tokenRepo.On("SaveSession", mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveRefreshToken", mock.Anything).Return(nil).Once()
+
+		// A code of the previous step is still accepted
+		pair, err := userService.LoginTwoFactor(&domain.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: totpCode(t, now.Add(-30*time.Second))})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, pair.AccessToken.GetToken())
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("replayed totp code", func(t *testing.T) {
+		m, userService := setupUserService(clockAt(now))
+
+		m.tokenRepo.On("ConsumeUserToken", domain.TokenPurposeTwoFactor, domain.HashToken("challenge"), now).
+			Return(&domain.UserToken{UserID: user.ID}, nil).Once()
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+		m.userRepo.On("UseTOTPStep", user.ID, totp.Step(now)).Return(clients.ErrRecordNotFound).Once()
+
+		_, err := userService.LoginTwoFactor(&domain.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: totpCode(t, now)})
+
+		assert.Equal(t, domain.ErrTwoFactorCodeInvalid, err)
+		m.tokenRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything)
+	})
+
+	t.Run("with a recovery code", func(t *testing.T) {
+		m, userService := setupUserService(clockAt(now))
+
+		m.tokenRepo.On("ConsumeUserToken", domain.TokenPurposeTwoFactor, domain.HashToken("challenge"), now).
+			Return(&domain.UserToken{UserID: user.ID}, nil).Once()
+		m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+		m.tokenRepo.On("ConsumeRecoveryCode", user.ID, domain.HashToken("abcdefghij"), now).Return(nil).Once()
+		m.tokenRepo.On("SaveSession", mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("SaveRefreshToken", mock.Anything).Return(nil).Once()
+
+		_, err := userService.LoginTwoFactor(&domain.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "ABCDE-FGHIJ"})
+
+		assert.NoError(t, err)
+		m.tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired or used challenge", func(t *testing.T) {
+		m, userService := setupUserService(clockAt(now))
+
+		m.tokenRepo.On("ConsumeUserToken", domain.TokenPurposeTwoFactor, domain.HashToken("challenge"), now).
+			Return(nil, clients.ErrRecordNotFound).Once()
+
+		_, err := userService.LoginTwoFactor(&domain.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: totpCode(t, now)})
+
+		assert.Equal(t, domain.ErrTwoFactorChallengeInvalid, err)
+	})
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	enabledAt := now.Add(-time.Hour)
+	user := &domain.User{ID: uuid.New(), TOTPSecret: totpSecret, TOTPEnabledAt: &enabledAt}
+
+	m, userService := setupUserService(clockAt(now))
+
+	m.userRepo.On("GetUser", map[string]any{"id": user.ID}).Return(user, nil).Once()
+	m.userRepo.On("UseTOTPStep", user.ID, totp.Step(now)).Return(nil).Once()
+	m.userRepo.On("SetTOTPSecret", user.ID, "").Return(nil).Once()
+	m.tokenRepo.On("ReplaceRecoveryCodes", user.ID, []domain.RecoveryCode(nil)).Return(nil).Once()
+
+	err := userService.DisableTwoFactor(user.ID, &domain.TwoFactorCodeRequest{Code: totpCode(t, now)})
+
+	assert.NoError(t, err)
+	m.userRepo.AssertExpectations(t)
+	m.tokenRepo.AssertExpectations(t)
+}
@@ -0,0 +1,68 @@
+package workspace
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+// ItemAccessResolver decides the access to the personal items of users.
+//
+//go:generate mockery --name ItemAccessResolver
+type ItemAccessResolver interface {
+	ItemAccess(item domain.Item, userID uuid.UUID) (domain.Access, error)
+}
+
+//go:generate mockery --name MemberGetter
+type MemberGetter interface {
+	GetMember(conditions map[string]any) (*domain.Membership, error)
+}
+
+type permissionResolver struct {
+	members  MemberGetter
+	personal ItemAccessResolver
+}
+
+// NewPermissionResolver creates the resolver deciding every access to items
+// and workspaces. The items of a workspace are governed by the role of the
+// user in it, with members owning the items they created; personal items are
+// left to the personal resolver.
+func NewPermissionResolver(members MemberGetter, personal ItemAccessResolver) *permissionResolver {
+	return &permissionResolver{
+		members:  members,
+		personal: personal,
+	}
+}
+
+func (r *permissionResolver) ItemAccess(item domain.Item, userID uuid.UUID) (domain.Access, error) {
+	if item.WorkspaceID == nil {
+		return r.personal.ItemAccess(item, userID)
+	}
+
+	access, err := r.WorkspaceAccess(*item.WorkspaceID, userID)
+	if err != nil {
+		return domain.AccessNone, err
+	}
+
+	if access == domain.AccessEdit && item.UserID == userID {
+		return domain.AccessOwner, nil
+	}
+
+	return access, nil
+}
+
+// WorkspaceAccess returns the access the role of the user gives to the data
+// of the workspace, none for users outside of it.
+func (r *permissionResolver) WorkspaceAccess(workspaceID, userID uuid.UUID) (domain.Access, error) {
+	member, err := r.members.GetMember(map[string]any{"workspace_id": workspaceID, "user_id": userID})
+	if errors.Is(err, clients.ErrRecordNotFound) {
+		return domain.AccessNone, nil
+	}
+	if err != nil {
+		return domain.AccessNone, err
+	}
+
+	return member.Role.Access(), nil
+}
@@ -0,0 +1,67 @@
+package workspace_test
+
+import (
+	"testing"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/workspace"
+	"todo-app/workspace/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemAccess(t *testing.T) {
+	workspaceID, creatorID, userID := uuid.New(), uuid.New(), uuid.New()
+	item := domain.Item{ID: uuid.New(), UserID: creatorID, WorkspaceID: &workspaceID}
+
+	cases := []struct {
+		name   string
+		userID uuid.UUID
+		role   domain.WorkspaceRole
+		want   domain.Access
+	}{
+		{"outsider", userID, "", domain.AccessNone},
+		{"viewer", userID, domain.WorkspaceViewer, domain.AccessView},
+		{"member", userID, domain.WorkspaceMember, domain.AccessEdit},
+		{"member who created the item", creatorID, domain.WorkspaceMember, domain.AccessOwner},
+		{"creator who left the workspace", creatorID, "", domain.AccessNone},
+		{"admin", userID, domain.WorkspaceAdmin, domain.AccessOwner},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			members, personal := new(mocks.MemberGetter), new(mocks.ItemAccessResolver)
+			resolver := service.NewPermissionResolver(members, personal)
+
+			call := members.On("GetMember", map[string]any{"workspace_id": workspaceID, "user_id": c.userID})
+			if c.role == "" {
+				call.Return(nil, clients.ErrRecordNotFound)
+			} else {
+				call.Return(&domain.Membership{Role: c.role}, nil)
+			}
+
+			access, err := resolver.ItemAccess(item, c.userID)
+
+			require.NoError(t, err)
+			assert.Equal(t, c.want, access)
+			personal.AssertNotCalled(t, "ItemAccess", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("personal items", func(t *testing.T) {
+		members, personal := new(mocks.MemberGetter), new(mocks.ItemAccessResolver)
+		resolver := service.NewPermissionResolver(members, personal)
+
+		personalItem := domain.Item{ID: uuid.New(), UserID: creatorID}
+		personal.On("ItemAccess", personalItem, userID).Return(domain.AccessView, nil).Once()
+
+		access, err := resolver.ItemAccess(personalItem, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AccessView, access)
+		members.AssertNotCalled(t, "GetMember", mock.Anything)
+	})
+}
@@ -0,0 +1,329 @@
+package workspace
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name WorkspaceRepo
+type WorkspaceRepo interface {
+	// Create saves the workspace together with the membership of its owner.
+	Create(workspace *domain.Workspace, owner *domain.Membership) error
+	GetWorkspace(conditions map[string]any) (*domain.Workspace, error)
+	ListWorkspaces(ids []uuid.UUID) ([]domain.Workspace, error)
+	Update(id uuid.UUID, name string, updatedAt time.Time) error
+	// Delete removes the workspace with its members, lists and items.
+	Delete(id uuid.UUID) error
+	GetMember(conditions map[string]any) (*domain.Membership, error)
+	// ListMembers returns the matching memberships with the emails of the
+	// members, oldest first.
+	ListMembers(conditions map[string]any) ([]domain.Membership, error)
+	CountMembers(conditions map[string]any) (int64, error)
+	SaveMember(member *domain.Membership) error
+	UpdateMember(workspaceID, userID uuid.UUID, role domain.WorkspaceRole) error
+	DeleteMember(workspaceID, userID uuid.UUID) error
+}
+
+//go:generate mockery --name UserGetter
+type UserGetter interface {
+	GetUser(conditions map[string]any) (*domain.User, error)
+}
+
+type workspaceService struct {
+	repo  WorkspaceRepo
+	users UserGetter
+}
+
+func NewWorkspaceService(repo WorkspaceRepo, users UserGetter) *workspaceService {
+	return &workspaceService{
+		repo:  repo,
+		users: users,
+	}
+}
+
+// CreateWorkspace creates a workspace owned by the user.
+func (s *workspaceService) CreateWorkspace(userID uuid.UUID, data *domain.WorkspaceCreation) (*domain.Workspace, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	workspace := &domain.Workspace{ID: uuid.New(), Name: data.Name, CreatedBy: userID}
+	owner := &domain.Membership{WorkspaceID: workspace.ID, UserID: userID, Role: domain.WorkspaceOwner}
+
+	if err := s.repo.Create(workspace, owner); err != nil {
+		return nil, clients.ErrCannotCreateEntity(workspace.TableName(), err)
+	}
+
+	workspace.Role = owner.Role
+
+	return workspace, nil
+}
+
+// GetWorkspaces lists the workspaces the user is a member of, with their role
+// in each.
+func (s *workspaceService) GetWorkspaces(userID uuid.UUID) ([]domain.Workspace, error) {
+	memberships, err := s.repo.ListMembers(map[string]any{"user_id": userID})
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Workspace{}.TableName(), err)
+	}
+
+	roles := make(map[uuid.UUID]domain.WorkspaceRole, len(memberships))
+	ids := make([]uuid.UUID, 0, len(memberships))
+	for _, membership := range memberships {
+		roles[membership.WorkspaceID] = membership.Role
+		ids = append(ids, membership.WorkspaceID)
+	}
+
+	workspaces, err := s.repo.ListWorkspaces(ids)
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Workspace{}.TableName(), err)
+	}
+
+	for i := range workspaces {
+		workspaces[i].Role = roles[workspaces[i].ID]
+	}
+
+	return workspaces, nil
+}
+
+// GetWorkspace returns a workspace the user is a member of.
+func (s *workspaceService) GetWorkspace(id, userID uuid.UUID) (*domain.Workspace, error) {
+	membership, err := s.GetMembership(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.repo.GetWorkspace(map[string]any{"id": id})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.Workspace{}.TableName(), err)
+	}
+
+	workspace.Role = membership.Role
+
+	return workspace, nil
+}
+
+// UpdateWorkspace renames the workspace. It takes an admin.
+func (s *workspaceService) UpdateWorkspace(id, userID uuid.UUID, data *domain.WorkspaceCreation) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	if _, err := s.authorize(id, userID, domain.WorkspaceAdmin); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(id, data.Name, time.Now()); err != nil {
+		return clients.ErrCannotUpdateEntity(domain.Workspace{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// DeleteWorkspace deletes the workspace together with its lists and items.
+// It takes an owner.
+func (s *workspaceService) DeleteWorkspace(id, userID uuid.UUID) error {
+	if _, err := s.authorize(id, userID, domain.WorkspaceOwner); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return clients.ErrCannotDeleteEntity(domain.Workspace{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// GetMembers lists the members of a workspace the user is a member of, with
+// their emails.
+func (s *workspaceService) GetMembers(id, userID uuid.UUID) ([]domain.Membership, error) {
+	if _, err := s.GetMembership(id, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.ListMembers(map[string]any{"workspace_id": id})
+	if err != nil {
+		return nil, clients.ErrCannotListEntity(domain.Membership{}.TableName(), err)
+	}
+
+	return members, nil
+}
+
+// AddMember adds the user registered with the email to the workspace. It
+// takes an admin, and an owner to add another owner.
+func (s *workspaceService) AddMember(id, userID uuid.UUID, data *domain.MemberCreation) (*domain.Membership, error) {
+	if err := data.Validate(); err != nil {
+		return nil, clients.ErrInvalidRequest(err)
+	}
+
+	actor, err := s.authorize(id, userID, domain.WorkspaceAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := grantable(actor, data.Role); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetUser(map[string]any{"email": data.Email})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.EntityName, err)
+	}
+
+	if _, err := s.repo.GetMember(map[string]any{"workspace_id": id, "user_id": user.ID}); err == nil {
+		return nil, domain.ErrMemberExisted
+	} else if !errors.Is(err, clients.ErrRecordNotFound) {
+		return nil, clients.ErrCannotGetEntity(domain.Membership{}.TableName(), err)
+	}
+
+	member := &domain.Membership{WorkspaceID: id, UserID: user.ID, Role: data.Role, Email: user.Email}
+	if err := s.repo.SaveMember(member); err != nil {
+		return nil, clients.ErrCannotCreateEntity(member.TableName(), err)
+	}
+
+	return member, nil
+}
+
+// UpdateMember changes the role of a member. It takes an admin, and an owner
+// when an owner is involved. The last owner can not step down.
+func (s *workspaceService) UpdateMember(id, userID, memberID uuid.UUID, data *domain.MemberUpdate) error {
+	if err := data.Validate(); err != nil {
+		return clients.ErrInvalidRequest(err)
+	}
+
+	actor, err := s.authorize(id, userID, domain.WorkspaceAdmin)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.getMember(id, memberID)
+	if err != nil {
+		return err
+	}
+
+	if err := grantable(actor, member.Role); err != nil {
+		return err
+	}
+
+	if err := grantable(actor, data.Role); err != nil {
+		return err
+	}
+
+	if member.Role == domain.WorkspaceOwner && data.Role != domain.WorkspaceOwner {
+		if err := s.checkOtherOwner(id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.UpdateMember(id, memberID, data.Role); err != nil {
+		return clients.ErrCannotUpdateEntity(member.TableName(), err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes a member from the workspace. Members can leave by
+// themselves; removing others takes an admin, and an owner to remove an
+// owner. The last owner can not leave.
+func (s *workspaceService) RemoveMember(id, userID, memberID uuid.UUID) error {
+	actor, err := s.GetMembership(id, userID)
+	if err != nil {
+		return err
+	}
+
+	member := actor
+	if memberID != userID {
+		if !actor.Role.Has(domain.WorkspaceAdmin) {
+			return errRole(domain.WorkspaceAdmin)
+		}
+
+		if member, err = s.getMember(id, memberID); err != nil {
+			return err
+		}
+
+		if err := grantable(actor, member.Role); err != nil {
+			return err
+		}
+	}
+
+	if member.Role == domain.WorkspaceOwner {
+		if err := s.checkOtherOwner(id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.DeleteMember(id, memberID); err != nil {
+		return clients.ErrCannotDeleteEntity(member.TableName(), err)
+	}
+
+	return nil
+}
+
+// GetMembership returns the membership of the user in the workspace, or
+// ErrNotWorkspaceMember.
+func (s *workspaceService) GetMembership(id, userID uuid.UUID) (*domain.Membership, error) {
+	membership, err := s.repo.GetMember(map[string]any{"workspace_id": id, "user_id": userID})
+	if errors.Is(err, clients.ErrRecordNotFound) {
+		return nil, domain.ErrNotWorkspaceMember
+	}
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.Membership{}.TableName(), err)
+	}
+
+	return membership, nil
+}
+
+// authorize returns the membership of the user, refusing users without the
+// needed role in the workspace.
+func (s *workspaceService) authorize(id, userID uuid.UUID, need domain.WorkspaceRole) (*domain.Membership, error) {
+	membership, err := s.GetMembership(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !membership.Role.Has(need) {
+		return nil, errRole(need)
+	}
+
+	return membership, nil
+}
+
+func (s *workspaceService) getMember(id, userID uuid.UUID) (*domain.Membership, error) {
+	member, err := s.repo.GetMember(map[string]any{"workspace_id": id, "user_id": userID})
+	if err != nil {
+		return nil, clients.ErrCannotGetEntity(domain.Membership{}.TableName(), err)
+	}
+
+	return member, nil
+}
+
+// checkOtherOwner refuses to take away an owner when they are the only one.
+func (s *workspaceService) checkOtherOwner(id uuid.UUID) error {
+	owners, err := s.repo.CountMembers(map[string]any{"workspace_id": id, "role": domain.WorkspaceOwner})
+	if err != nil {
+		return clients.ErrCannotGetEntity(domain.Membership{}.TableName(), err)
+	}
+
+	if owners <= 1 {
+		return domain.ErrLastOwner
+	}
+
+	return nil
+}
+
+// grantable refuses admins granting, changing or taking away the owner role.
+func grantable(actor *domain.Membership, role domain.WorkspaceRole) error {
+	if role == domain.WorkspaceOwner && actor.Role != domain.WorkspaceOwner {
+		return errRole(domain.WorkspaceOwner)
+	}
+
+	return nil
+}
+
+func errRole(role domain.WorkspaceRole) *clients.AppError {
+	return clients.ErrNoPermission(errors.New("requires the " + string(role) + " role in the workspace"))
+}
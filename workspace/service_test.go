@@ -0,0 +1,229 @@
+package workspace_test
+
+import (
+	"testing"
+	"todo-app/domain"
+	"todo-app/pkg/clients"
+	service "todo-app/workspace"
+	"todo-app/workspace/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// workspaceService lists the methods under test, as the service type itself
+// is unexported.
+type workspaceService interface {
+	CreateWorkspace(userID uuid.UUID, data *domain.WorkspaceCreation) (*domain.Workspace, error)
+	GetWorkspaces(userID uuid.UUID) ([]domain.Workspace, error)
+	GetWorkspace(id, userID uuid.UUID) (*domain.Workspace, error)
+	DeleteWorkspace(id, userID uuid.UUID) error
+	GetMembers(id, userID uuid.UUID) ([]domain.Membership, error)
+	AddMember(id, userID uuid.UUID, data *domain.MemberCreation) (*domain.Membership, error)
+	UpdateMember(id, userID, memberID uuid.UUID, data *domain.MemberUpdate) error
+	RemoveMember(id, userID, memberID uuid.UUID) error
+}
+
+func setupWorkspaceService() (*mocks.WorkspaceRepo, *mocks.UserGetter, workspaceService) {
+	repo, users := new(mocks.WorkspaceRepo), new(mocks.UserGetter)
+
+	return repo, users, service.NewWorkspaceService(repo, users)
+}
+
+// member expects the membership lookup of the user in the workspace.
+func member(repo *mocks.WorkspaceRepo, workspaceID, userID uuid.UUID, role domain.WorkspaceRole) {
+	call := repo.On("GetMember", map[string]any{"workspace_id": workspaceID, "user_id": userID})
+	if role == "" {
+		call.Return(nil, clients.ErrRecordNotFound)
+		return
+	}
+
+	call.Return(&domain.Membership{WorkspaceID: workspaceID, UserID: userID, Role: role}, nil)
+}
+
+func TestCreateWorkspace(t *testing.T) {
+	repo, _, workspaceService := setupWorkspaceService()
+	userID := uuid.New()
+
+	repo.On("Create", mock.MatchedBy(func(workspace *domain.Workspace) bool {
+		return workspace.Name == "Platform" && workspace.CreatedBy == userID
+	}), mock.MatchedBy(func(owner *domain.Membership) bool {
+		return owner.UserID == userID && owner.Role == domain.WorkspaceOwner
+	})).Return(nil).Once()
+
+	workspace, err := workspaceService.CreateWorkspace(userID, &domain.WorkspaceCreation{Name: " Platform "})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkspaceOwner, workspace.Role)
+	repo.AssertExpectations(t)
+}
+
+func TestGetWorkspaces(t *testing.T) {
+	repo, _, workspaceService := setupWorkspaceService()
+	userID, platformID, mobileID := uuid.New(), uuid.New(), uuid.New()
+
+	repo.On("ListMembers", map[string]any{"user_id": userID}).Return([]domain.Membership{
+		{WorkspaceID: platformID, UserID: userID, Role: domain.WorkspaceOwner},
+		{WorkspaceID: mobileID, UserID: userID, Role: domain.WorkspaceViewer},
+	}, nil).Once()
+	repo.On("ListWorkspaces", []uuid.UUID{platformID, mobileID}).Return([]domain.Workspace{
+		{ID: mobileID, Name: "Mobile"}, {ID: platformID, Name: "Platform"},
+	}, nil).Once()
+
+	workspaces, err := workspaceService.GetWorkspaces(userID)
+
+	require.NoError(t, err)
+	require.Len(t, workspaces, 2)
+	assert.Equal(t, domain.WorkspaceViewer, workspaces[0].Role)
+	assert.Equal(t, domain.WorkspaceOwner, workspaces[1].Role)
+}
+
+func TestWorkspaceOutsiders(t *testing.T) {
+	repo, _, workspaceService := setupWorkspaceService()
+	workspaceID, outsiderID := uuid.New(), uuid.New()
+	member(repo, workspaceID, outsiderID, "")
+
+	_, err := workspaceService.GetWorkspace(workspaceID, outsiderID)
+	assert.ErrorIs(t, err, domain.ErrNotWorkspaceMember)
+
+	_, err = workspaceService.GetMembers(workspaceID, outsiderID)
+	assert.ErrorIs(t, err, domain.ErrNotWorkspaceMember)
+
+	_, err = workspaceService.AddMember(workspaceID, outsiderID, &domain.MemberCreation{Email: "eve@example.com"})
+	assert.ErrorIs(t, err, domain.ErrNotWorkspaceMember)
+
+	assert.ErrorIs(t, workspaceService.DeleteWorkspace(workspaceID, outsiderID), domain.ErrNotWorkspaceMember)
+
+	repo.AssertNotCalled(t, "GetWorkspace", mock.Anything)
+	repo.AssertNotCalled(t, "ListMembers", mock.Anything)
+	repo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestAddMember(t *testing.T) {
+	workspaceID, adminID := uuid.New(), uuid.New()
+	ann := &domain.User{ID: uuid.New(), Email: "ann@example.com"}
+
+	t.Run("success", func(t *testing.T) {
+		repo, users, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, adminID, domain.WorkspaceAdmin)
+		users.On("GetUser", map[string]any{"email": "ann@example.com"}).Return(ann, nil).Once()
+		member(repo, workspaceID, ann.ID, "")
+		repo.On("SaveMember", &domain.Membership{WorkspaceID: workspaceID, UserID: ann.ID, Role: domain.WorkspaceMember, Email: ann.Email}).Return(nil).Once()
+
+		added, err := workspaceService.AddMember(workspaceID, adminID, &domain.MemberCreation{Email: "Ann@Example.com"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.WorkspaceMember, added.Role)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("twice", func(t *testing.T) {
+		repo, users, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, adminID, domain.WorkspaceAdmin)
+		users.On("GetUser", mock.Anything).Return(ann, nil).Once()
+		member(repo, workspaceID, ann.ID, domain.WorkspaceViewer)
+
+		_, err := workspaceService.AddMember(workspaceID, adminID, &domain.MemberCreation{Email: ann.Email})
+
+		assert.ErrorIs(t, err, domain.ErrMemberExisted)
+	})
+
+	t.Run("members can not add", func(t *testing.T) {
+		repo, users, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, adminID, domain.WorkspaceMember)
+
+		_, err := workspaceService.AddMember(workspaceID, adminID, &domain.MemberCreation{Email: ann.Email})
+
+		require.Error(t, err)
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		users.AssertNotCalled(t, "GetUser", mock.Anything)
+	})
+
+	t.Run("admins can not add owners", func(t *testing.T) {
+		repo, _, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, adminID, domain.WorkspaceAdmin)
+
+		_, err := workspaceService.AddMember(workspaceID, adminID, &domain.MemberCreation{Email: ann.Email, Role: domain.WorkspaceOwner})
+
+		require.Error(t, err)
+		assert.Equal(t, "ErrNoPermission", err.(*clients.AppError).Key)
+		repo.AssertNotCalled(t, "SaveMember", mock.Anything)
+	})
+}
+
+func TestUpdateMember(t *testing.T) {
+	workspaceID, ownerID, memberID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("promote", func(t *testing.T) {
+		repo, _, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, ownerID, domain.WorkspaceOwner)
+		member(repo, workspaceID, memberID, domain.WorkspaceMember)
+		repo.On("UpdateMember", workspaceID, memberID, domain.WorkspaceAdmin).Return(nil).Once()
+
+		err := workspaceService.UpdateMember(workspaceID, ownerID, memberID, &domain.MemberUpdate{Role: domain.WorkspaceAdmin})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("last owner steps down", func(t *testing.T) {
+		repo, _, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, ownerID, domain.WorkspaceOwner)
+		repo.On("CountMembers", map[string]any{"workspace_id": workspaceID, "role": domain.WorkspaceOwner}).Return(int64(1), nil).Once()
+
+		err := workspaceService.UpdateMember(workspaceID, ownerID, ownerID, &domain.MemberUpdate{Role: domain.WorkspaceAdmin})
+
+		assert.ErrorIs(t, err, domain.ErrLastOwner)
+		repo.AssertNotCalled(t, "UpdateMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRemoveMember(t *testing.T) {
+	workspaceID, ownerID, adminID, memberID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("member leaves", func(t *testing.T) {
+		repo, _, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, memberID, domain.WorkspaceMember)
+		repo.On("DeleteMember", workspaceID, memberID).Return(nil).Once()
+
+		assert.NoError(t, workspaceService.RemoveMember(workspaceID, memberID, memberID))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("member removes another", func(t *testing.T) {
+		repo, _, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, memberID, domain.WorkspaceMember)
+
+		assert.Error(t, workspaceService.RemoveMember(workspaceID, memberID, adminID))
+		repo.AssertNotCalled(t, "DeleteMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("admin removes the owner", func(t *testing.T) {
+		repo, _, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, adminID, domain.WorkspaceAdmin)
+		member(repo, workspaceID, ownerID, domain.WorkspaceOwner)
+
+		assert.Error(t, workspaceService.RemoveMember(workspaceID, adminID, ownerID))
+		repo.AssertNotCalled(t, "DeleteMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("last owner leaves", func(t *testing.T) {
+		repo, _, workspaceService := setupWorkspaceService()
+
+		member(repo, workspaceID, ownerID, domain.WorkspaceOwner)
+		repo.On("CountMembers", mock.Anything).Return(int64(1), nil).Once()
+
+		assert.ErrorIs(t, workspaceService.RemoveMember(workspaceID, ownerID, ownerID), domain.ErrLastOwner)
+	})
+}